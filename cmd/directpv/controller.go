@@ -18,15 +18,32 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/directpv/pkg/admission"
+	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/csi/controller"
 	pkgidentity "github.com/minio/directpv/pkg/csi/identity"
+	"github.com/minio/directpv/pkg/node"
+	"github.com/minio/directpv/pkg/quota"
+	"github.com/minio/directpv/pkg/volume"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/klog/v2"
 )
 
+// leaseName is the lease to elect the controller replica running cluster-wide controllers.
+const leaseName = consts.Identity + "-controller"
+
+var kubeNodePolicy node.KubeNodePolicy
+
 var controllerCmd = &cobra.Command{
 	Use:           consts.ControllerServerName,
 	Short:         "Start controller server.",
@@ -38,6 +55,54 @@ var controllerCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	controllerCmd.PersistentFlags().BoolVar(&kubeNodePolicy.MarkDrivesLost, "mark-lost-on-node-deletion", kubeNodePolicy.MarkDrivesLost, "Mark drives of deleted Kubernetes nodes as lost")
+	controllerCmd.PersistentFlags().BoolVar(&kubeNodePolicy.MirrorUnschedulable, "mirror-node-unschedulable", kubeNodePolicy.MirrorUnschedulable, "Cordon/uncordon drives as their Kubernetes nodes are cordoned/uncordoned")
+	controllerCmd.PersistentFlags().BoolVar(&kubeNodePolicy.RemoveOrphanNodes, "remove-orphan-nodes", kubeNodePolicy.RemoveOrphanNodes, "Remove node objects of deleted Kubernetes nodes")
+}
+
+// runLeaderControllers runs controllers which must run in one controller replica only; they are
+// started after this replica acquires the lease and an error is returned when the lease is lost.
+func runLeaderControllers(ctx context.Context) error {
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: consts.AppName,
+		},
+		Client:     client.GetClient().Kube().CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: id},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.InfoS("Acquired lease; starting controllers", "lease", leaseName, "identity", id)
+				go quota.StartController(ctx)
+				go node.StartKubeNodeController(ctx, kubeNodePolicy)
+				go volume.StartShrinkController(ctx)
+			},
+			OnStoppedLeading: func() {
+				klog.InfoS("Lost lease", "lease", leaseName, "identity", id)
+			},
+		},
+	})
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("lease %v lost", leaseName)
+}
+
 func startController(ctx context.Context) error {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
@@ -76,5 +141,23 @@ func startController(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		if err := runLeaderControllers(ctx); err != nil {
+			klog.ErrorS(err, "unable to run controllers")
+			errCh <- err
+		}
+	}()
+
+	go func() {
+		err := admission.Serve(ctx, consts.AdmissionWebhookPort, consts.AdmissionWebhookCertDir)
+		switch {
+		case errors.Is(err, admission.ErrCertNotFound):
+			klog.InfoS("Admission webhook certificate not found; admission webhook disabled", "certDir", consts.AdmissionWebhookCertDir)
+		case err != nil:
+			klog.ErrorS(err, "unable to start admission webhook")
+			errCh <- err
+		}
+	}()
+
 	return <-errCh
 }
@@ -29,6 +29,7 @@ import (
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/topology"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
@@ -45,6 +46,10 @@ var (
 	rack                 = "default"
 	zone                 = "default"
 	region               = "default"
+	rackLabel            = ""
+	zoneLabel            = ""
+	regionLabel          = ""
+	topologyKeys         []string
 	csiEndpoint          = installer.UnixCSIEndpoint
 	kubeconfig           = ""
 	conversionHealthzURL = ""
@@ -101,6 +106,10 @@ func init() {
 	mainCmd.PersistentFlags().StringVar(&rack, "rack", rack, "Rack ID of "+consts.AppPrettyName+" instances")
 	mainCmd.PersistentFlags().StringVar(&zone, "zone", zone, "Zone ID of "+consts.AppPrettyName+" instances")
 	mainCmd.PersistentFlags().StringVar(&region, "region", region, "Region ID of "+consts.AppPrettyName+" instances")
+	mainCmd.PersistentFlags().StringVar(&rackLabel, "rack-label", rackLabel, "Kubernetes node label key to read rack ID from; --rack is used if the label is not set")
+	mainCmd.PersistentFlags().StringVar(&zoneLabel, "zone-label", zoneLabel, "Kubernetes node label key to read zone ID from e.g. topology.kubernetes.io/zone; --zone is used if the label is not set")
+	mainCmd.PersistentFlags().StringVar(&regionLabel, "region-label", regionLabel, "Kubernetes node label key to read region ID from e.g. topology.kubernetes.io/region; --region is used if the label is not set")
+	mainCmd.PersistentFlags().StringSliceVar(&topologyKeys, "topology-keys", topologyKeys, "Additional Kubernetes node label keys to be added to topology")
 	mainCmd.PersistentFlags().StringVar(&conversionHealthzURL, "conversion-healthz-url", conversionHealthzURL, "URL to conversion webhook health endpoint")
 	mainCmd.PersistentFlags().IntVar(&readinessPort, "readiness-port", readinessPort, "Readiness port at "+consts.AppPrettyName+" exports readiness of services")
 
@@ -149,3 +158,17 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func newTopologyResolver() *topology.Resolver {
+	return topology.NewResolver(topology.Config{
+		Identity:    identity,
+		NodeID:      nodeID,
+		Rack:        rack,
+		Zone:        zone,
+		Region:      region,
+		RackLabel:   rackLabel,
+		ZoneLabel:   zoneLabel,
+		RegionLabel: regionLabel,
+		ExtraKeys:   topologyKeys,
+	})
+}
@@ -20,15 +20,19 @@ import (
 	"context"
 	"errors"
 	"os"
+	"time"
 
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/initrequest"
 	"github.com/minio/directpv/pkg/node"
 	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/topology"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 )
 
+var deviceWatchDebounce = 2 * time.Second
+
 var nodeControllerCmd = &cobra.Command{
 	Use:           consts.NodeControllerName,
 	Short:         "Start node controller.",
@@ -46,6 +50,10 @@ var nodeControllerCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	nodeControllerCmd.PersistentFlags().DurationVar(&deviceWatchDebounce, "device-watch-debounce", deviceWatchDebounce, "Wait period after the latest block device event to re-probe changed devices; 0 disables device watch")
+}
+
 func startNodeController(ctx context.Context) error {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
@@ -58,17 +66,29 @@ func startNodeController(ctx context.Context) error {
 		errCh <- errors.New("node controller stopped")
 	}()
 
+	resolver := newTopologyResolver()
+	if err := resolver.Refresh(ctx); err != nil {
+		klog.ErrorS(err, "unable to refresh node topology", "node", nodeID)
+	}
+
+	go func() {
+		topology.StartController(ctx, resolver)
+		errCh <- errors.New("topology controller stopped")
+	}()
+
 	go func() {
-		initrequest.StartController(
-			ctx,
-			nodeID,
-			identity,
-			rack,
-			zone,
-			region,
-		)
+		initrequest.StartController(ctx, nodeID, resolver)
 		errCh <- errors.New("initrequest controller stopped")
 	}()
 
+	if deviceWatchDebounce > 0 {
+		go func() {
+			// Device watch is optional; devices are still refreshed by the node controller.
+			if err := node.StartDeviceWatcher(ctx, nodeID, deviceWatchDebounce); err != nil {
+				klog.ErrorS(err, "unable to start device watcher")
+			}
+		}()
+	}
+
 	return <-errCh
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/minio/directpv/pkg/csi/node"
 	"github.com/minio/directpv/pkg/device"
 	"github.com/minio/directpv/pkg/drive"
+	"github.com/minio/directpv/pkg/snapshot"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/volume"
 	"github.com/spf13/cobra"
@@ -90,13 +91,15 @@ func startNodeServer(ctx context.Context) error {
 		errCh <- errors.New("drive controller stopped")
 	}()
 
+	go func() {
+		snapshot.StartController(ctx, nodeID)
+		errCh <- errors.New("snapshot controller stopped")
+	}()
+
 	nodeServer := node.NewServer(
 		ctx,
-		identity,
 		nodeID,
-		rack,
-		zone,
-		region,
+		newTopologyResolver(),
 		metricsPort,
 	)
 	klog.V(3).Infof("Node server started")
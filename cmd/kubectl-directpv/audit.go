@@ -0,0 +1,192 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+const defaultAuditLogFile = "operations.log"
+
+var auditSinkValues = []string{"file", "configmap", "none"}
+
+var (
+	auditOperationArgs []string      // --operations flag
+	auditUserArgs      []string      // --users flag
+	auditTargetArgs    []string      // --targets flag
+	auditSince         time.Duration // --since flag
+	auditLimit         int           // --limit flag
+)
+
+var auditCmd = &cobra.Command{
+	Use:           "audit",
+	Short:         "Show audit log of mutating operations",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Show all audit entries from the local audit log
+   $ kubectl {PLUGIN_NAME} audit
+
+2. Show audit entries from the in-cluster audit log
+   $ kubectl {PLUGIN_NAME} audit --audit-sink=configmap
+
+3. Show cordon and remove operations of the last 24 hours
+   $ kubectl {PLUGIN_NAME} audit --operations=cordon,remove --since=24h
+
+4. Show the latest 10 operations touching a drive in JSON
+   $ kubectl {PLUGIN_NAME} audit --targets=af3b8b4c-73b4-4a74-84b7-1ec30492a6f0 --limit=10 -o json`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, _ []string) {
+		if err := validateAuditCmd(); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		auditMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(auditCmd)
+
+	auditCmd.PersistentFlags().StringSliceVar(&auditOperationArgs, "operations", auditOperationArgs, "Filter output by operations")
+	auditCmd.PersistentFlags().StringSliceVar(&auditUserArgs, "users", auditUserArgs, "Filter output by users")
+	auditCmd.PersistentFlags().StringSliceVar(&auditTargetArgs, "targets", auditTargetArgs, "Filter output by target object names")
+	auditCmd.PersistentFlags().DurationVar(&auditSince, "since", auditSince, "Show entries newer than a relative duration like 5m, 1h or 24h")
+	auditCmd.PersistentFlags().IntVar(&auditLimit, "limit", auditLimit, "Show only the latest N entries")
+	addOutputFormatFlag(auditCmd, "Output format. One of: json|yaml")
+	auditCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", noHeaders, "When using the default output, don't print headers")
+}
+
+func validateAuditCmd() error {
+	if err := validateOutputFormat(false); err != nil {
+		return err
+	}
+	if auditLimit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	if auditSince < 0 {
+		return errors.New("--since must not be negative")
+	}
+	return nil
+}
+
+func newFileAuditSink() (*admin.FileAuditSink, error) {
+	auditDir, err := getDefaultAuditDir()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get default audit directory; %w", err)
+	}
+	if err := os.MkdirAll(auditDir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create default audit directory; %w", err)
+	}
+	return admin.NewFileAuditSink(path.Join(auditDir, defaultAuditLogFile)), nil
+}
+
+func setAuditSink() error {
+	switch auditSinkFlag {
+	case "file":
+		sink, err := newFileAuditSink()
+		if err != nil {
+			return err
+		}
+		adminClient.SetAuditSink(sink)
+	case "configmap":
+		adminClient.SetAuditSink(admin.NewConfigMapAuditSink(adminClient.Kube(), admin.DefaultAuditConfigMapEntries))
+	case "none":
+		adminClient.SetAuditSink(nil)
+	default:
+		return fmt.Errorf("--audit-sink flag value must be one of %v", strings.Join(auditSinkValues, "|"))
+	}
+	return nil
+}
+
+func auditTargetsString(entry admin.AuditEntry) string {
+	var names []string
+	for _, target := range entry.Targets {
+		names = append(names, target.Kind+"/"+target.Name)
+	}
+	return printableString(strings.Join(names, ","))
+}
+
+func auditMain(ctx context.Context) {
+	args := admin.AuditQueryArgs{
+		Users:   auditUserArgs,
+		Targets: auditTargetArgs,
+		Limit:   auditLimit,
+	}
+	for _, operation := range auditOperationArgs {
+		args.Operations = append(args.Operations, admin.AuditOperation(strings.TrimSpace(operation)))
+	}
+	if auditSince > 0 {
+		args.Since = time.Now().Add(-auditSince)
+	}
+
+	entries, err := adminClient.QueryAudit(ctx, args)
+	if err != nil {
+		eprintf(true, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if dryRunPrinter != nil {
+		dryRunPrinter(entries)
+		return
+	}
+
+	if len(entries) == 0 {
+		eprintf(false, "No audit entries found\n")
+		return
+	}
+
+	writer := newTableWriter(
+		table.Row{
+			"TIME",
+			"USER",
+			"OPERATION",
+			"DRY-RUN",
+			"TARGETS",
+			"ERROR",
+		},
+		nil,
+		noHeaders,
+	)
+	for _, entry := range entries {
+		writer.AppendRow(
+			[]any{
+				entry.Time.Local().Format(time.RFC3339),
+				entry.User,
+				entry.Operation,
+				entry.DryRun,
+				auditTargetsString(entry),
+				printableString(entry.Error),
+			},
+		)
+	}
+	writer.Render()
+}
@@ -0,0 +1,98 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var backupFileArg string // --output-file flag
+
+var backupCmd = &cobra.Command{
+	Use:           "backup",
+	Short:         "Backup drives, volumes, quotas and persistent volumes",
+	Long:          "Backup " + consts.AppPrettyName + " drives, volumes, quotas and their persistent volumes into a versioned archive. The archive is used by restore command to re-create them. Nodes are not backed up as they are re-created by node servers.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Backup to the default file directpv-backup-<TIMESTAMP>.yaml
+   $ kubectl {PLUGIN_NAME} backup
+
+2. Backup to file 'backup.yaml'
+   $ kubectl {PLUGIN_NAME} backup --output-file=backup.yaml`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, _ []string) {
+		if err := validateBackupCmd(); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		backupMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(backupCmd)
+
+	backupCmd.PersistentFlags().StringVar(&backupFileArg, "output-file", backupFileArg, "output file to write the backup")
+}
+
+func validateBackupCmd() error {
+	backupFileArg = strings.TrimSpace(backupFileArg)
+	if backupFileArg == "" {
+		backupFileArg = consts.AppName + "-backup-" + time.Now().UTC().Format("20060102T150405Z") + ".yaml"
+	}
+	if _, err := os.Stat(backupFileArg); err == nil {
+		return errors.New("output file " + backupFileArg + " already exists")
+	}
+	return nil
+}
+
+func backupMain(ctx context.Context) {
+	backup, err := adminClient.Backup(ctx)
+	if err != nil {
+		eprintf(true, "%v\n", err)
+		os.Exit(1)
+	}
+
+	f, err := os.OpenFile(backupFileArg, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		eprintf(true, "unable to write backup; %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := backup.Write(f); err != nil {
+		eprintf(true, "unable to write backup; %v\n", err)
+		os.Exit(1)
+	}
+
+	if !quietFlag {
+		color.HiGreen("Backed up %v drives, %v volumes, %v quotas and %v persistent volumes to '%v' successfully.",
+			len(backup.Drives), len(backup.Volumes), len(backup.Quotas), len(backup.PersistentVolumes), backupFileArg)
+	}
+}
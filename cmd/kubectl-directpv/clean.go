@@ -52,7 +52,10 @@ var cleanCmd = &cobra.Command{
    $ kubectl {PLUGIN_NAME} clean --pod-names=minio-{1...3}
 
 7. Clean volumes by pod namespace
-   $ kubectl {PLUGIN_NAME} clean --pod-namespaces=tenant-{1...3}`,
+   $ kubectl {PLUGIN_NAME} clean --pod-namespaces=tenant-{1...3}
+
+8. Clean volumes by PVC namespace
+   $ kubectl {PLUGIN_NAME} clean --pvc-namespaces=tenant-{1...3}`,
 		`{PLUGIN_NAME}`,
 		consts.AppName,
 	),
@@ -78,6 +81,8 @@ func init() {
 	addDriveIDFlag(cleanCmd, "Select volumes by drive IDs")
 	addPodNameFlag(cleanCmd, "Select volumes by pod names")
 	addPodNSFlag(cleanCmd, "Select volumes by pod namespaces")
+	addPVCNameFlag(cleanCmd, "Select volumes by PVC names")
+	addPVCNSFlag(cleanCmd, "Select volumes by PVC namespaces")
 }
 
 func validateCleanCmd() error {
@@ -101,6 +106,14 @@ func validateCleanCmd() error {
 		return err
 	}
 
+	if err := validatePVCNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePVCNSArgs(); err != nil {
+		return err
+	}
+
 	if err := validateVolumeNameArgs(); err != nil {
 		return err
 	}
@@ -112,6 +125,8 @@ func validateCleanCmd() error {
 	case len(driveIDArgs) != 0:
 	case len(podNameArgs) != 0:
 	case len(podNSArgs) != 0:
+	case len(pvcNameArgs) != 0:
+	case len(pvcNSArgs) != 0:
 	case len(volumeNameArgs) != 0:
 	default:
 		return errors.New("no volume selected to clean")
@@ -123,6 +138,8 @@ func validateCleanCmd() error {
 		driveIDArgs = nil
 		podNameArgs = nil
 		podNSArgs = nil
+		pvcNameArgs = nil
+		pvcNSArgs = nil
 		volumeNameArgs = nil
 	}
 
@@ -138,6 +155,8 @@ func cleanMain(ctx context.Context) {
 			DriveIDs:      driveIDArgs,
 			PodNames:      podNameArgs,
 			PodNamespaces: podNSArgs,
+			PVCNames:      pvcNameArgs,
+			PVCNamespaces: pvcNSArgs,
 			VolumeStatus:  volumeStatusSelectors,
 			VolumeNames:   volumeNameArgs,
 		},
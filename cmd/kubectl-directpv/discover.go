@@ -119,17 +119,21 @@ func showDevices(resultMap map[directpvtypes.NodeID][]types.Device) error {
 	var foundAvailableDrive bool
 	for node, devices := range resultMap {
 		for _, device := range devices {
-			var desc string
+			var descs []string
 			available := "YES"
 			if device.DeniedReason != "" {
 				if !allFlag {
 					continue
 				}
 				available = "NO"
-				desc = device.DeniedReason
+				descs = append(descs, device.DeniedReason)
 			} else {
 				foundAvailableDrive = true
 			}
+			if len(device.Components) != 0 {
+				descs = append(descs, fmt.Sprintf("%v of %v", device.Type, strings.Join(device.Components, ", ")))
+			}
+			desc := strings.Join(descs, "; ")
 			writer.AppendRow(
 				[]interface{}{
 					device.ID[:16] + "...",
@@ -0,0 +1,141 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022, 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var pvcNamespace = "default"
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain volume scheduling decisions",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if parent := cmd.Parent(); parent != nil {
+			parent.PersistentPreRunE(parent, args)
+		}
+		return nil
+	},
+}
+
+var explainPVCCmd = &cobra.Command{
+	Use:           "pvc NAME",
+	Short:         "Explain why drives are selectable or rejected for a PVC",
+	Long:          "Replay the storage class parameters and the topology of a PVC against every drive and show the reason each drive is rejected",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Explain a pending PVC in the default namespace
+   $ kubectl {PLUGIN_NAME} explain pvc data-minio-0
+
+2. Explain a PVC in a namespace
+   $ kubectl {PLUGIN_NAME} explain pvc data-minio-0 --namespace=tenant-1
+
+3. Explain a PVC in YAML format
+   $ kubectl {PLUGIN_NAME} explain pvc data-minio-0 -o yaml`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		if err := validateOutputFormat(false); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		explainPVCMain(c.Context(), args[0])
+	},
+}
+
+func init() {
+	setFlagOpts(explainCmd)
+	setFlagOpts(explainPVCCmd)
+
+	addOutputFormatFlag(explainCmd, "Output format of the results. One of: json|yaml")
+	explainPVCCmd.PersistentFlags().StringVar(&pvcNamespace, "namespace", pvcNamespace, "Namespace of the PVC")
+
+	explainCmd.AddCommand(explainPVCCmd)
+}
+
+func explainPVCMain(ctx context.Context, name string) {
+	result, err := adminClient.ExplainPVC(
+		ctx,
+		admin.ExplainPVCArgs{
+			Name:      name,
+			Namespace: pvcNamespace,
+		},
+	)
+	if err != nil {
+		eprintf(true, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if dryRunPrinter != nil {
+		dryRunPrinter(result)
+		return
+	}
+
+	if result.VolumeName != "" {
+		eprintf(false, "%v\n", color.HiYellowString("PVC is already bound to volume %v; showing current drive state", result.VolumeName))
+	}
+
+	writer := newTableWriter(
+		table.Row{
+			"NODE",
+			"DRIVE",
+			"FREE",
+			"STATUS",
+			"DETAILS",
+		},
+		nil,
+		false,
+	)
+
+	selectable := 0
+	for _, drive := range result.Drives {
+		status := "Selectable"
+		if drive.Reason != "" {
+			status = string(drive.Reason)
+		} else {
+			selectable++
+		}
+		writer.AppendRow([]interface{}{
+			drive.NodeID,
+			drive.DriveName,
+			printableBytes(drive.FreeCapacity),
+			status,
+			printableString(drive.Message),
+		})
+	}
+
+	if writer.Length() != 0 {
+		writer.Render()
+	}
+
+	if selectable == 0 {
+		eprintf(false, "%v\n", color.HiRedString("No drives are selectable for PVC %v/%v", pvcNamespace, name))
+	}
+}
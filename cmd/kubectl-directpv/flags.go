@@ -54,6 +54,8 @@ var (
 	driveIDArgs      []string // --drive-id flag
 	podNameArgs      []string // --pod-name flag
 	podNSArgs        []string // --pod-namespace flag
+	pvcNameArgs      []string // --pvc-names flag
+	pvcNSArgs        []string // --pvc-namespaces flag
 	volumeStatusArgs []string // --status flag of volumes
 	pvcFlag          bool     // --pvc flag
 	dryRunFlag       bool     // --dry-run flag
@@ -61,6 +63,7 @@ var (
 	showLabels       bool     // --show-labels flag
 	labelArgs        []string // --labels flag
 	dangerousFlag    bool     // --dangerous flag
+	auditSinkFlag    = "file" // --audit-sink flag
 )
 
 func addAllFlag(cmd *cobra.Command, usage string) {
@@ -103,6 +106,14 @@ func addPodNSFlag(cmd *cobra.Command, usage string) {
 	cmd.PersistentFlags().StringSliceVar(&podNSArgs, "pod-namespaces", podNameArgs, usage+"; supports ellipses pattern e.g. tenant-{0...3}")
 }
 
+func addPVCNameFlag(cmd *cobra.Command, usage string) {
+	cmd.PersistentFlags().StringSliceVar(&pvcNameArgs, "pvc-names", pvcNameArgs, usage+"; supports ellipses pattern e.g. data-minio-{0...4}")
+}
+
+func addPVCNSFlag(cmd *cobra.Command, usage string) {
+	cmd.PersistentFlags().StringSliceVar(&pvcNSArgs, "pvc-namespaces", pvcNSArgs, usage+"; supports ellipses pattern e.g. tenant-{0...3}")
+}
+
 func addVolumeStatusFlag(cmd *cobra.Command, usage string) {
 	cmd.PersistentFlags().StringSliceVar(&volumeStatusArgs, "status", volumeStatusArgs, fmt.Sprintf("%v; one of: %v", usage, strings.Join(volumeStatusValues, "|")))
 }
@@ -241,6 +252,44 @@ func validatePodNSArgs() error {
 	return nil
 }
 
+func validatePVCNameArgs() error {
+	var values []string
+
+	for i := range pvcNameArgs {
+		pvcNameArgs[i] = strings.TrimSpace(pvcNameArgs[i])
+		if pvcNameArgs[i] == "" {
+			return errors.New("empty PVC name")
+		}
+		result, err := ellipsis.Expand(pvcNameArgs[i])
+		if err != nil {
+			return err
+		}
+		values = append(values, result...)
+	}
+
+	pvcNameArgs = values
+	return nil
+}
+
+func validatePVCNSArgs() error {
+	var values []string
+
+	for i := range pvcNSArgs {
+		pvcNSArgs[i] = strings.TrimSpace(pvcNSArgs[i])
+		if pvcNSArgs[i] == "" {
+			return errors.New("empty PVC namespace")
+		}
+		result, err := ellipsis.Expand(pvcNSArgs[i])
+		if err != nil {
+			return err
+		}
+		values = append(values, result...)
+	}
+
+	pvcNSArgs = values
+	return nil
+}
+
 func validateVolumeNameArgs() error {
 	for i := range volumeNameArgs {
 		volumeNameArgs[i] = strings.TrimSpace(volumeNameArgs[i])
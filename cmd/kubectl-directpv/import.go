@@ -0,0 +1,197 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var (
+	createPVFlag    bool                      // --create-pv flag
+	storageClassArg = consts.StorageClassName // --storage-class flag
+	claimArgs       []string                  // --claims flag
+	claimsByVolume  = map[string]string{}
+)
+
+var importCmd = &cobra.Command{
+	Use:           "import [VOLUME ...]",
+	Short:         "Import volume directories found on the drives as volumes",
+	Long:          "Import volume directories found on the drives, having no volume objects, as volumes. This is useful to recover volumes created after the last backup when the cluster state is lost or " + consts.AppPrettyName + " is reinstalled. Only the drives having drive objects are scanned; restore the drives by restore command prior to import.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Import all volumes from a node
+   $ kubectl {PLUGIN_NAME} import --nodes=node1
+
+2. Show volumes to be imported from all nodes
+   $ kubectl {PLUGIN_NAME} import --dry-run
+
+3. Import a volume by its name 'pvc-0700b8c7-85b2-4894-b83a-274484f220d0' with its persistent volume
+   $ kubectl {PLUGIN_NAME} import pvc-0700b8c7-85b2-4894-b83a-274484f220d0 --create-pv
+
+4. Import a volume with its persistent volume bound to PVC 'data-minio-0' in namespace 'tenant-1'
+   $ kubectl {PLUGIN_NAME} import --create-pv --claims=pvc-0700b8c7-85b2-4894-b83a-274484f220d0=tenant-1/data-minio-0`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		volumeNameArgs = args
+
+		if err := validateImportCmd(); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		importMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(importCmd)
+
+	addNodesFlag(importCmd, "If present, import volumes from given nodes")
+	addDrivesFlag(importCmd, "If present, import volumes by given drive names")
+	addDryRunFlag(importCmd, "Run in dry run mode")
+	importCmd.PersistentFlags().BoolVar(&createPVFlag, "create-pv", createPVFlag, "If present, create persistent volumes of imported volumes")
+	importCmd.PersistentFlags().StringVar(&storageClassArg, "storage-class", storageClassArg, "Storage class of created persistent volumes")
+	importCmd.PersistentFlags().StringSliceVar(&claimArgs, "claims", claimArgs, "Bind created persistent volumes to PVCs in VOLUME=[NAMESPACE/]PVC format")
+	importCmd.PersistentFlags().DurationVar(&nodeListTimeout, "timeout", nodeListTimeout, "specify timeout for scanning the nodes")
+}
+
+func validateImportCmd() error {
+	if err := validateNodeArgs(); err != nil {
+		return err
+	}
+
+	if err := validateDriveNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validateVolumeNameArgs(); err != nil {
+		return err
+	}
+
+	if storageClassArg = strings.TrimSpace(storageClassArg); storageClassArg == "" {
+		return errors.New("empty storage class")
+	}
+
+	for _, claim := range claimArgs {
+		volumeName, pvc, found := strings.Cut(strings.TrimSpace(claim), "=")
+		if !found || volumeName == "" {
+			return fmt.Errorf("invalid claim %v; format must be VOLUME=[NAMESPACE/]PVC", claim)
+		}
+		if _, _, err := admin.ParseClaim(pvc); err != nil {
+			return err
+		}
+		claimsByVolume[volumeName] = pvc
+	}
+
+	if len(claimsByVolume) != 0 && !createPVFlag {
+		return errors.New("--claims must be used with --create-pv")
+	}
+
+	return nil
+}
+
+// refreshNodes refreshes the nodes and waits for the nodes to report their volume directories.
+func refreshNodes(ctx context.Context) error {
+	nodeCh, errCh, err := adminClient.RefreshNodes(ctx, nodesArgs)
+	if err != nil {
+		return err
+	}
+
+	var nodeNames []string
+	for nodeCh != nil || errCh != nil {
+		select {
+		case nodeID, ok := <-nodeCh:
+			if !ok {
+				nodeCh = nil
+				continue
+			}
+			nodeNames = append(nodeNames, string(nodeID))
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nodeListTimeout)
+	defer cancel()
+
+	eventCh, stop, err := adminClient.NewNodeLister().NodeNameSelector(nodeNames).Watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	refreshed := map[string]struct{}{}
+	for len(refreshed) < len(nodeNames) {
+		select {
+		case event, ok := <-eventCh:
+			if !ok {
+				return errors.New("node watch closed unexpectedly")
+			}
+			if event.Err != nil {
+				return event.Err
+			}
+			if (event.Type == watch.Added || event.Type == watch.Modified) && !event.Item.Spec.Refresh {
+				refreshed[event.Item.Name] = struct{}{}
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("unable to scan the nodes; %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+func importMain(ctx context.Context) {
+	if err := refreshNodes(ctx); err != nil {
+		eprintf(true, "%v\n", err)
+		os.Exit(1)
+	}
+
+	_, err := adminClient.ImportVolumes(
+		ctx,
+		admin.ImportVolumesArgs{
+			Nodes:        nodesArgs,
+			Drives:       drivesArgs,
+			VolumeNames:  volumeNameArgs,
+			Claims:       claimsByVolume,
+			CreatePV:     createPVFlag,
+			StorageClass: storageClassArg,
+			DryRun:       dryRunFlag,
+		},
+		logFunc,
+	)
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/minio/directpv/pkg/admin"
@@ -43,26 +45,46 @@ type initResult struct {
 	devices   []types.InitDeviceResult
 }
 
-var initRequestListTimeout = 2 * time.Minute
+var (
+	initRequestListTimeout = 2 * time.Minute
+	virtualDrives          int
+	virtualDriveSize       = "1GiB"
+)
 
 var initCmd = &cobra.Command{
-	Use:           "init drives.yaml",
+	Use:           "init [drives.yaml]",
 	Short:         "Initialize the drives",
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	Example: strings.ReplaceAll(
 		`1. Initialize the drives
-   $ kubectl {PLUGIN_NAME} init drives.yaml`,
+   $ kubectl {PLUGIN_NAME} init drives.yaml
+
+2. Initialize two virtual drives of 10GiB on each node for development or testing
+   $ kubectl {PLUGIN_NAME} init --virtual-drives=2 --virtual-drive-size=10GiB
+
+3. Initialize a virtual drive on node1
+   $ kubectl {PLUGIN_NAME} init --virtual-drives=1 --nodes=node1`,
 		`{PLUGIN_NAME}`,
 		consts.AppName,
 	),
 	Run: func(c *cobra.Command, args []string) {
-		switch len(args) {
-		case 1:
-		case 0:
+		switch {
+		case virtualDrives < 0:
+			eprintf(true, "Invalid virtual drive count %v. Check `--help` for usage.\n", virtualDrives)
+			os.Exit(-1)
+		case virtualDrives > 0 && len(args) != 0:
+			eprintf(true, "Input file is not supported with --virtual-drives flag. Check `--help` for usage.\n")
+			os.Exit(-1)
+		case virtualDrives > 0:
+			if err := validateNodeArgs(); err != nil {
+				eprintf(true, "%v\n", err)
+				os.Exit(-1)
+			}
+		case len(args) == 0:
 			eprintf(true, "Please provide the input file. Check `--help` for usage.\n")
 			os.Exit(-1)
-		default:
+		case len(args) > 1:
 			eprintf(true, "Too many input args. Check `--help` for usage.\n")
 			os.Exit(-1)
 		}
@@ -72,7 +94,19 @@ var initCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		initMain(c.Context(), args[0])
+		var initConfig *admin.InitConfig
+		var err error
+		if virtualDrives > 0 {
+			initConfig, err = getVirtualInitConfig(c.Context())
+		} else if initConfig, err = admin.ReadInitConfig(args[0]); err != nil {
+			err = fmt.Errorf("unable to read the input file; %w", err)
+		}
+		if err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(1)
+		}
+
+		initMain(c.Context(), initConfig)
 	},
 }
 
@@ -80,6 +114,9 @@ func init() {
 	setFlagOpts(initCmd)
 
 	initCmd.PersistentFlags().DurationVar(&initRequestListTimeout, "timeout", initRequestListTimeout, "specify timeout for the initialization process")
+	initCmd.PersistentFlags().IntVar(&virtualDrives, "virtual-drives", virtualDrives, "Create and initialize given number of file-backed virtual drives on each node; for development and testing only")
+	initCmd.PersistentFlags().StringVar(&virtualDriveSize, "virtual-drive-size", virtualDriveSize, "Size of each virtual drive")
+	addNodesFlag(initCmd, "Create virtual drives on given nodes")
 	addDangerousFlag(initCmd, "Perform initialization of drives which will permanently erase existing data")
 }
 
@@ -146,21 +183,21 @@ func initDevices(ctx context.Context, initRequests []types.InitRequest, requestI
 	totalTasks := totalReqCount * 2
 	var completedTasks int
 	initProgressMap := make(map[string]progressLog, totalReqCount)
-	for i := range initRequests {
-		initReq, err := adminClient.InitRequest().Create(ctx, &initRequests[i], metav1.CreateOptions{TypeMeta: types.NewInitRequestTypeMeta()})
-		if err != nil {
-			return nil, err
+	log := func(log admin.LogMessage) {
+		if teaProgram == nil || log.Type != admin.InfoLogType {
+			return
 		}
-		if teaProgram != nil {
-			completedTasks++
-			initProgressMap[initReq.Name] = progressLog{
-				log: fmt.Sprintf("Processing initialization request '%s' for node '%v'", initReq.Name, initReq.GetNodeID()),
-			}
-			teaProgram.Send(progressNotification{
-				progressLogs: toProgressLogs(initProgressMap),
-				percent:      float64(completedTasks) / float64(totalTasks),
-			})
+		completedTasks++
+		initProgressMap[fmt.Sprint(log.Values["requestID"])] = progressLog{
+			log: strings.TrimSuffix(log.FormattedMessage, "\n"),
 		}
+		teaProgram.Send(progressNotification{
+			progressLogs: toProgressLogs(initProgressMap),
+			percent:      float64(completedTasks) / float64(totalTasks),
+		})
+	}
+	if _, err = adminClient.CreateInitRequests(ctx, admin.InitArgs{InitRequests: initRequests, RequestID: requestID}, log); err != nil {
+		return nil, err
 	}
 	ctx, cancel := context.WithTimeout(ctx, initRequestListTimeout)
 	defer cancel()
@@ -220,13 +257,30 @@ func initDevices(ctx context.Context, initRequests []types.InitRequest, requestI
 	}
 }
 
-func initMain(ctx context.Context, inputFile string) {
-	initConfig, err := admin.ReadInitConfig(inputFile)
+// getVirtualInitConfig returns init config of virtual drives on requested nodes or on all nodes.
+func getVirtualInitConfig(ctx context.Context) (*admin.InitConfig, error) {
+	size, err := humanize.ParseBytes(virtualDriveSize)
 	if err != nil {
-		eprintf(true, "unable to read the input file; %v", err.Error())
-		os.Exit(1)
+		return nil, fmt.Errorf("invalid virtual drive size %v; %w", virtualDriveSize, err)
+	}
+
+	nodes, err := adminClient.NewNodeLister().NodeSelector(directpvtypes.ToLabelValues(nodesArgs)).Get(ctx)
+	if err != nil {
+		return nil, err
 	}
+	if len(nodes) == 0 {
+		return nil, errors.New("no nodes found")
+	}
+
+	var nodeIDs []directpvtypes.NodeID
+	for _, node := range nodes {
+		nodeIDs = append(nodeIDs, directpvtypes.NodeID(node.Name))
+	}
+
+	return admin.NewVirtualInitConfig(nodeIDs, virtualDrives, size)
+}
 
+func initMain(ctx context.Context, initConfig *admin.InitConfig) {
 	initRequests, requestID := initConfig.ToInitRequestObjects()
 	if len(initRequests) == 0 {
 		eprintf(false, "%v\n", color.HiYellowString("No drives are available to init"))
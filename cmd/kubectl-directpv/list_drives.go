@@ -149,6 +149,9 @@ func listDrivesMain(ctx context.Context) {
 		"VOLUMES",
 		"STATUS",
 	}
+	if wideOutput {
+		headers = append(headers, "FILESYSTEM", "PROFILE", "ENCRYPTION")
+	}
 	if showLabels {
 		headers = append(headers, "LABELS")
 	}
@@ -192,6 +195,9 @@ func listDrivesMain(ctx context.Context) {
 		if drive.IsSuspended() {
 			status += ",Suspended"
 		}
+		if drive.IsVirtual() {
+			status += ",Virtual"
+		}
 		driveMake := drive.Status.Make
 		if driveMake == "" {
 			driveMake = "-"
@@ -206,6 +212,17 @@ func listDrivesMain(ctx context.Context) {
 			volumes,
 			status,
 		}
+		if wideOutput {
+			profile := "-"
+			if drive.Status.FormatProfile != nil {
+				profile = drive.Status.FormatProfile.Name
+			}
+			encryption := "-"
+			if drive.IsEncrypted() {
+				encryption = "luks2"
+			}
+			row = append(row, drive.GetFSType(), profile, encryption)
+		}
 		if showLabels {
 			row = append(row, labelsToString(drive.GetLabels()))
 		}
@@ -54,7 +54,7 @@ var listVolumesCmd = &cobra.Command{
    $ kubectl {PLUGIN_NAME} list volumes --pod-namespaces=tenant-{1...3}
 
 6. List all volumes from all nodes with all information include PVC name.
-   $ kubectl {PLUGIN_NAME} list drives --all --pvc --output wide
+   $ kubectl {PLUGIN_NAME} list volumes --all --pvc --output wide
 
 7. List volumes in Pending state
    $ kubectl {PLUGIN_NAME} list volumes --status=pending
@@ -66,7 +66,10 @@ var listVolumesCmd = &cobra.Command{
    $ kubectl {PLUGIN_NAME} list volumes --show-labels
 
 10. List volumes filtered by labels
-   $ kubectl {PLUGIN_NAME} list volumes --labels tier=hot`,
+   $ kubectl {PLUGIN_NAME} list volumes --labels tier=hot
+
+11. List volumes by PVC name and namespace
+   $ kubectl {PLUGIN_NAME} list volumes --pvc-names=data-minio-{0...3} --pvc-namespaces=tenant-1`,
 		`{PLUGIN_NAME}`,
 		consts.AppName,
 	),
@@ -87,6 +90,8 @@ func init() {
 	addDriveIDFlag(listVolumesCmd, "Filter output by drive IDs")
 	addPodNameFlag(listVolumesCmd, "Filter output by pod names")
 	addPodNSFlag(listVolumesCmd, "Filter output by pod namespaces")
+	addPVCNameFlag(listVolumesCmd, "Filter output by PVC names")
+	addPVCNSFlag(listVolumesCmd, "Filter output by PVC namespaces")
 	listVolumesCmd.PersistentFlags().BoolVar(&pvcFlag, "pvc", pvcFlag, "Add PVC names in the output")
 	addVolumeStatusFlag(listVolumesCmd, "Filter output by volume status")
 	addShowLabelsFlag(listVolumesCmd)
@@ -107,6 +112,14 @@ func validateListVolumesArgs() error {
 		return err
 	}
 
+	if err := validatePVCNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePVCNSArgs(); err != nil {
+		return err
+	}
+
 	if err := validateVolumeNameArgs(); err != nil {
 		return err
 	}
@@ -122,6 +135,8 @@ func validateListVolumesArgs() error {
 	case len(driveIDArgs) != 0:
 	case len(podNameArgs) != 0:
 	case len(podNSArgs) != 0:
+	case len(pvcNameArgs) != 0:
+	case len(pvcNSArgs) != 0:
 	case len(volumeNameArgs) != 0:
 	case len(volumeStatusArgs) != 0:
 	case len(labelArgs) != 0:
@@ -135,6 +150,8 @@ func validateListVolumesArgs() error {
 		driveIDSelectors = nil
 		podNameArgs = nil
 		podNSArgs = nil
+		pvcNameArgs = nil
+		pvcNSArgs = nil
 		volumeNameArgs = nil
 		volumeStatusSelectors = nil
 		labelSelectors = nil
@@ -144,10 +161,17 @@ func validateListVolumesArgs() error {
 }
 
 func getPVCName(ctx context.Context, volume types.Volume) string {
+	// Full PVC name is recorded in the annotation; the label value may be truncated.
+	if name, found := volume.GetAnnotations()[string(directpvtypes.PVCNameLabelKey)]; found {
+		return name
+	}
 	pv, err := adminClient.Kube().CoreV1().PersistentVolumes().Get(ctx, volume.Name, metav1.GetOptions{})
 	if err == nil && pv != nil && pv.Spec.ClaimRef != nil {
 		return pv.Spec.ClaimRef.Name
 	}
+	if name := volume.GetPVCName(); name != "" {
+		return name
+	}
 	return "-"
 }
 
@@ -158,6 +182,8 @@ func listVolumesMain(ctx context.Context) {
 		DriveIDSelector(directpvtypes.ToLabelValues(driveIDArgs)).
 		PodNameSelector(directpvtypes.ToLabelValues(podNameArgs)).
 		PodNSSelector(directpvtypes.ToLabelValues(podNSArgs)).
+		PVCNameSelector(directpvtypes.ToLabelValues(pvcNameArgs)).
+		PVCNamespaceSelector(directpvtypes.ToLabelValues(pvcNSArgs)).
 		StatusSelector(volumeStatusSelectors).
 		VolumeNameSelector(volumeNameArgs).
 		LabelSelector(labelSelectors).
@@ -189,7 +215,7 @@ func listVolumesMain(ctx context.Context) {
 		"STATUS",
 	}
 	if wideOutput {
-		headers = append(headers, "DRIVE ID")
+		headers = append(headers, "DRIVE ID", "BACKEND")
 	}
 	if pvcFlag {
 		headers = append(headers, "PVC")
@@ -244,6 +270,9 @@ func listVolumesMain(ctx context.Context) {
 
 		if volume.IsSuspended() {
 			status += ",Suspended"
+			if mode := volume.GetSuspendMode(); mode != directpvtypes.SuspendModeEmpty {
+				status += "(" + string(mode) + ")"
+			}
 		}
 
 		if volume.DeletionTimestamp != nil {
@@ -260,7 +289,7 @@ func listVolumesMain(ctx context.Context) {
 			status,
 		}
 		if wideOutput {
-			row = append(row, volume.GetDriveID())
+			row = append(row, volume.GetDriveID(), volume.GetBackend())
 		}
 		if pvcFlag {
 			row = append(row, getPVCName(ctx, volume))
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/minio/directpv/pkg/admin"
@@ -67,6 +68,9 @@ var mainCmd = &cobra.Command{
 			if err != nil {
 				klog.Fatalf("unable to create legacy client; %v", err)
 			}
+			if err = setAuditSink(); err != nil {
+				klog.Fatalf("unable to set audit sink; %v", err)
+			}
 		} else {
 			adminClient = &admin.Client{}
 			legacyClient = &legacy.Client{}
@@ -142,6 +146,13 @@ Use "{{.CommandPath}} [command] --help" for more information about this command.
 		"Suppress printing error messages",
 	)
 
+	mainCmd.PersistentFlags().StringVar(
+		&auditSinkFlag,
+		"audit-sink",
+		auditSinkFlag,
+		"Audit log sink of mutating operations; one of: "+strings.Join(auditSinkValues, "|"),
+	)
+
 	mainCmd.PersistentFlags().MarkHidden("alsologtostderr")
 	mainCmd.PersistentFlags().MarkHidden("add_dir_header")
 	mainCmd.PersistentFlags().MarkHidden("log_file")
@@ -170,13 +181,20 @@ Use "{{.CommandPath}} [command] --help" for more information about this command.
 	mainCmd.AddCommand(cordonCmd)
 	mainCmd.AddCommand(uncordonCmd)
 	mainCmd.AddCommand(migrateCmd)
+	mainCmd.AddCommand(importCmd)
+	mainCmd.AddCommand(backupCmd)
+	mainCmd.AddCommand(restoreCmd)
 	mainCmd.AddCommand(moveCmd)
 	mainCmd.AddCommand(cleanCmd)
 	mainCmd.AddCommand(suspendCmd)
 	mainCmd.AddCommand(resumeCmd)
+	mainCmd.AddCommand(remountCmd)
 	mainCmd.AddCommand(repairCmd)
 	mainCmd.AddCommand(removeCmd)
+	mainCmd.AddCommand(nodeCmd)
+	mainCmd.AddCommand(explainCmd)
 	mainCmd.AddCommand(uninstallCmd)
+	mainCmd.AddCommand(auditCmd)
 	mainCmd.SetHelpCommand(&cobra.Command{
 		Hidden: true,
 	})
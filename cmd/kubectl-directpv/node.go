@@ -0,0 +1,58 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022, 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Manage nodes for maintenance and removal",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if parent := cmd.Parent(); parent != nil {
+			parent.PersistentPreRunE(parent, args)
+		}
+		return nil
+	},
+}
+
+func init() {
+	setFlagOpts(nodeCmd)
+
+	addDryRunFlag(nodeCmd, "Run in dry run mode")
+	addOutputFormatFlag(nodeCmd, "Output format of the results. One of: json|yaml")
+
+	nodeCmd.AddCommand(nodeDrainCmd)
+	nodeCmd.AddCommand(nodeRestoreCmd)
+	nodeCmd.AddCommand(nodeRemoveCmd)
+}
+
+func validateNodeCmdArgs(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no node provided")
+	}
+
+	nodesArgs = args
+	if err := validateNodeArgs(); err != nil {
+		return err
+	}
+
+	return validateOutputFormat(false)
+}
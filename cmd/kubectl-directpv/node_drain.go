@@ -0,0 +1,111 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022, 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/admin"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var suspendVolumesFlag bool // --suspend-volumes flag
+
+var nodeDrainCmd = &cobra.Command{
+	Use:           "drain NODE ...",
+	Short:         "Drain nodes for maintenance",
+	Long:          "Cordon all drives of the nodes and optionally suspend their volumes. Use 'node restore' to revert.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Drain a node
+   $ kubectl {PLUGIN_NAME} node drain node1
+
+2. Drain nodes and suspend their volumes
+   $ kubectl {PLUGIN_NAME} node drain node{1...4} --suspend-volumes --dangerous
+
+3. Drain a node and suspend its volumes keeping their data readable
+   $ kubectl {PLUGIN_NAME} node drain node1 --suspend-volumes --mode=read-only --dangerous
+
+4. Show what is drained in a node in JSON
+   $ kubectl {PLUGIN_NAME} node drain node1 --dry-run -o json`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		if err := validateNodeDrainCmd(args); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		if suspendVolumesFlag && !dangerousFlag {
+			eprintf(true, "Suspending the volumes will make them as read-only. Please review carefully before performing this *DANGEROUS* operation and retry this command with --dangerous flag.\n")
+			os.Exit(1)
+		}
+
+		nodeDrainMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(nodeDrainCmd)
+
+	nodeDrainCmd.PersistentFlags().BoolVar(&suspendVolumesFlag, "suspend-volumes", suspendVolumesFlag, "If present, suspend volumes of the nodes")
+	nodeDrainCmd.PersistentFlags().StringVar(
+		&suspendModeArg,
+		"mode",
+		suspendModeArg,
+		"Suspend mode of the volumes; one of "+strings.Join([]string{string(directpvtypes.SuspendModeEmpty), string(directpvtypes.SuspendModeReadOnly), string(directpvtypes.SuspendModeFenced)}, "|"),
+	)
+	addDangerousFlag(nodeDrainCmd, "Suspending the volumes will make them as read-only")
+}
+
+func validateNodeDrainCmd(args []string) error {
+	if err := validateNodeCmdArgs(args); err != nil {
+		return err
+	}
+
+	var err error
+	suspendMode, err = directpvtypes.ToSuspendMode(suspendModeArg)
+	return err
+}
+
+func nodeDrainMain(ctx context.Context) {
+	results, err := adminClient.NodeDrain(
+		ctx,
+		admin.NodeDrainArgs{
+			Nodes:          nodesArgs,
+			SuspendVolumes: suspendVolumesFlag,
+			SuspendMode:    suspendMode,
+			DryRun:         dryRunFlag,
+		},
+		logFunc,
+	)
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+
+	if dryRunPrinter != nil {
+		dryRunPrinter(results)
+	}
+}
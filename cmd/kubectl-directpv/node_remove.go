@@ -0,0 +1,83 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022, 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var nodeRemoveCmd = &cobra.Command{
+	Use:           "remove NODE ...",
+	Short:         "Remove nodes from " + consts.AppPrettyName,
+	Long:          "Remove the drives, the init requests and the node objects of the nodes (CAUTION: The nodes must not have volumes and must be removed from " + consts.AppPrettyName + " DaemonSet)",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Remove a node
+   $ kubectl {PLUGIN_NAME} node remove node1 --dangerous
+
+2. Show what is removed for nodes
+   $ kubectl {PLUGIN_NAME} node remove node{1...4} --dry-run`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		if err := validateNodeCmdArgs(args); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		if !dryRunFlag && !dangerousFlag {
+			eprintf(true, "Removing the nodes is irreversible. Please review carefully before performing this *DANGEROUS* operation and retry this command with --dangerous flag.\n")
+			os.Exit(1)
+		}
+
+		nodeRemoveMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(nodeRemoveCmd)
+
+	addDangerousFlag(nodeRemoveCmd, "Removing the nodes is irreversible")
+}
+
+func nodeRemoveMain(ctx context.Context) {
+	results, err := adminClient.NodeRemove(
+		ctx,
+		admin.NodeRemoveArgs{
+			Nodes:  nodesArgs,
+			DryRun: dryRunFlag,
+		},
+		logFunc,
+	)
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+
+	if dryRunPrinter != nil {
+		dryRunPrinter(results)
+	}
+}
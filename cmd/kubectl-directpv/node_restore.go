@@ -0,0 +1,76 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022, 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var nodeRestoreCmd = &cobra.Command{
+	Use:           "restore NODE ...",
+	Short:         "Restore drained nodes",
+	Long:          "Uncordon the drives and resume the volumes changed by 'node drain'",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Restore a drained node
+   $ kubectl {PLUGIN_NAME} node restore node1
+
+2. Restore drained nodes
+   $ kubectl {PLUGIN_NAME} node restore node{1...4}`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		if err := validateNodeCmdArgs(args); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		nodeRestoreMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(nodeRestoreCmd)
+}
+
+func nodeRestoreMain(ctx context.Context) {
+	results, err := adminClient.NodeRestore(
+		ctx,
+		admin.NodeRestoreArgs{
+			Nodes:  nodesArgs,
+			DryRun: dryRunFlag,
+		},
+		logFunc,
+	)
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+
+	if dryRunPrinter != nil {
+		dryRunPrinter(results)
+	}
+}
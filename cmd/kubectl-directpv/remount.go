@@ -0,0 +1,167 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/xfs"
+	"github.com/spf13/cobra"
+)
+
+var mountOptionsArgs []string
+
+var remountCmd = &cobra.Command{
+	Use:           "remount [VOLUME ...]",
+	Short:         "Remount volumes with new mount options",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Remount all volumes with noatime and nodev
+   $ kubectl {PLUGIN_NAME} remount --all --mount-options=noatime,nodev
+
+2. Remount a volume by its name 'pvc-0700b8c7-85b2-4894-b83a-274484f220d0'
+   $ kubectl {PLUGIN_NAME} remount pvc-0700b8c7-85b2-4894-b83a-274484f220d0 --mount-options=noexec
+
+3. Remount volumes by PVC namespace
+   $ kubectl {PLUGIN_NAME} remount --pvc-namespaces=tenant-{1...3} --mount-options=relatime
+
+4. Reset mount options of volumes from a node
+   $ kubectl {PLUGIN_NAME} remount --nodes=node1 --mount-options=""`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		volumeNameArgs = args
+
+		if err := validateRemountCmd(c); err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(-1)
+		}
+
+		remountMain(c.Context())
+	},
+}
+
+func init() {
+	setFlagOpts(remountCmd)
+
+	addNodesFlag(remountCmd, "If present, select volumes from given nodes")
+	addDrivesFlag(remountCmd, "If present, select volumes by given drive names")
+	addAllFlag(remountCmd, "If present, select all volumes")
+	addDryRunFlag(remountCmd, "Run in dry run mode")
+	addPodNameFlag(remountCmd, "Select volumes by pod names")
+	addPodNSFlag(remountCmd, "Select volumes by pod namespaces")
+	addPVCNameFlag(remountCmd, "Select volumes by PVC names")
+	addPVCNSFlag(remountCmd, "Select volumes by PVC namespaces")
+	remountCmd.PersistentFlags().StringSliceVar(&mountOptionsArgs, "mount-options", mountOptionsArgs, "Mount options to be applied to the selected volumes; e.g. noatime,nodev")
+}
+
+func validateRemountCmd(c *cobra.Command) error {
+	if !c.Flags().Changed("mount-options") {
+		return errors.New("--mount-options must be provided")
+	}
+
+	_, fsOptions, err := xfs.ParseMountOptions(mountOptionsArgs)
+	if err != nil {
+		return err
+	}
+	if len(fsOptions) != 0 {
+		return fmt.Errorf("filesystem mount options %v cannot be changed by remount", fsOptions)
+	}
+
+	if err := validateNodeArgs(); err != nil {
+		return err
+	}
+
+	if err := validateDriveNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePodNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePodNSArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePVCNameArgs(); err != nil {
+		return err
+	}
+
+	if err := validatePVCNSArgs(); err != nil {
+		return err
+	}
+
+	if err := validateVolumeNameArgs(); err != nil {
+		return err
+	}
+
+	switch {
+	case allFlag:
+	case len(nodesArgs) != 0:
+	case len(drivesArgs) != 0:
+	case len(podNameArgs) != 0:
+	case len(podNSArgs) != 0:
+	case len(pvcNameArgs) != 0:
+	case len(pvcNSArgs) != 0:
+	case len(volumeNameArgs) != 0:
+	default:
+		return errors.New("no volume selected to remount")
+	}
+
+	if allFlag {
+		nodesArgs = nil
+		drivesArgs = nil
+		podNameArgs = nil
+		podNSArgs = nil
+		pvcNameArgs = nil
+		pvcNSArgs = nil
+		volumeNameArgs = nil
+	}
+
+	return nil
+}
+
+func remountMain(ctx context.Context) {
+	_, err := adminClient.RemountVolumes(
+		ctx,
+		admin.RemountVolumeArgs{
+			Nodes:         nodesArgs,
+			Drives:        drivesArgs,
+			PodNames:      podNameArgs,
+			PodNamespaces: podNSArgs,
+			PVCNames:      pvcNameArgs,
+			PVCNamespaces: pvcNSArgs,
+			VolumeNames:   volumeNameArgs,
+			MountOptions:  mountOptionsArgs,
+			DryRun:        dryRunFlag,
+		},
+		logFunc,
+	)
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+}
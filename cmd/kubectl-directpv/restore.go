@@ -0,0 +1,113 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/minio/directpv/pkg/admin"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:           "restore FILE",
+	Short:         "Restore drives, volumes, quotas and persistent volumes from backup",
+	Long:          "Restore drives, volumes, quotas and persistent volumes from the backup taken by backup command. Drives are validated against the devices seen by the nodes; capacities of restored drives are reconciled with restored volumes. Existing objects are never overwritten.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Example: strings.ReplaceAll(
+		`1. Restore from file 'backup.yaml'
+   $ kubectl {PLUGIN_NAME} restore backup.yaml
+
+2. Show the differences between file 'backup.yaml' and the cluster without restoring
+   $ kubectl {PLUGIN_NAME} restore backup.yaml --dry-run`,
+		`{PLUGIN_NAME}`,
+		consts.AppName,
+	),
+	Run: func(c *cobra.Command, args []string) {
+		if len(args) != 1 {
+			eprintf(true, "only one backup file must be provided\n")
+			os.Exit(-1)
+		}
+
+		restoreMain(c.Context(), args[0])
+	},
+}
+
+func init() {
+	setFlagOpts(restoreCmd)
+
+	addDryRunFlag(restoreCmd, "Show the differences without restoring")
+	restoreCmd.PersistentFlags().DurationVar(&nodeListTimeout, "timeout", nodeListTimeout, "specify timeout for scanning the nodes")
+}
+
+func restoreChangesString(changes []admin.AuditChange) string {
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, fmt.Sprintf("%v: %v => %v", change.Field, change.Before, change.After))
+	}
+	return printableString(strings.Join(lines, "\n"))
+}
+
+func restoreMain(ctx context.Context, file string) {
+	backup, err := admin.ReadBackup(file)
+	if err != nil {
+		eprintf(true, "unable to read backup %v; %v\n", file, err)
+		os.Exit(1)
+	}
+
+	if err := refreshNodes(ctx); err != nil {
+		eprintf(true, "%v\n", err)
+		os.Exit(1)
+	}
+
+	results, err := adminClient.Restore(
+		ctx,
+		admin.RestoreArgs{
+			Backup: backup,
+			DryRun: dryRunFlag,
+		},
+		logFunc,
+	)
+
+	if len(results) != 0 {
+		header := table.Row{"KIND", "NAME", "ACTION", "REASON"}
+		if dryRunFlag {
+			header = append(header, "CHANGES")
+		}
+		writer := newTableWriter(header, nil, false)
+		for _, result := range results {
+			row := []any{result.Kind, result.Name, result.Action, printableString(result.Reason)}
+			if dryRunFlag {
+				row = append(row, restoreChangesString(result.Changes))
+			}
+			writer.AppendRow(row)
+		}
+		writer.Render()
+	}
+
+	if err != nil {
+		eprintf(!errors.Is(err, admin.ErrNoMatchingResourcesFound), "%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -40,7 +40,10 @@ var resumeVolumesCmd = &cobra.Command{
    $ kubectl {PLUGIN_NAME} resume volumes --nodes=node1 --volumes=sda
 
 3. Resume a volume by its name 'pvc-0700b8c7-85b2-4894-b83a-274484f220d0'
-   $ kubectl {PLUGIN_NAME} resume volumes pvc-0700b8c7-85b2-4894-b83a-274484f220d0`,
+   $ kubectl {PLUGIN_NAME} resume volumes pvc-0700b8c7-85b2-4894-b83a-274484f220d0
+
+4. Resume volumes by PVC name in a namespace
+   $ kubectl {PLUGIN_NAME} resume volumes --pvc-names=data-minio-0 --pvc-namespaces=tenant-1`,
 		`{PLUGIN_NAME}`,
 		consts.AppName,
 	),
@@ -63,6 +66,8 @@ func init() {
 	addDrivesFlag(resumeVolumesCmd, "If present, resume volumes by given drive names")
 	addPodNameFlag(resumeVolumesCmd, "If present, resume volumes by given pod names")
 	addPodNSFlag(resumeVolumesCmd, "If present, resume volumes by given pod namespaces")
+	addPVCNameFlag(resumeVolumesCmd, "If present, resume volumes by given PVC names")
+	addPVCNSFlag(resumeVolumesCmd, "If present, resume volumes by given PVC namespaces")
 }
 
 func validateResumeVolumesCmd() error {
@@ -81,6 +86,12 @@ func validateResumeVolumesCmd() error {
 	if err := validatePodNSArgs(); err != nil {
 		return err
 	}
+	if err := validatePVCNameArgs(); err != nil {
+		return err
+	}
+	if err := validatePVCNSArgs(); err != nil {
+		return err
+	}
 
 	switch {
 	case len(volumeNameArgs) != 0:
@@ -88,6 +99,8 @@ func validateResumeVolumesCmd() error {
 	case len(drivesArgs) != 0:
 	case len(podNameArgs) != 0:
 	case len(podNSArgs) != 0:
+	case len(pvcNameArgs) != 0:
+	case len(pvcNSArgs) != 0:
 	default:
 		return errors.New("no volume selected to resume")
 	}
@@ -103,6 +116,8 @@ func resumeVolumesMain(ctx context.Context) {
 			Drives:        drivesArgs,
 			PodNames:      podNameArgs,
 			PodNamespaces: podNSArgs,
+			PVCNames:      pvcNameArgs,
+			PVCNamespaces: pvcNSArgs,
 			VolumeNames:   volumeNameArgs,
 			DryRun:        dryRunFlag,
 		},
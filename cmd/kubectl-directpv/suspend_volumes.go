@@ -23,10 +23,16 @@ import (
 	"strings"
 
 	"github.com/minio/directpv/pkg/admin"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/spf13/cobra"
 )
 
+var (
+	suspendModeArg = string(directpvtypes.SuspendModeEmpty) // --mode flag
+	suspendMode    directpvtypes.SuspendMode
+)
+
 var suspendVolumesCmd = &cobra.Command{
 	Use:           "volumes [VOLUME ...]",
 	Short:         "Suspend volumes",
@@ -41,7 +47,16 @@ var suspendVolumesCmd = &cobra.Command{
    $ kubectl {PLUGIN_NAME} suspend volumes --nodes=node1 --volumes=sda
 
 3. Suspend a volume by its name 'pvc-0700b8c7-85b2-4894-b83a-274484f220d0'
-   $ kubectl {PLUGIN_NAME} suspend volumes pvc-0700b8c7-85b2-4894-b83a-274484f220d0`,
+   $ kubectl {PLUGIN_NAME} suspend volumes pvc-0700b8c7-85b2-4894-b83a-274484f220d0
+
+4. Suspend volumes by PVC name in a namespace
+   $ kubectl {PLUGIN_NAME} suspend volumes --pvc-names=data-minio-0 --pvc-namespaces=tenant-1
+
+5. Suspend a volume keeping its data readable
+   $ kubectl {PLUGIN_NAME} suspend volumes --mode=read-only pvc-0700b8c7-85b2-4894-b83a-274484f220d0
+
+6. Suspend a volume by blocking its publishing
+   $ kubectl {PLUGIN_NAME} suspend volumes --mode=fenced pvc-0700b8c7-85b2-4894-b83a-274484f220d0`,
 		`{PLUGIN_NAME}`,
 		consts.AppName,
 	),
@@ -69,7 +84,15 @@ func init() {
 	addDrivesFlag(suspendVolumesCmd, "If present, suspend volumes by given drive names")
 	addPodNameFlag(suspendVolumesCmd, "If present, suspend volumes by given pod names")
 	addPodNSFlag(suspendVolumesCmd, "If present, suspend volumes by given pod namespaces")
+	addPVCNameFlag(suspendVolumesCmd, "If present, suspend volumes by given PVC names")
+	addPVCNSFlag(suspendVolumesCmd, "If present, suspend volumes by given PVC namespaces")
 	addDangerousFlag(suspendVolumesCmd, "Suspending the volumes will make them as read-only")
+	suspendVolumesCmd.PersistentFlags().StringVar(
+		&suspendModeArg,
+		"mode",
+		suspendModeArg,
+		"Suspend mode; one of "+strings.Join([]string{string(directpvtypes.SuspendModeEmpty), string(directpvtypes.SuspendModeReadOnly), string(directpvtypes.SuspendModeFenced)}, "|"),
+	)
 }
 
 func validateSuspendVolumesCmd() error {
@@ -88,6 +111,17 @@ func validateSuspendVolumesCmd() error {
 	if err := validatePodNSArgs(); err != nil {
 		return err
 	}
+	if err := validatePVCNameArgs(); err != nil {
+		return err
+	}
+	if err := validatePVCNSArgs(); err != nil {
+		return err
+	}
+
+	var err error
+	if suspendMode, err = directpvtypes.ToSuspendMode(suspendModeArg); err != nil {
+		return err
+	}
 
 	switch {
 	case len(volumeNameArgs) != 0:
@@ -95,6 +129,8 @@ func validateSuspendVolumesCmd() error {
 	case len(drivesArgs) != 0:
 	case len(podNameArgs) != 0:
 	case len(podNSArgs) != 0:
+	case len(pvcNameArgs) != 0:
+	case len(pvcNSArgs) != 0:
 	default:
 		return errors.New("no volume selected to suspend")
 	}
@@ -110,7 +146,10 @@ func suspendVolumesMain(ctx context.Context) {
 			Drives:        drivesArgs,
 			PodNames:      podNameArgs,
 			PodNamespaces: podNSArgs,
+			PVCNames:      pvcNameArgs,
+			PVCNamespaces: pvcNSArgs,
 			VolumeNames:   volumeNameArgs,
+			Mode:          suspendMode,
 			DryRun:        dryRunFlag,
 		},
 		logFunc,
@@ -44,6 +44,16 @@ func init() {
 }
 
 func uninstallMain(ctx context.Context) {
+	// The audit ConfigMap is deleted along with the namespace; hence record uninstall in the local audit log.
+	if _, ok := adminClient.AuditSink().(*admin.ConfigMapAuditSink); ok {
+		sink, err := newFileAuditSink()
+		if err != nil {
+			eprintf(true, "%v\n", err)
+			os.Exit(1)
+		}
+		adminClient.SetAuditSink(sink)
+	}
+
 	err := adminClient.Uninstall(
 		ctx,
 		admin.UninstallArgs{
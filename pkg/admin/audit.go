@@ -0,0 +1,325 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/user"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditOperation denotes the audited admin operation
+type AuditOperation string
+
+// Enum values of AuditOperation type
+const (
+	AuditOperationCordon         AuditOperation = "cordon"
+	AuditOperationUncordon       AuditOperation = "uncordon"
+	AuditOperationLabelDrives    AuditOperation = "label-drives"
+	AuditOperationLabelVolumes   AuditOperation = "label-volumes"
+	AuditOperationMove           AuditOperation = "move"
+	AuditOperationClean          AuditOperation = "clean"
+	AuditOperationRemove         AuditOperation = "remove"
+	AuditOperationSuspendDrives  AuditOperation = "suspend-drives"
+	AuditOperationSuspendVolumes AuditOperation = "suspend-volumes"
+	AuditOperationResumeDrives   AuditOperation = "resume-drives"
+	AuditOperationResumeVolumes  AuditOperation = "resume-volumes"
+	AuditOperationRemountVolumes AuditOperation = "remount-volumes"
+	AuditOperationRepair         AuditOperation = "repair"
+	AuditOperationInit           AuditOperation = "init"
+	AuditOperationUninstall      AuditOperation = "uninstall"
+	AuditOperationImport         AuditOperation = "import"
+	AuditOperationRestore        AuditOperation = "restore"
+	AuditOperationNodeDrain      AuditOperation = "node-drain"
+	AuditOperationNodeRestore    AuditOperation = "node-restore"
+	AuditOperationNodeRemove     AuditOperation = "node-remove"
+)
+
+// AuditChange represents a changed field of the target object
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// AuditTarget represents an object touched by the audited operation
+type AuditTarget struct {
+	Kind    string        `json:"kind"`
+	Name    string        `json:"name"`
+	Changes []AuditChange `json:"changes,omitempty"`
+}
+
+// AuditEntry represents an audit log entry of an admin operation
+type AuditEntry struct {
+	Time      time.Time      `json:"time"`
+	User      string         `json:"user"`
+	Operation AuditOperation `json:"operation"`
+	DryRun    bool           `json:"dryRun"`
+	Args      any            `json:"args,omitempty"`
+	Targets   []AuditTarget  `json:"targets,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// AuditSink represents the storage of audit entries
+type AuditSink interface {
+	// Write appends the entry to the sink.
+	Write(ctx context.Context, entry AuditEntry) error
+	// Read returns all the entries in the sink ordered by time.
+	Read(ctx context.Context) ([]AuditEntry, error)
+}
+
+type auditor struct {
+	sink AuditSink
+	once sync.Once
+	user string
+}
+
+// SetAuditSink enables audit logging of mutating operations to the sink
+func (client *Client) SetAuditSink(sink AuditSink) {
+	if sink == nil {
+		client.auditor = nil
+		return
+	}
+	client.auditor = &auditor{sink: sink}
+}
+
+// AuditSink returns the configured audit sink or nil if audit logging is disabled
+func (client *Client) AuditSink() AuditSink {
+	if client.auditor == nil {
+		return nil
+	}
+	return client.auditor.sink
+}
+
+// getAuditUser returns the user name authenticated by the API server; falls
+// back to the local user name if self subject review is not available.
+func (client *Client) getAuditUser(ctx context.Context) string {
+	client.auditor.once.Do(func() {
+		if client.Client != nil {
+			review, err := client.Kube().AuthenticationV1().SelfSubjectReviews().Create(
+				ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{},
+			)
+			if err == nil && review.Status.UserInfo.Username != "" {
+				client.auditor.user = review.Status.UserInfo.Username
+				return
+			}
+		}
+		if u, err := user.Current(); err == nil {
+			client.auditor.user = "local:" + u.Username
+			return
+		}
+		client.auditor.user = "unknown"
+	})
+	return client.auditor.user
+}
+
+type auditRecord struct {
+	client *Client
+	entry  AuditEntry
+}
+
+// newAuditRecord returns nil if auditing is disabled; all auditRecord methods
+// are safe to call on nil.
+func (client *Client) newAuditRecord(operation AuditOperation, dryRun bool, args any) *auditRecord {
+	if client.auditor == nil {
+		return nil
+	}
+	return &auditRecord{
+		client: client,
+		entry: AuditEntry{
+			Operation: operation,
+			DryRun:    dryRun,
+			Args:      args,
+		},
+	}
+}
+
+// add records the target object with the difference between before and after.
+func (record *auditRecord) add(kind, name string, before, after any) {
+	if record == nil {
+		return
+	}
+	changes, err := diffObjects(before, after)
+	if err != nil {
+		changes = []AuditChange{{Field: "-", After: fmt.Sprintf("unable to compute diff; %v", err)}}
+	}
+	record.entry.Targets = append(record.entry.Targets, AuditTarget{
+		Kind:    kind,
+		Name:    name,
+		Changes: changes,
+	})
+}
+
+// write writes the record to the sink. Failures are logged, but never fail the audited operation.
+func (record *auditRecord) write(ctx context.Context, err error, log LogFunc) {
+	if record == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	record.entry.Time = time.Now().UTC()
+	record.entry.User = record.client.getAuditUser(ctx)
+	if err != nil {
+		record.entry.Error = err.Error()
+	}
+	if werr := record.client.auditor.sink.Write(ctx, record.entry); werr != nil {
+		log(
+			LogMessage{
+				Type:             ErrorLogType,
+				Err:              werr,
+				Message:          "unable to write audit entry",
+				Values:           map[string]any{"operation": record.entry.Operation},
+				FormattedMessage: fmt.Sprintf("unable to write audit entry; %v\n", werr),
+			},
+		)
+	}
+}
+
+// ignoredAuditFields are not meaningful as a change.
+var ignoredAuditFields = []string{
+	"metadata.managedFields",
+	"metadata.resourceVersion",
+	"metadata.generation",
+}
+
+func toFieldMap(obj any) (map[string]any, error) {
+	fields := map[string]any{}
+	if obj == nil || (reflect.ValueOf(obj).Kind() == reflect.Pointer && reflect.ValueOf(obj).IsNil()) {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	var flatten func(prefix string, value any)
+	flatten = func(prefix string, value any) {
+		if slices.Contains(ignoredAuditFields, prefix) {
+			return
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			if prefix != "" {
+				fields[prefix] = value
+			}
+			return
+		}
+		for key, val := range m {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, val)
+		}
+	}
+	flatten("", value)
+
+	return fields, nil
+}
+
+// diffObjects returns the changed leaf fields between two JSON serializable objects.
+func diffObjects(before, after any) ([]AuditChange, error) {
+	beforeFields, err := toFieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []AuditChange
+	for field, beforeValue := range beforeFields {
+		afterValue, found := afterFields[field]
+		if !found || !reflect.DeepEqual(beforeValue, afterValue) {
+			changes = append(changes, AuditChange{Field: field, Before: beforeValue, After: afterValue})
+		}
+	}
+	for field, afterValue := range afterFields {
+		if _, found := beforeFields[field]; !found {
+			changes = append(changes, AuditChange{Field: field, After: afterValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// AuditQueryArgs represents the arguments to query the audit log
+type AuditQueryArgs struct {
+	Operations []AuditOperation
+	Users      []string
+	Targets    []string
+	Since      time.Time
+	Limit      int
+}
+
+func (args AuditQueryArgs) match(entry AuditEntry) bool {
+	if len(args.Operations) != 0 && !slices.Contains(args.Operations, entry.Operation) {
+		return false
+	}
+	if len(args.Users) != 0 && !slices.Contains(args.Users, entry.User) {
+		return false
+	}
+	if !args.Since.IsZero() && entry.Time.Before(args.Since) {
+		return false
+	}
+	if len(args.Targets) == 0 {
+		return true
+	}
+	for _, target := range entry.Targets {
+		if slices.ContainsFunc(args.Targets, func(name string) bool { return strings.EqualFold(name, target.Name) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryAudit returns the matching audit entries from the configured sink; latest entries
+// are returned if limit is set
+func (client *Client) QueryAudit(ctx context.Context, args AuditQueryArgs) ([]AuditEntry, error) {
+	sink := client.AuditSink()
+	if sink == nil {
+		return nil, fmt.Errorf("audit logging is disabled")
+	}
+	entries, err := sink.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []AuditEntry
+	for _, entry := range entries {
+		if args.match(entry) {
+			result = append(result, entry)
+		}
+	}
+	if args.Limit > 0 && len(result) > args.Limit {
+		result = result[len(result)-args.Limit:]
+	}
+	return result, nil
+}
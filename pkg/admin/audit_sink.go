@@ -0,0 +1,224 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/minio/directpv/pkg/consts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// AuditConfigMapName is the name of the ConfigMap used by ConfigMapAuditSink
+	AuditConfigMapName = consts.AppName + "-audit-log"
+
+	// DefaultAuditConfigMapEntries is the default capacity of ConfigMapAuditSink
+	DefaultAuditConfigMapEntries = 1000
+
+	auditConfigMapKey = "entries"
+
+	// ConfigMap object size is limited to 1MiB; leave room for metadata.
+	maxAuditConfigMapDataSize = 900 * 1024
+)
+
+func parseAuditEntries(r io.Reader) (entries []AuditEntry, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err = json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("unable to parse audit entry; %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// FileAuditSink writes audit entries as JSON lines to a local file
+type FileAuditSink struct {
+	filename string
+	mutex    sync.Mutex
+}
+
+// NewFileAuditSink creates a new file audit sink
+func NewFileAuditSink(filename string) *FileAuditSink {
+	return &FileAuditSink{filename: filename}
+}
+
+// Write appends the entry to the file
+func (sink *FileAuditSink) Write(_ context.Context, entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	file, err := os.OpenFile(sink.filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read returns the entries from the file
+func (sink *FileAuditSink) Read(_ context.Context) ([]AuditEntry, error) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	file, err := os.Open(sink.filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+	return parseAuditEntries(file)
+}
+
+// ConfigMapAuditSink stores audit entries in a ConfigMap as a ring buffer;
+// oldest entries are dropped when the capacity or the ConfigMap size limit is reached
+type ConfigMapAuditSink struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	name       string
+	maxEntries int
+}
+
+// NewConfigMapAuditSink creates a new ConfigMap audit sink in the application namespace
+func NewConfigMapAuditSink(kubeClient kubernetes.Interface, maxEntries int) *ConfigMapAuditSink {
+	if maxEntries <= 0 {
+		maxEntries = DefaultAuditConfigMapEntries
+	}
+	return &ConfigMapAuditSink{
+		kubeClient: kubeClient,
+		namespace:  consts.AppName,
+		name:       AuditConfigMapName,
+		maxEntries: maxEntries,
+	}
+}
+
+func (sink *ConfigMapAuditSink) get(ctx context.Context) (*corev1.ConfigMap, []AuditEntry, error) {
+	configMap, err := sink.kubeClient.CoreV1().ConfigMaps(sink.namespace).Get(ctx, sink.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := parseAuditEntries(bytes.NewBufferString(configMap.Data[auditConfigMapKey]))
+	return configMap, entries, err
+}
+
+// toRingData encodes the latest entries fitting within the capacity and size limits.
+func (sink *ConfigMapAuditSink) toRingData(entries []AuditEntry) (string, error) {
+	if len(entries) > sink.maxEntries {
+		entries = entries[len(entries)-sink.maxEntries:]
+	}
+
+	lines := make([][]byte, 0, len(entries))
+	size := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		data, err := json.Marshal(entries[i])
+		if err != nil {
+			return "", err
+		}
+		if size+len(data)+1 > maxAuditConfigMapDataSize {
+			break
+		}
+		size += len(data) + 1
+		lines = append(lines, data)
+	}
+
+	var buf bytes.Buffer
+	for i := len(lines) - 1; i >= 0; i-- {
+		buf.Write(lines[i])
+		buf.WriteByte('\n')
+	}
+	return buf.String(), nil
+}
+
+// Write appends the entry to the ConfigMap
+func (sink *ConfigMapAuditSink) Write(ctx context.Context, entry AuditEntry) error {
+	updateFunc := func() error {
+		configMap, entries, err := sink.get(ctx)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		data, err := sink.toRingData(append(entries, entry))
+		if err != nil {
+			return err
+		}
+
+		if configMap == nil {
+			configMap = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sink.name,
+					Namespace: sink.namespace,
+					Labels:    map[string]string{"application-name": consts.GroupName},
+				},
+				Data: map[string]string{auditConfigMapKey: data},
+			}
+			_, err = sink.kubeClient.CoreV1().ConfigMaps(sink.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Let RetryOnConflict() handle the race.
+				return apierrors.NewConflict(corev1.Resource("configmaps"), sink.name, err)
+			}
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[auditConfigMapKey] = data
+		_, err = sink.kubeClient.CoreV1().ConfigMaps(sink.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
+}
+
+// Read returns the entries from the ConfigMap
+func (sink *ConfigMapAuditSink) Read(ctx context.Context) ([]AuditEntry, error) {
+	_, entries, err := sink.get(ctx)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return entries, err
+}
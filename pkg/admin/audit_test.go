@@ -0,0 +1,110 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiffObjects(t *testing.T) {
+	before := types.NewDrive(
+		"drive-1",
+		types.DriveStatus{Status: directpvtypes.DriveStatusReady},
+		"node-1",
+		"sda",
+		directpvtypes.AccessTierDefault,
+	)
+	after := before.DeepCopy()
+	after.Unschedulable()
+	after.ResourceVersion = "100"
+
+	changes, err := diffObjects(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []AuditChange{{Field: "spec.unschedulable", After: true}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, changes)
+	}
+
+	changes, err = diffObjects(before, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, change := range changes {
+		if change.After != nil {
+			t.Fatalf("unexpected after value in deletion diff; %+v", change)
+		}
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	sink := NewFileAuditSink(path.Join(t.TempDir(), "audit.log"))
+	entries, err := sink.Read(context.Background())
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("unexpected result on empty sink; entries: %v, err: %v", entries, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, op := range []AuditOperation{AuditOperationCordon, AuditOperationRemove, AuditOperationCordon} {
+		if err := sink.Write(context.Background(), AuditEntry{Time: now, User: "admin", Operation: op}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	client := &Client{}
+	client.SetAuditSink(sink)
+	entries, err = client.QueryAudit(context.Background(), AuditQueryArgs{Operations: []AuditOperation{AuditOperationCordon}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected: 2 entries, got: %v", len(entries))
+	}
+}
+
+func TestConfigMapAuditSink(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "directpv"}})
+	sink := NewConfigMapAuditSink(kubeClient, 3)
+	for i := 0; i < 5; i++ {
+		entry := AuditEntry{User: "admin", Operation: AuditOperationLabelDrives, Args: float64(i)}
+		if err := sink.Write(context.Background(), entry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := sink.Read(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected: 3 entries, got: %v", len(entries))
+	}
+	if entries[0].Args != float64(2) || entries[2].Args != float64(4) {
+		t.Fatalf("oldest entries are not dropped; %+v", entries)
+	}
+}
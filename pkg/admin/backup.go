@@ -0,0 +1,144 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var errUnsupportedBackupVersion = errors.New("unsupported backup version")
+
+const latestBackupVersion = "v1"
+
+// Backup holds the latest backup version
+type Backup = BackupV1
+
+// ReadBackup reads the backup from a file
+func ReadBackup(inputFile string) (*Backup, error) {
+	f, err := os.Open(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseBackup(f)
+}
+
+func parseBackup(r io.Reader) (*Backup, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var backup Backup
+	if err := yaml.UnmarshalStrict(data, &backup); err != nil {
+		return nil, err
+	}
+	if backup.Version != latestBackupVersion {
+		return nil, errUnsupportedBackupVersion
+	}
+	return &backup, nil
+}
+
+// Write encodes the backup in YAML and writes to the writer
+func (backup Backup) Write(w io.Writer) error {
+	data, err := yaml.Marshal(backup)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// cleanObjectMeta removes server populated fields of the object metadata.
+func cleanObjectMeta(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.DeletionTimestamp = nil
+	meta.DeletionGracePeriodSeconds = nil
+	meta.ManagedFields = nil
+}
+
+// Backup returns the backup of drives, volumes, quotas and persistent volumes of DirectPV.
+// Nodes are not backed up as node servers re-create them from the probed devices.
+func (client *Client) Backup(ctx context.Context) (*Backup, error) {
+	backup := Backup{
+		Version:   latestBackupVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	drives, err := client.NewDriveLister().Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get drives; %w", err)
+	}
+	for i := range drives {
+		cleanObjectMeta(&drives[i].ObjectMeta)
+		drives[i].TypeMeta = types.NewDriveTypeMeta()
+	}
+	backup.Drives = drives
+
+	volumes, err := client.NewVolumeLister().Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get volumes; %w", err)
+	}
+	for i := range volumes {
+		// Volumes being deleted are not backed up.
+		if volumes[i].DeletionTimestamp != nil {
+			continue
+		}
+		cleanObjectMeta(&volumes[i].ObjectMeta)
+		volumes[i].TypeMeta = types.NewVolumeTypeMeta()
+		backup.Volumes = append(backup.Volumes, volumes[i])
+	}
+
+	quotaList, err := client.Quota("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get quotas; %w", err)
+	}
+	for i := range quotaList.Items {
+		cleanObjectMeta(&quotaList.Items[i].ObjectMeta)
+		quotaList.Items[i].TypeMeta = types.NewQuotaTypeMeta()
+	}
+	backup.Quotas = quotaList.Items
+
+	pvList, err := client.Kube().CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get persistent volumes; %w", err)
+	}
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != consts.Identity {
+			continue
+		}
+		cleanObjectMeta(&pv.ObjectMeta)
+		pv.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"}
+		pv.Status = corev1.PersistentVolumeStatus{}
+		backup.PersistentVolumes = append(backup.PersistentVolumes, pv)
+	}
+
+	return &backup, nil
+}
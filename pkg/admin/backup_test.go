@@ -0,0 +1,191 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newFakeAdminClient(objects []runtime.Object, kubeObjects []runtime.Object) *Client {
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(objects...))
+	return &Client{
+		Client: &client.Client{
+			ClientsetInterface: clientset,
+			DriveClient:        clientset.DirectpvLatest().DirectPVDrives(),
+			VolumeClient:       clientset.DirectpvLatest().DirectPVVolumes(),
+			NodeClient:         clientset.DirectpvLatest().DirectPVNodes(),
+			InitRequestClient:  clientset.DirectpvLatest().DirectPVInitRequests(),
+			K8sClient:          &k8s.Client{KubeClient: fake.NewSimpleClientset(kubeObjects...)},
+		},
+	}
+}
+
+func newTestPV(name, driver string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: "uid-1"},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: name},
+			},
+			ClaimRef: &corev1.ObjectReference{Namespace: "default", Name: "pvc-" + name, UID: "uid-pvc"},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{
+		TotalCapacity: 100 * GiB,
+		FreeCapacity:  10 * GiB,
+		FSUUID:        "fsuuid-1",
+		Status:        directpvtypes.DriveStatusReady,
+	}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{
+		TotalCapacity: 100 * GiB,
+		FreeCapacity:  80 * GiB,
+		FSUUID:        "fsuuid-2",
+		Status:        directpvtypes.DriveStatusReady,
+	}, "node-1", "sdb", directpvtypes.AccessTierDefault)
+	volume1 := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10*GiB)
+	volume2 := types.NewVolume("volume-2", "fsuuid-2", "node-1", "drive-2", "sdb", 20*GiB)
+	drive1.AddVolumeFinalizer("volume-1")
+	drive2.AddVolumeFinalizer("volume-2")
+	node := types.NewNode("node-1", nil)
+	capacity := resource.MustParse("100Gi")
+	quota := types.NewQuota("quota-1", "tenant-1", types.QuotaSpec{Capacity: &capacity})
+	quota.Status.Volumes = 2
+
+	source := newFakeAdminClient(
+		[]runtime.Object{drive1, drive2, volume1, volume2, node, quota},
+		[]runtime.Object{newTestPV("volume-1", consts.Identity), newTestPV("volume-2", consts.Identity), newTestPV("other", "other.csi.io")},
+	)
+
+	ctx := t.Context()
+	backup, err := source.Backup(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Drives) != 2 || len(backup.Volumes) != 2 || len(backup.Quotas) != 1 || len(backup.PersistentVolumes) != 2 {
+		t.Fatalf("unexpected backup %+v", backup)
+	}
+
+	var buf bytes.Buffer
+	if err := backup.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if backup, err = parseBackup(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the device of drive-1 is seen by the node after reinstall.
+	target := newFakeAdminClient(
+		[]runtime.Object{types.NewNode("node-1", []types.Device{{Name: "sda", FSUUID: "fsuuid-1"}})},
+		nil,
+	)
+
+	actions := func(results []RestoreResult) map[string]RestoreAction {
+		m := map[string]RestoreAction{}
+		for _, result := range results {
+			m[result.Kind+"/"+result.Name] = result.Action
+		}
+		return m
+	}
+
+	expectedActions := map[string]RestoreAction{
+		consts.DriveKind + "/drive-1":          RestoreActionCreate,
+		consts.DriveKind + "/drive-2":          RestoreActionSkip,
+		consts.VolumeKind + "/volume-1":        RestoreActionCreate,
+		consts.VolumeKind + "/volume-2":        RestoreActionSkip,
+		consts.QuotaKind + "/tenant-1/quota-1": RestoreActionCreate,
+		"PersistentVolume/volume-1":            RestoreActionCreate,
+		"PersistentVolume/volume-2":            RestoreActionSkip,
+	}
+
+	// Nothing is changed in dry run mode.
+	results, err := target.Restore(ctx, RestoreArgs{Backup: backup, DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(results); len(got) != len(expectedActions) {
+		t.Fatalf("expected: %v, got: %v", expectedActions, got)
+	}
+	if _, err := target.Drive().Get(ctx, "drive-1", metav1.GetOptions{}); err == nil {
+		t.Fatalf("drive must not be created in dry run mode")
+	}
+
+	results, err = target.Restore(ctx, RestoreArgs{Backup: backup}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, action := range actions(results) {
+		if expectedActions[key] != action {
+			t.Fatalf("%v: expected: %v, got: %v", key, expectedActions[key], action)
+		}
+	}
+
+	drive, err := target.Drive().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.AllocatedCapacity != 10*GiB || drive.Status.FreeCapacity != 90*GiB || !drive.VolumeExist("volume-1") {
+		t.Fatalf("unexpected drive status %+v", drive.Status)
+	}
+
+	restoredQuota, err := target.Quota("tenant-1").Get(ctx, "quota-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restoredQuota.Spec.Capacity.Cmp(capacity) != 0 || restoredQuota.Status.Volumes != 0 {
+		t.Fatalf("unexpected quota %+v", restoredQuota)
+	}
+
+	pv, err := target.Kube().CoreV1().PersistentVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pv.UID != "" || pv.Spec.ClaimRef.UID != "" || pv.Status.Phase != "" {
+		t.Fatalf("unexpected persistent volume %+v", pv)
+	}
+
+	// Restored objects are reported as existing on next restore.
+	results, err = target.Restore(ctx, RestoreArgs{Backup: backup, DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := actions(results)
+	if got[consts.VolumeKind+"/volume-1"] != RestoreActionExists || got["PersistentVolume/volume-1"] != RestoreActionExists || got[consts.QuotaKind+"/tenant-1/quota-1"] != RestoreActionExists {
+		t.Fatalf("unexpected results %v", got)
+	}
+	if got[consts.DriveKind+"/drive-1"] != RestoreActionConflict {
+		t.Fatalf("reconciled drive must differ from backup; got %v", got)
+	}
+}
@@ -0,0 +1,34 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"time"
+
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BackupV1 holds DirectPV objects and their persistent volumes
+type BackupV1 struct {
+	Version           string                    `json:"version"`
+	CreatedAt         time.Time                 `json:"createdAt"`
+	Drives            []types.Drive             `json:"drives,omitempty"`
+	Volumes           []types.Volume            `json:"volumes,omitempty"`
+	Quotas            []types.Quota             `json:"quotas,omitempty"`
+	PersistentVolumes []corev1.PersistentVolume `json:"persistentVolumes,omitempty"`
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +35,8 @@ type CleanArgs struct {
 	DriveIDs      []string
 	PodNames      []string
 	PodNamespaces []string
+	PVCNames      []string
+	PVCNamespaces []string
 	VolumeStatus  []directpvtypes.VolumeStatus
 	VolumeNames   []string
 	DryRun        bool
@@ -45,6 +48,9 @@ func (client *Client) Clean(ctx context.Context, args CleanArgs, log LogFunc) (r
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationClean, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
 
@@ -54,6 +60,8 @@ func (client *Client) Clean(ctx context.Context, args CleanArgs, log LogFunc) (r
 		DriveIDSelector(directpvtypes.ToLabelValues(args.DriveIDs)).
 		PodNameSelector(directpvtypes.ToLabelValues(args.PodNames)).
 		PodNSSelector(directpvtypes.ToLabelValues(args.PodNamespaces)).
+		PVCNameSelector(directpvtypes.ToLabelValues(args.PVCNames)).
+		PVCNamespaceSelector(directpvtypes.ToLabelValues(args.PVCNamespaces)).
 		StatusSelector(args.VolumeStatus).
 		VolumeNameSelector(args.VolumeNames).
 		List(ctx)
@@ -91,8 +99,10 @@ func (client *Client) Clean(ctx context.Context, args CleanArgs, log LogFunc) (r
 		if !matchFunc(&result.Volume) {
 			continue
 		}
+		before := result.Volume.DeepCopy()
 		result.Volume.RemovePVProtection()
 		if args.DryRun {
+			record.add(consts.VolumeKind, result.Volume.Name, before, nil)
 			continue
 		}
 		if _, err = client.Volume().Update(ctx, &result.Volume, metav1.UpdateOptions{
@@ -113,6 +123,7 @@ func (client *Client) Clean(ctx context.Context, args CleanArgs, log LogFunc) (r
 		if err = client.Volume().Delete(ctx, result.Volume.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
 			return
 		}
+		record.add(consts.VolumeKind, result.Volume.Name, before, nil)
 		removedVolumes = append(removedVolumes, result.Volume.Name)
 	}
 
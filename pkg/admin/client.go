@@ -18,12 +18,15 @@ package admin
 
 import (
 	"github.com/minio/directpv/pkg/client"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 )
 
 // Client represents the admin clientset
 type Client struct {
 	*client.Client
+	auditor       *auditor
+	dynamicClient dynamic.Interface
 }
 
 // NewClient returns a new admin client
@@ -36,3 +39,11 @@ func NewClient(c *rest.Config) (*Client, error) {
 		Client: directpvClientSet,
 	}, nil
 }
+
+// dynamic returns the dynamic client to access resources not having typed clients e.g. volume snapshots.
+func (client *Client) dynamic() (dynamic.Interface, error) {
+	if client.dynamicClient != nil {
+		return client.dynamicClient, nil
+	}
+	return dynamic.NewForConfig(client.KubeConfig())
+}
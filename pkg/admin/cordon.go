@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,6 +48,9 @@ func (client *Client) Cordon(ctx context.Context, args CordonArgs, log LogFunc)
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationCordon, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -69,21 +74,11 @@ func (client *Client) Cordon(ctx context.Context, args CordonArgs, log LogFunc)
 			continue
 		}
 
-		volumes := result.Drive.GetVolumes()
-		if len(volumes) != 0 {
-			for vresult := range client.NewVolumeLister().VolumeNameSelector(volumes).IgnoreNotFound(true).List(ctx) {
-				if vresult.Err != nil {
-					err = vresult.Err
-					return
-				}
-
-				if vresult.Volume.Status.Status == directpvtypes.VolumeStatusPending {
-					err = fmt.Errorf("unable to cordon drive %v; pending volumes found", result.Drive.GetDriveID())
-					return
-				}
-			}
+		if err = client.checkPendingVolumes(ctx, &result.Drive); err != nil {
+			return
 		}
 
+		before := result.Drive.DeepCopy()
 		result.Drive.Unschedulable()
 		if !args.DryRun {
 			if _, err = client.Drive().Update(ctx, &result.Drive, metav1.UpdateOptions{}); err != nil {
@@ -92,6 +87,8 @@ func (client *Client) Cordon(ctx context.Context, args CordonArgs, log LogFunc)
 			}
 		}
 
+		record.add(consts.DriveKind, result.Drive.Name, before, &result.Drive)
+
 		log(
 			LogMessage{
 				Type:             InfoLogType,
@@ -113,3 +110,20 @@ func (client *Client) Cordon(ctx context.Context, args CordonArgs, log LogFunc)
 	}
 	return
 }
+
+// checkPendingVolumes returns error if the drive has any volume in pending state.
+func (client *Client) checkPendingVolumes(ctx context.Context, drive *types.Drive) error {
+	volumes := drive.GetVolumes()
+	if len(volumes) == 0 {
+		return nil
+	}
+	for result := range client.NewVolumeLister().VolumeNameSelector(volumes).IgnoreNotFound(true).List(ctx) {
+		if result.Err != nil {
+			return result.Err
+		}
+		if result.Volume.Status.Status == directpvtypes.VolumeStatusPending {
+			return fmt.Errorf("unable to cordon drive %v; pending volumes found", drive.GetDriveID())
+		}
+	}
+	return nil
+}
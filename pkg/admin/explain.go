@@ -0,0 +1,291 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/csi/controller"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	volumeSnapshotKind        = "VolumeSnapshot"
+	selectedNodeAnnotationKey = "volume.kubernetes.io/selected-node"
+	csiParameterPrefix        = "csi.storage.k8s.io/"
+	fsTypeParameter           = csiParameterPrefix + "fstype"
+)
+
+var snapshotGroupVersion = schema.GroupVersion{Group: "snapshot.storage.k8s.io", Version: "v1"}
+
+// ExplainPVCArgs represents the args to explain provisioning of a persistent volume claim
+type ExplainPVCArgs struct {
+	Name      string
+	Namespace string
+}
+
+// DriveExplanation represents whether a drive is selectable for the persistent volume claim
+type DriveExplanation struct {
+	NodeID       directpvtypes.NodeID
+	DriveName    directpvtypes.DriveName
+	DriveID      directpvtypes.DriveID
+	FreeCapacity int64
+	// Reason and Message are empty if the drive is selectable.
+	Reason  controller.RejectReason
+	Message string
+}
+
+// ExplainPVCResult represents the explanation of provisioning of a persistent volume claim
+type ExplainPVCResult struct {
+	StorageClass   string
+	VolumeName     string
+	SelectedNode   string
+	RequestedBytes int64
+	Parameters     map[string]string
+	Topologies     []map[string]string
+	Drives         []DriveExplanation
+}
+
+// ExplainPVC replays the storage class parameters and the topology of the persistent volume claim
+// against every drive and returns the reason each drive is rejected.
+func (client *Client) ExplainPVC(ctx context.Context, args ExplainPVCArgs) (*ExplainPVCResult, error) {
+	if args.Name == "" {
+		return nil, errors.New("no persistent volume claim provided")
+	}
+	if args.Namespace == "" {
+		args.Namespace = metav1.NamespaceDefault
+	}
+
+	pvc, err := client.Kube().CoreV1().PersistentVolumeClaims(args.Namespace).Get(ctx, args.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get persistent volume claim %v/%v; %w", args.Namespace, args.Name, err)
+	}
+
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil, fmt.Errorf("persistent volume claim %v/%v has no storage class", args.Namespace, args.Name)
+	}
+	storageClass, err := client.Kube().StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get storage class %v; %w", *pvc.Spec.StorageClassName, err)
+	}
+	if storageClass.Provisioner != consts.Identity {
+		return nil, fmt.Errorf("storage class %v is not provisioned by %v", storageClass.Name, consts.AppPrettyName)
+	}
+
+	result := &ExplainPVCResult{
+		StorageClass: storageClass.Name,
+		VolumeName:   pvc.Spec.VolumeName,
+		SelectedNode: pvc.Annotations[selectedNodeAnnotationKey],
+		Parameters:   map[string]string{},
+	}
+
+	req := &csi.CreateVolumeRequest{
+		Name:       pvc.Spec.VolumeName,
+		Parameters: map[string]string{},
+	}
+
+	if storage, found := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; found {
+		result.RequestedBytes = storage.Value()
+		req.CapacityRange = &csi.CapacityRange{RequiredBytes: result.RequestedBytes}
+	}
+
+	// CSI provisioner passes the storage class parameters except its own prefixed keys.
+	for key, value := range storageClass.Parameters {
+		if !strings.HasPrefix(key, csiParameterPrefix) {
+			req.Parameters[key] = value
+			result.Parameters[key] = value
+		}
+	}
+	req.VolumeCapabilities = []*csi.VolumeCapability{
+		{
+			AccessType: &csi.VolumeCapability_Mount{
+				Mount: &csi.VolumeCapability_MountVolume{FsType: storageClass.Parameters[fsTypeParameter]},
+			},
+		},
+	}
+
+	var topologies []*csi.Topology
+	if result.SelectedNode != "" {
+		segments, err := client.getNodeTopology(ctx, result.SelectedNode)
+		if err != nil {
+			return nil, err
+		}
+		topologies = []*csi.Topology{{Segments: segments}}
+	} else {
+		topologies = toTopologies(storageClass.AllowedTopologies)
+	}
+	if len(topologies) != 0 {
+		req.AccessibilityRequirements = &csi.TopologyRequirement{Requisite: topologies, Preferred: topologies}
+	}
+	for _, topology := range topologies {
+		result.Topologies = append(result.Topologies, topology.GetSegments())
+	}
+
+	snapshot, err := client.getSourceSnapshot(ctx, pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	drives, err := client.NewDriveLister().Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range drives {
+		explanation := DriveExplanation{
+			NodeID:       drives[i].GetNodeID(),
+			DriveName:    drives[i].GetDriveName(),
+			DriveID:      drives[i].GetDriveID(),
+			FreeCapacity: drives[i].Status.FreeCapacity,
+		}
+		rejection := controller.CheckSnapshot(&drives[i], snapshot)
+		if rejection == nil {
+			rejection = controller.CheckDrive(&drives[i], req)
+		}
+		if rejection != nil {
+			explanation.Reason = rejection.Reason
+			explanation.Message = rejection.Message
+		}
+		result.Drives = append(result.Drives, explanation)
+	}
+	sort.Slice(result.Drives, func(i, j int) bool {
+		if result.Drives[i].NodeID != result.Drives[j].NodeID {
+			return result.Drives[i].NodeID < result.Drives[j].NodeID
+		}
+		return result.Drives[i].DriveName < result.Drives[j].DriveName
+	})
+
+	return result, nil
+}
+
+// getSourceSnapshot returns the snapshot of the volume snapshot in the data source of the persistent
+// volume claim, or nil if no data source is requested.
+func (client *Client) getSourceSnapshot(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*types.Snapshot, error) {
+	var apiGroup *string
+	var kind, name string
+	switch {
+	case pvc.Spec.DataSourceRef != nil:
+		apiGroup, kind, name = pvc.Spec.DataSourceRef.APIGroup, pvc.Spec.DataSourceRef.Kind, pvc.Spec.DataSourceRef.Name
+	case pvc.Spec.DataSource != nil:
+		apiGroup, kind, name = pvc.Spec.DataSource.APIGroup, pvc.Spec.DataSource.Kind, pvc.Spec.DataSource.Name
+	default:
+		return nil, nil
+	}
+	if apiGroup == nil || *apiGroup != snapshotGroupVersion.Group || kind != volumeSnapshotKind {
+		return nil, fmt.Errorf("unsupported data source %v of persistent volume claim %v/%v", kind, pvc.Namespace, pvc.Name)
+	}
+
+	dynamicClient, err := client.dynamic()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSnapshot, err := dynamicClient.Resource(snapshotGroupVersion.WithResource("volumesnapshots")).Namespace(pvc.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get volume snapshot %v/%v; %w", pvc.Namespace, name, err)
+	}
+	contentName, _, err := unstructured.NestedString(volumeSnapshot.Object, "status", "boundVolumeSnapshotContentName")
+	if err != nil || contentName == "" {
+		return nil, fmt.Errorf("volume snapshot %v/%v is not bound to a snapshot content", pvc.Namespace, name)
+	}
+
+	content, err := dynamicClient.Resource(snapshotGroupVersion.WithResource("volumesnapshotcontents")).Get(ctx, contentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get volume snapshot content %v; %w", contentName, err)
+	}
+	snapshotHandle, _, err := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	if err != nil || snapshotHandle == "" {
+		return nil, fmt.Errorf("volume snapshot content %v has no snapshot handle", contentName)
+	}
+
+	snapshot, err := client.Snapshot().Get(ctx, snapshotHandle, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot %v; %w", snapshotHandle, err)
+	}
+	return snapshot, nil
+}
+
+// getNodeTopology returns the topology segments of the node as passed by CSI provisioner with strict topology.
+func (client *Client) getNodeTopology(ctx context.Context, nodeName string) (map[string]string, error) {
+	segments := map[string]string{string(directpvtypes.TopologyDriverNode): nodeName}
+
+	csiNode, err := client.Kube().StorageV1().CSINodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return segments, nil
+		}
+		return nil, fmt.Errorf("unable to get CSI node %v; %w", nodeName, err)
+	}
+
+	var topologyKeys []string
+	for _, driver := range csiNode.Spec.Drivers {
+		if driver.Name == consts.Identity {
+			topologyKeys = driver.TopologyKeys
+			break
+		}
+	}
+	if len(topologyKeys) == 0 {
+		return segments, nil
+	}
+
+	node, err := client.Kube().CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get node %v; %w", nodeName, err)
+	}
+	for _, key := range topologyKeys {
+		if value, found := node.Labels[key]; found {
+			segments[key] = value
+		}
+	}
+	return segments, nil
+}
+
+// toTopologies converts allowed topologies of storage class to CSI topologies.
+func toTopologies(terms []corev1.TopologySelectorTerm) (topologies []*csi.Topology) {
+	for _, term := range terms {
+		segmentsList := []map[string]string{{}}
+		for _, requirement := range term.MatchLabelExpressions {
+			var expanded []map[string]string
+			for _, segments := range segmentsList {
+				for _, value := range requirement.Values {
+					segments := maps.Clone(segments)
+					segments[requirement.Key] = value
+					expanded = append(expanded, segments)
+				}
+			}
+			segmentsList = expanded
+		}
+		for _, segments := range segmentsList {
+			if len(segments) != 0 {
+				topologies = append(topologies, &csi.Topology{Segments: segments})
+			}
+		}
+	}
+	return topologies
+}
@@ -0,0 +1,192 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/csi/controller"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestExplainPVC(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	newDrive := func(driveID, nodeID, driveName string, freeCapacity int64) *types.Drive {
+		return types.NewDrive(
+			directpvtypes.DriveID(driveID),
+			types.DriveStatus{
+				Status:        directpvtypes.DriveStatusReady,
+				TotalCapacity: 100 * GiB,
+				FreeCapacity:  freeCapacity,
+				Topology:      map[string]string{string(directpvtypes.TopologyDriverNode): nodeID},
+			},
+			directpvtypes.NodeID(nodeID),
+			directpvtypes.DriveName(driveName),
+			directpvtypes.AccessTierDefault,
+		)
+	}
+
+	drive1 := newDrive("drive-1", "node-1", "sda", 100*GiB)
+	drive2 := newDrive("drive-2", "node-1", "sdb", 5*GiB)
+	drive3 := newDrive("drive-3", "node-1", "sdc", 100*GiB)
+	drive3.Unschedulable()
+	drive4 := newDrive("drive-4", "node-2", "sda", 100*GiB)
+
+	storageClassName := "directpv-min-io"
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+		Provisioner: consts.Identity,
+		Parameters:  map[string]string{"csi.storage.k8s.io/fstype": "xfs"},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "data-minio-0",
+			Namespace:   "tenant-1",
+			Annotations: map[string]string{"volume.kubernetes.io/selected-node": "node-1"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+
+	client := newFakeAdminClient(
+		[]runtime.Object{drive1, drive2, drive3, drive4},
+		[]runtime.Object{storageClass, pvc},
+	)
+
+	if _, err := client.ExplainPVC(t.Context(), ExplainPVCArgs{Name: "data-minio-0"}); err == nil {
+		t.Fatalf("expected error for PVC in wrong namespace")
+	}
+
+	result, err := client.ExplainPVC(t.Context(), ExplainPVCArgs{Name: "data-minio-0", Namespace: "tenant-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequestedBytes != 10*GiB || result.StorageClass != storageClassName {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	expectedReasons := map[directpvtypes.DriveID]controller.RejectReason{
+		"drive-1": "",
+		"drive-2": controller.RejectReasonCapacity,
+		"drive-3": controller.RejectReasonCordoned,
+		"drive-4": controller.RejectReasonTopology,
+	}
+	if len(result.Drives) != len(expectedReasons) {
+		t.Fatalf("expected: %v drives, got: %v", len(expectedReasons), len(result.Drives))
+	}
+	for _, drive := range result.Drives {
+		if expected := expectedReasons[drive.DriveID]; drive.Reason != expected {
+			t.Fatalf("drive %v: expected: %q, got: %q (%v)", drive.DriveID, expected, drive.Reason, drive.Message)
+		}
+	}
+	if result.Drives[0].NodeID != "node-1" || result.Drives[0].DriveName != "sda" {
+		t.Fatalf("drives are not sorted; %+v", result.Drives)
+	}
+}
+
+func TestExplainPVCSnapshot(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	newDrive := func(driveID, driveName string) *types.Drive {
+		drive := types.NewDrive(
+			directpvtypes.DriveID(driveID),
+			types.DriveStatus{
+				Status:        directpvtypes.DriveStatusReady,
+				TotalCapacity: 100 * GiB,
+				FreeCapacity:  100 * GiB,
+				Topology:      map[string]string{string(directpvtypes.TopologyDriverNode): "node-1"},
+				LVM:           &types.DriveLVM{VGName: "vg-" + driveID},
+			},
+			"node-1",
+			directpvtypes.DriveName(driveName),
+			directpvtypes.AccessTierDefault,
+		)
+		drive.SetLabel(directpvtypes.BackendLabelKey, directpvtypes.LabelValue(directpvtypes.BackendLVMThin))
+		return drive
+	}
+	drive1 := newDrive("drive-1", "sda")
+	drive2 := newDrive("drive-2", "sdb")
+
+	volume := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10*GiB)
+	snapshot := types.NewSnapshot("snapshot-1", volume)
+
+	storageClassName := "directpv-lvm-thin"
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+		Provisioner: consts.Identity,
+		Parameters:  map[string]string{string(directpvtypes.BackendLabelKey): string(directpvtypes.BackendLVMThin)},
+	}
+	apiGroup := "snapshot.storage.k8s.io"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-restored", Namespace: "tenant-1"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClassName,
+			DataSource:       &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: "VolumeSnapshot", Name: "data-snapshot"},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata":   map[string]any{"name": "data-snapshot", "namespace": "tenant-1"},
+		"status":     map[string]any{"boundVolumeSnapshotContentName": "snapcontent-1"},
+	}}
+	content := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshotContent",
+		"metadata":   map[string]any{"name": "snapcontent-1"},
+		"status":     map[string]any{"snapshotHandle": "snapshot-1"},
+	}}
+
+	client := newFakeAdminClient(
+		[]runtime.Object{drive1, drive2, snapshot},
+		[]runtime.Object{storageClass, pvc},
+	)
+	client.dynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), volumeSnapshot, content)
+
+	result, err := client.ExplainPVC(t.Context(), ExplainPVCArgs{Name: "data-restored", Namespace: "tenant-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedReasons := map[directpvtypes.DriveID]controller.RejectReason{
+		"drive-1": "",
+		"drive-2": controller.RejectReasonSnapshot,
+	}
+	for _, drive := range result.Drives {
+		if expected := expectedReasons[drive.DriveID]; drive.Reason != expected {
+			t.Fatalf("drive %v: expected: %q, got: %q (%v)", drive.DriveID, expected, drive.Reason, drive.Message)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// ImportVolumesArgs denotes the args for importing volume directories found on the drives
+type ImportVolumesArgs struct {
+	Nodes       []string
+	Drives      []string
+	VolumeNames []string
+	// Claims maps volume name to the persistent volume claim in NAMESPACE/NAME format.
+	Claims       map[string]string
+	CreatePV     bool
+	StorageClass string
+	DryRun       bool
+}
+
+// ImportVolumeResult represents the imported volume
+type ImportVolumeResult struct {
+	NodeID       directpvtypes.NodeID
+	VolumeName   string
+	DriveID      directpvtypes.DriveID
+	DriveName    directpvtypes.DriveName
+	Size         int64
+	PVCName      string
+	PVCNamespace string
+	PVCreated    bool
+}
+
+// ParseClaim parses the persistent volume claim in [NAMESPACE/]NAME format.
+func ParseClaim(value string) (namespace, name string, err error) {
+	namespace = "default"
+	name = value
+	if tokens := strings.SplitN(value, "/", 2); len(tokens) == 2 {
+		namespace, name = tokens[0], tokens[1]
+	}
+	if namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid persistent volume claim %v", value)
+	}
+	return namespace, name, nil
+}
+
+// ImportVolumes creates volumes for the volume directories reported by the nodes
+// which have no volume object; nodes must be refreshed prior to this call.
+func (client *Client) ImportVolumes(ctx context.Context, args ImportVolumesArgs, log LogFunc) (results []ImportVolumeResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	record := client.newAuditRecord(AuditOperationImport, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	storageClass := args.StorageClass
+	if storageClass == "" {
+		storageClass = consts.StorageClassName
+	}
+
+	nodes, err := client.NewNodeLister().
+		NodeSelector(directpvtypes.ToLabelValues(args.Nodes)).
+		Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logError := func(volumeDir types.VolumeDir, nodeID directpvtypes.NodeID, err error) {
+		log(
+			LogMessage{
+				Type:             ErrorLogType,
+				Err:              err,
+				Message:          "unable to import volume",
+				Values:           map[string]any{"node": nodeID, "volume": volumeDir.Name},
+				FormattedMessage: fmt.Sprintf("%v/%v: %v\n", nodeID, volumeDir.Name, err),
+			},
+		)
+	}
+
+	for _, node := range nodes {
+		nodeID := directpvtypes.NodeID(node.Name)
+		for _, volumeDir := range node.Status.VolumeDirs {
+			if len(args.VolumeNames) != 0 && !slices.Contains(args.VolumeNames, volumeDir.Name) {
+				continue
+			}
+
+			_, err := client.Volume().Get(ctx, volumeDir.Name, metav1.GetOptions{})
+			switch {
+			case err == nil:
+				// Volume is already managed.
+				continue
+			case !apierrors.IsNotFound(err):
+				return results, err
+			}
+
+			drive, err := client.Drive().Get(ctx, string(volumeDir.DriveID), metav1.GetOptions{})
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					return results, err
+				}
+				logError(volumeDir, nodeID, fmt.Errorf("drive %v not found", volumeDir.DriveID))
+				continue
+			}
+			if len(args.Drives) != 0 && !slices.Contains(args.Drives, string(drive.GetDriveName())) {
+				continue
+			}
+
+			switch {
+			case drive.GetNodeID() != nodeID || drive.Status.FSUUID != volumeDir.FSUUID:
+				err = fmt.Errorf("drive %v does not match the volume directory", volumeDir.DriveID)
+			case drive.Status.Status != directpvtypes.DriveStatusReady:
+				err = fmt.Errorf("drive %v is not ready", drive.GetDriveName())
+			case volumeDir.Size <= 0:
+				err = errors.New("no project quota found")
+			case drive.Status.FreeCapacity < volumeDir.Size:
+				err = fmt.Errorf("insufficient free capacity on drive %v", drive.GetDriveName())
+			}
+			if err != nil {
+				logError(volumeDir, nodeID, err)
+				continue
+			}
+
+			var pvcNamespace, pvcName string
+			if claim, found := args.Claims[volumeDir.Name]; found {
+				if pvcNamespace, pvcName, err = ParseClaim(claim); err != nil {
+					return results, err
+				}
+			}
+
+			volume := types.NewVolume(volumeDir.Name, volumeDir.FSUUID, nodeID, drive.GetDriveID(), drive.GetDriveName(), volumeDir.Size)
+			volume.Status.DataPath = types.GetVolumeDir(volumeDir.FSUUID, volumeDir.Name)
+			volume.SetPVCName(pvcName)
+			volume.SetPVCNamespace(pvcNamespace)
+
+			var pv *corev1.PersistentVolume
+			if args.CreatePV {
+				pv = newImportedPV(volume, drive.GetFSType(), storageClass, pvcNamespace, pvcName)
+			}
+
+			if !args.DryRun {
+				if err = client.importVolume(ctx, volume, pv); err != nil {
+					return results, fmt.Errorf("unable to import volume %v; %w", volume.Name, err)
+				}
+			}
+			record.add(consts.VolumeKind, volume.Name, nil, volume.Status)
+
+			log(
+				LogMessage{
+					Type:             InfoLogType,
+					Message:          "volume imported",
+					Values:           map[string]any{"node": nodeID, "volume": volume.Name, "size": volumeDir.Size},
+					FormattedMessage: fmt.Sprintf("Volume %v/%v imported\n", nodeID, volume.Name),
+				},
+			)
+
+			results = append(results, ImportVolumeResult{
+				NodeID:       nodeID,
+				VolumeName:   volume.Name,
+				DriveID:      drive.GetDriveID(),
+				DriveName:    drive.GetDriveName(),
+				Size:         volumeDir.Size,
+				PVCName:      pvcName,
+				PVCNamespace: pvcNamespace,
+				PVCreated:    pv != nil,
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoMatchingResourcesFound
+	}
+	return results, nil
+}
+
+// importVolume creates the volume, reserves its capacity on the drive and creates the PV if provided.
+func (client *Client) importVolume(ctx context.Context, volume *types.Volume, pv *corev1.PersistentVolume) error {
+	if _, err := client.Volume().Create(ctx, volume, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	updateFunc := func() error {
+		drive, err := client.Drive().Get(ctx, string(volume.GetDriveID()), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !drive.AddVolumeFinalizer(volume.Name) {
+			return nil
+		}
+		drive.Status.FreeCapacity -= volume.Status.TotalCapacity
+		drive.Status.AllocatedCapacity += volume.Status.TotalCapacity
+		_, err = client.Drive().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+		return err
+	}
+
+	if pv != nil {
+		if _, err := client.Kube().CoreV1().PersistentVolumes().Create(ctx, pv, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// newImportedPV returns static persistent volume of the imported volume; the data is retained on PV release.
+func newImportedPV(volume *types.Volume, fsType, storageClass, pvcNamespace, pvcName string) *corev1.PersistentVolume {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        volume.Name,
+			Annotations: map[string]string{"pv.kubernetes.io/provisioned-by": consts.Identity},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: *resource.NewQuantity(volume.Status.TotalCapacity, resource.BinarySI),
+			},
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			StorageClassName:              storageClass,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       consts.Identity,
+					VolumeHandle: volume.Name,
+					FSType:       fsType,
+				},
+			},
+			NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{
+									Key:      string(directpvtypes.TopologyDriverNode),
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{string(volume.GetNodeID())},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if pvcName != "" {
+		pv.Spec.ClaimRef = &corev1.ObjectReference{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+			Namespace:  pvcNamespace,
+			Name:       pvcName,
+		}
+	}
+	return pv
+}
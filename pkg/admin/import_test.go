@@ -0,0 +1,146 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestImportVolumes(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	drive := types.NewDrive("drive-1", types.DriveStatus{
+		TotalCapacity: 100 * GiB,
+		FreeCapacity:  90 * GiB,
+		FSUUID:        "fsuuid-1",
+		Status:        directpvtypes.DriveStatusReady,
+	}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	existingVolume := types.NewVolume("volume-0", "fsuuid-1", "node-1", "drive-1", "sda", 10*GiB)
+	node := types.NewNode("node-1", nil)
+	node.Status.VolumeDirs = []types.VolumeDir{
+		{Name: "volume-0", DriveID: "drive-1", FSUUID: "fsuuid-1", Size: 10 * GiB},
+		{Name: "volume-1", DriveID: "drive-1", FSUUID: "fsuuid-1", Size: 20 * GiB},
+		{Name: "volume-2", DriveID: "drive-1", FSUUID: "fsuuid-1"},
+		{Name: "volume-3", DriveID: "drive-1", FSUUID: "fsuuid-1", Size: 100 * GiB},
+		{Name: "volume-4", DriveID: "drive-2", FSUUID: "fsuuid-2", Size: 1 * GiB},
+	}
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive, existingVolume, node))
+	kubeClient := fake.NewSimpleClientset()
+	adminClient := &Client{
+		Client: &client.Client{
+			ClientsetInterface: clientset,
+			DriveClient:        clientset.DirectpvLatest().DirectPVDrives(),
+			VolumeClient:       clientset.DirectpvLatest().DirectPVVolumes(),
+			NodeClient:         clientset.DirectpvLatest().DirectPVNodes(),
+			K8sClient:          &k8s.Client{KubeClient: kubeClient},
+		},
+	}
+
+	ctx := t.Context()
+	args := ImportVolumesArgs{
+		Claims:   map[string]string{"volume-1": "tenant-1/data-minio-0"},
+		CreatePV: true,
+	}
+
+	// Nothing is changed in dry run mode.
+	args.DryRun = true
+	results, err := adminClient.ImportVolumes(ctx, args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].VolumeName != "volume-1" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if _, err := adminClient.Volume().Get(ctx, "volume-1", metav1.GetOptions{}); err == nil {
+		t.Fatalf("volume must not be created in dry run mode")
+	}
+
+	args.DryRun = false
+	if _, err := adminClient.ImportVolumes(ctx, args, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	volume, err := adminClient.Volume().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Status.TotalCapacity != 20*GiB || volume.GetPVCName() != "data-minio-0" || volume.GetPVCNamespace() != "tenant-1" {
+		t.Fatalf("unexpected volume %+v", volume)
+	}
+	if volume.Status.DataPath != types.GetVolumeDir("fsuuid-1", "volume-1") {
+		t.Fatalf("unexpected data path %v", volume.Status.DataPath)
+	}
+
+	drive, err = adminClient.Drive().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.FreeCapacity != 70*GiB || !drive.VolumeExist("volume-1") {
+		t.Fatalf("unexpected drive status %+v", drive.Status)
+	}
+
+	pv, err := kubeClient.CoreV1().PersistentVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pv.Spec.CSI.VolumeHandle != "volume-1" || pv.Spec.ClaimRef.Namespace != "tenant-1" || pv.Spec.ClaimRef.Name != "data-minio-0" {
+		t.Fatalf("unexpected PV spec %+v", pv.Spec)
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		t.Fatalf("unexpected reclaim policy %v", pv.Spec.PersistentVolumeReclaimPolicy)
+	}
+
+	// Already imported volumes are skipped.
+	if _, err := adminClient.ImportVolumes(ctx, args, nil); !errors.Is(err, ErrNoMatchingResourcesFound) {
+		t.Fatalf("expected: %v, got: %v", ErrNoMatchingResourcesFound, err)
+	}
+}
+
+func TestParseClaim(t *testing.T) {
+	testCases := []struct {
+		value             string
+		expectedNamespace string
+		expectedName      string
+		expectErr         bool
+	}{
+		{"data-minio-0", "default", "data-minio-0", false},
+		{"tenant-1/data-minio-0", "tenant-1", "data-minio-0", false},
+		{"tenant-1/", "", "", true},
+		{"/data-minio-0", "", "", true},
+	}
+
+	for i, testCase := range testCases {
+		namespace, name, err := ParseClaim(testCase.value)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if namespace != testCase.expectedNamespace || name != testCase.expectedName {
+			t.Fatalf("case %v: expected: %v/%v, got: %v/%v", i+1, testCase.expectedNamespace, testCase.expectedName, namespace, name)
+		}
+	}
+}
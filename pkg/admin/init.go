@@ -0,0 +1,62 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InitArgs represents the arguments to initialize the devices
+type InitArgs struct {
+	InitRequests []types.InitRequest
+	RequestID    string
+}
+
+// CreateInitRequests creates the init requests to initialize the devices
+func (client *Client) CreateInitRequests(ctx context.Context, args InitArgs, log LogFunc) (initRequests []types.InitRequest, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	record := client.newAuditRecord(AuditOperationInit, false, map[string]any{"requestID": args.RequestID})
+	defer func() { record.write(ctx, err, log) }()
+
+	for i := range args.InitRequests {
+		initRequest, err := client.InitRequest().Create(ctx, &args.InitRequests[i], metav1.CreateOptions{TypeMeta: types.NewInitRequestTypeMeta()})
+		if err != nil {
+			return initRequests, err
+		}
+		record.add(consts.InitRequestKind, initRequest.Name, nil, initRequest.Spec)
+
+		log(
+			LogMessage{
+				Type:             InfoLogType,
+				Message:          "init request created",
+				Values:           map[string]any{"requestID": initRequest.Name, "node": initRequest.GetNodeID()},
+				FormattedMessage: fmt.Sprintf("Processing initialization request '%s' for node '%v'\n", initRequest.Name, initRequest.GetNodeID()),
+			},
+		)
+		initRequests = append(initRequests, *initRequest)
+	}
+
+	return initRequests, nil
+}
@@ -18,13 +18,18 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/luks"
 	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/xfs"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +38,12 @@ const (
 	DriveSelectedValue = "yes"
 )
 
+// minPartitionSize is the minimum size of a partition to be initialized as a drive.
+const minPartitionSize = 512 * 1024 * 1024 // 512 MiB
+
+// MinVirtualDriveSize is the minimum size of a virtual drive.
+const MinVirtualDriveSize = 512 * 1024 * 1024 // 512 MiB
+
 var errUnsupportedInitConfigVersion = errors.New("unsupported init config version")
 
 const latestInitConfigVersion = "v1"
@@ -53,6 +64,27 @@ func NewInitConfig() InitConfig {
 	}
 }
 
+// NewVirtualInitConfig returns init config having count virtual drives of given size on each node.
+func NewVirtualInitConfig(nodes []directpvtypes.NodeID, count int, size uint64) (*InitConfig, error) {
+	config := NewInitConfig()
+	for _, node := range nodes {
+		nodeInfo := NodeInfo{Name: node}
+		for i := range count {
+			nodeInfo.Drives = append(nodeInfo.Drives, DriveInfo{
+				Name:    fmt.Sprintf("virtual%v", i+1),
+				Size:    size,
+				Select:  DriveSelectedValue,
+				Virtual: true,
+			})
+		}
+		config.Nodes = append(config.Nodes, nodeInfo)
+	}
+	if err := config.validateVirtualDrives(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
 // ReadInitConfig reads the init config from a file
 func ReadInitConfig(inputFile string) (*InitConfig, error) {
 	f, err := os.Open(inputFile)
@@ -71,9 +103,175 @@ func parseInitConfig(r io.Reader) (*InitConfig, error) {
 	if config.Version != latestInitConfigVersion {
 		return nil, errUnsupportedInitConfigVersion
 	}
+	if err := config.validateProfiles(); err != nil {
+		return nil, err
+	}
+	if err := config.validateEncryption(); err != nil {
+		return nil, err
+	}
+	if err := config.validatePartitions(); err != nil {
+		return nil, err
+	}
+	if err := config.validateBackends(); err != nil {
+		return nil, err
+	}
+	if err := config.validateVirtualDrives(); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
 
+func (config InitConfig) getProfile(name string) (profile xfs.Profile, found bool) {
+	if profile, found = config.Profiles[name]; found {
+		return profile, true
+	}
+	profile, found = xfs.Profiles[name]
+	return profile, found
+}
+
+func (config InitConfig) validateProfiles() error {
+	for name, profile := range config.Profiles {
+		if _, found := xfs.Profiles[name]; found {
+			return fmt.Errorf("profile %v conflicts with built-in profile", name)
+		}
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("invalid profile %v; %w", name, err)
+		}
+	}
+
+	for _, node := range config.Nodes {
+		for _, drive := range node.Drives {
+			if drive.FSType != "" && !fs.Supported(drive.FSType) {
+				return fmt.Errorf("unsupported filesystem %v for drive %v on node %v", drive.FSType, drive.Name, node.Name)
+			}
+			if drive.Profile == "" {
+				continue
+			}
+			if drive.FSType != "" && drive.FSType != fs.XFS {
+				return fmt.Errorf("profile is not supported on %v filesystem for drive %v on node %v", drive.FSType, drive.Name, node.Name)
+			}
+			if _, found := config.getProfile(drive.Profile); !found {
+				return fmt.Errorf("unknown profile %v for drive %v on node %v", drive.Profile, drive.Name, node.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (config InitConfig) validateEncryption() error {
+	if config.Encryption != nil {
+		if config.Encryption.KeyRef == "" {
+			return errors.New("key reference must be provided for encryption")
+		}
+		if config.Encryption.KeyProvider == "" || config.Encryption.KeyProvider == luks.SecretKeyProvider {
+			if _, _, err := luks.ParseSecretRef(config.Encryption.KeyRef); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, node := range config.Nodes {
+		for _, drive := range node.Drives {
+			if strings.ToLower(drive.Encrypt) == DriveSelectedValue && config.Encryption == nil {
+				return fmt.Errorf("encryption is not configured for drive %v on node %v", drive.Name, node.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (config InitConfig) validatePartitions() error {
+	for _, node := range config.Nodes {
+		for _, drive := range node.Drives {
+			if drive.Partitions < 0 {
+				return fmt.Errorf("invalid partitions %v for drive %v on node %v", drive.Partitions, drive.Name, node.Name)
+			}
+			if drive.Partitions > 0 && drive.Size != 0 && drive.Size/uint64(drive.Partitions) < minPartitionSize {
+				return fmt.Errorf("partitions of drive %v on node %v are smaller than %v", drive.Name, node.Name, humanize.IBytes(minPartitionSize))
+			}
+		}
+	}
+	return nil
+}
+
+func (config InitConfig) validateBackends() error {
+	for _, node := range config.Nodes {
+		for _, drive := range node.Drives {
+			if drive.Backend == "" {
+				continue
+			}
+			backend, err := directpvtypes.ToBackend(drive.Backend)
+			if err != nil {
+				return fmt.Errorf("invalid backend for drive %v on node %v; %w", drive.Name, node.Name, err)
+			}
+			if backend != directpvtypes.BackendLVMThin {
+				continue
+			}
+			if drive.FSType != "" && drive.FSType != fs.XFS {
+				return fmt.Errorf("%v filesystem is not supported on %v backend for drive %v on node %v", drive.FSType, backend, drive.Name, node.Name)
+			}
+			if strings.ToLower(drive.Encrypt) == DriveSelectedValue {
+				return fmt.Errorf("encryption is not supported on %v backend for drive %v on node %v", backend, drive.Name, node.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (config InitConfig) validateVirtualDrives() error {
+	for _, node := range config.Nodes {
+		for _, drive := range node.Drives {
+			if !drive.Virtual {
+				continue
+			}
+			if drive.Size < MinVirtualDriveSize {
+				return fmt.Errorf(
+					"virtual drive %v on node %v must be at least %v",
+					drive.Name, node.Name, humanize.IBytes(MinVirtualDriveSize),
+				)
+			}
+			if drive.Partitions != 0 {
+				return fmt.Errorf("partitions are not supported on virtual drive %v on node %v", drive.Name, node.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (config InitConfig) toEncryption(encrypt string) *types.Encryption {
+	if strings.ToLower(encrypt) != DriveSelectedValue || config.Encryption == nil {
+		return nil
+	}
+	return &types.Encryption{
+		KeyProvider: config.Encryption.KeyProvider,
+		KeyRef:      config.Encryption.KeyRef,
+	}
+}
+
+func (config InitConfig) toFormatProfile(name string) *types.FormatProfile {
+	if name == "" {
+		return nil
+	}
+	profile, found := config.getProfile(name)
+	if !found {
+		return nil
+	}
+	return &types.FormatProfile{
+		Name:        name,
+		BlockSize:   profile.BlockSize,
+		InodeSize:   profile.InodeSize,
+		LogSize:     profile.LogSize,
+		StripeUnit:  profile.StripeUnit,
+		StripeWidth: profile.StripeWidth,
+		CRC:         profile.CRC,
+		BigTime:     profile.BigTime,
+		InobtCount:  profile.InobtCount,
+		RmapBT:      profile.RmapBT,
+	}
+}
+
 // Write encodes the YAML to the stream provided
 func (config InitConfig) Write(w io.Writer) error {
 	encoder := yaml.NewEncoder(w)
@@ -119,9 +317,15 @@ func (config *InitConfig) ToInitRequestObjects() (initRequests []types.InitReque
 				continue
 			}
 			initDevices = append(initDevices, types.InitDevice{
-				ID:    device.ID,
-				Name:  device.Name,
-				Force: device.FS != "",
+				ID:            device.ID,
+				Name:          device.Name,
+				Force:         device.FS != "",
+				FSType:        device.FSType,
+				FormatProfile: config.toFormatProfile(device.Profile),
+				Encryption:    config.toEncryption(device.Encrypt),
+				Partitions:    device.Partitions,
+				Backend:       strings.ToLower(device.Backend),
+				VirtualSize:   toVirtualSize(device),
 			})
 		}
 		if len(initDevices) > 0 {
@@ -130,3 +334,10 @@ func (config *InitConfig) ToInitRequestObjects() (initRequests []types.InitReque
 	}
 	return
 }
+
+func toVirtualSize(device DriveInfo) int64 {
+	if !device.Virtual {
+		return 0
+	}
+	return int64(device.Size)
+}
@@ -0,0 +1,174 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/types"
+)
+
+func TestParseInitConfigProfiles(t *testing.T) {
+	testCases := []struct {
+		config      string
+		expectedErr bool
+	}{
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    profile: large-log\n", false},
+		{"version: v1\nprofiles:\n  raid:\n    stripeUnit: 64k\n    stripeWidth: 4\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    profile: raid\n", false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    profile: unknown\n", true},
+		{"version: v1\nprofiles:\n  raid:\n    stripeUnit: 64k\n", true},
+		{"version: v1\nprofiles:\n  default:\n    logSize: 64m\n", true},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    fsType: btrfs\n", true},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    fsType: ext4\n    profile: large-log\n", true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := parseInitConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		initRequests, _ := config.ToInitRequestObjects()
+		if len(initRequests) != 1 || initRequests[0].Spec.Devices[0].FormatProfile == nil {
+			t.Fatalf("case %v: expected format profile in init request", i+1)
+		}
+	}
+}
+
+func TestParseInitConfigEncryption(t *testing.T) {
+	testCases := []struct {
+		config             string
+		expectedEncryption *types.Encryption
+		expectedErr        bool
+	}{
+		{"version: v1\nencryption:\n  keyRef: luks-key\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    encrypt: \"yes\"\n", &types.Encryption{KeyRef: "luks-key"}, false},
+		{"version: v1\nencryption:\n  keyProvider: kes\n  keyRef: tenant-1/key\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    encrypt: \"yes\"\n", &types.Encryption{KeyProvider: "kes", KeyRef: "tenant-1/key"}, false},
+		{"version: v1\nencryption:\n  keyRef: luks-key\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n", nil, false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    encrypt: \"yes\"\n", nil, true},
+		{"version: v1\nencryption:\n  keyRef: a/b/c\n", nil, true},
+		{"version: v1\nencryption:\n  keyProvider: secret\n", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := parseInitConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		initRequests, _ := config.ToInitRequestObjects()
+		if len(initRequests) != 1 {
+			t.Fatalf("case %v: expected one init request; got: %v", i+1, len(initRequests))
+		}
+		if encryption := initRequests[0].Spec.Devices[0].Encryption; !reflect.DeepEqual(encryption, testCase.expectedEncryption) {
+			t.Fatalf("case %v: encryption: expected: %+v; got: %+v", i+1, testCase.expectedEncryption, encryption)
+		}
+	}
+}
+
+func TestParseInitConfigPartitions(t *testing.T) {
+	testCases := []struct {
+		config             string
+		expectedPartitions int
+		expectedErr        bool
+	}{
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: nvme0n1\n    size: 4294967296\n    select: \"yes\"\n    partitions: 4\n", 4, false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: nvme0n1\n    size: 4294967296\n    select: \"yes\"\n", 0, false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: nvme0n1\n    size: 4294967296\n    select: \"yes\"\n    partitions: 16\n", 0, true},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: nvme0n1\n    select: \"yes\"\n    partitions: -1\n", 0, true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := parseInitConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		initRequests, _ := config.ToInitRequestObjects()
+		if len(initRequests) != 1 {
+			t.Fatalf("case %v: expected one init request; got: %v", i+1, len(initRequests))
+		}
+		if partitions := initRequests[0].Spec.Devices[0].Partitions; partitions != testCase.expectedPartitions {
+			t.Fatalf("case %v: partitions: expected: %v; got: %v", i+1, testCase.expectedPartitions, partitions)
+		}
+	}
+}
+
+func TestParseInitConfigBackends(t *testing.T) {
+	testCases := []struct {
+		config          string
+		expectedBackend string
+		expectedErr     bool
+	}{
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    backend: lvm-thin\n", "lvm-thin", false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n", "", false},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    backend: zfs\n", "", true},
+		{"version: v1\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    backend: lvm-thin\n    fsType: ext4\n", "", true},
+		{"version: v1\nencryption:\n  keyRef: luks-key\nnodes:\n- name: node1\n  drives:\n  - id: a\n    name: sda\n    select: \"yes\"\n    backend: lvm-thin\n    encrypt: \"yes\"\n", "", true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := parseInitConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		initRequests, _ := config.ToInitRequestObjects()
+		if backend := initRequests[0].Spec.Devices[0].Backend; backend != testCase.expectedBackend {
+			t.Fatalf("case %v: backend: expected: %v; got: %v", i+1, testCase.expectedBackend, backend)
+		}
+	}
+}
+
+func TestNewVirtualInitConfig(t *testing.T) {
+	config, err := NewVirtualInitConfig([]directpvtypes.NodeID{"node1", "node2"}, 2, MinVirtualDriveSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	initRequests, _ := config.ToInitRequestObjects()
+	if len(initRequests) != 2 {
+		t.Fatalf("init requests: expected: 2; got: %v", len(initRequests))
+	}
+	for _, initRequest := range initRequests {
+		if len(initRequest.Spec.Devices) != 2 {
+			t.Fatalf("devices: expected: 2; got: %v", len(initRequest.Spec.Devices))
+		}
+		for _, device := range initRequest.Spec.Devices {
+			if device.VirtualSize != MinVirtualDriveSize {
+				t.Fatalf("virtual size: expected: %v; got: %v", MinVirtualDriveSize, device.VirtualSize)
+			}
+		}
+	}
+
+	if _, err := NewVirtualInitConfig([]directpvtypes.NodeID{"node1"}, 1, MinVirtualDriveSize-1); err == nil {
+		t.Fatalf("expected error for too small virtual drive")
+	}
+
+	if _, err := parseInitConfig(strings.NewReader("version: v1\nnodes:\n- name: node1\n  drives:\n  - name: virtual1\n    size: 1073741824\n    select: \"yes\"\n    virtual: true\n    partitions: 2\n")); err == nil {
+		t.Fatalf("expected error for partitions on virtual drive")
+	}
+}
@@ -16,12 +16,23 @@
 
 package admin
 
-import directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+import (
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/xfs"
+)
 
 // InitConfigV1 defines the config to initialize the devices
 type InitConfigV1 struct {
-	Version string       `yaml:"version" json:"version"`
-	Nodes   []NodeInfoV1 `yaml:"nodes,omitempty" json:"nodes,omitempty"`
+	Version    string                 `yaml:"version" json:"version"`
+	Profiles   map[string]xfs.Profile `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+	Encryption *EncryptionV1          `yaml:"encryption,omitempty" json:"encryption,omitempty"`
+	Nodes      []NodeInfoV1           `yaml:"nodes,omitempty" json:"nodes,omitempty"`
+}
+
+// EncryptionV1 holds the key of LUKS2 encryption of the drives
+type EncryptionV1 struct {
+	KeyProvider string `yaml:"keyProvider,omitempty" json:"keyProvider,omitempty"`
+	KeyRef      string `yaml:"keyRef" json:"keyRef"`
 }
 
 // NodeInfoV1 holds the node information
@@ -32,10 +43,19 @@ type NodeInfoV1 struct {
 
 // DriveInfoV1 represents the drives that are to be initialized
 type DriveInfoV1 struct {
-	ID     string `yaml:"id" json:"id"`
-	Name   string `yaml:"name" json:"name"`
-	Size   uint64 `yaml:"size" json:"size"`
-	Make   string `yaml:"make" json:"make"`
-	FS     string `yaml:"fs,omitempty" json:"fs,omitempty"`
-	Select string `yaml:"select,omitempty" json:"select,omitempty"`
+	ID      string `yaml:"id" json:"id"`
+	Name    string `yaml:"name" json:"name"`
+	Size    uint64 `yaml:"size" json:"size"`
+	Make    string `yaml:"make" json:"make"`
+	FS      string `yaml:"fs,omitempty" json:"fs,omitempty"`
+	Select  string `yaml:"select,omitempty" json:"select,omitempty"`
+	Profile string `yaml:"profile,omitempty" json:"profile,omitempty"`
+	FSType  string `yaml:"fsType,omitempty" json:"fsType,omitempty"`
+	Encrypt string `yaml:"encrypt,omitempty" json:"encrypt,omitempty"`
+	// Partitions denotes the number of equally sized partitions to create on the drive by a new GPT.
+	Partitions int `yaml:"partitions,omitempty" json:"partitions,omitempty"`
+	// Backend denotes how volumes are provisioned on the drive i.e. directory or lvm-thin.
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"`
+	// Virtual denotes a file-backed loop device of size is created and initialized as a virtual drive; ID is ignored.
+	Virtual bool `yaml:"virtual,omitempty" json:"virtual,omitempty"`
 }
@@ -44,6 +44,12 @@ var nodesYAML []byte
 //go:embed directpv.min.io_directpvinitrequests.yaml
 var initrequestsYAML []byte
 
+//go:embed directpv.min.io_directpvquotas.yaml
+var quotasYAML []byte
+
+//go:embed directpv.min.io_directpvsnapshots.yaml
+var snapshotsYAML []byte
+
 type crdTask struct {
 	client *client.Client
 }
@@ -53,7 +59,7 @@ func (crdTask) Name() string {
 }
 
 func (crdTask) Start(ctx context.Context, args *Args) error {
-	if !sendStartMessage(ctx, args.ProgressCh, 4) {
+	if !sendStartMessage(ctx, args.ProgressCh, 6) {
 		return errSendProgress
 	}
 	return nil
@@ -227,7 +233,15 @@ func (t crdTask) createCRDs(ctx context.Context, args *Args) (err error) {
 		return err
 	}
 
-	return register(initrequestsYAML, 4)
+	if err := register(initrequestsYAML, 4); err != nil {
+		return err
+	}
+
+	if err := register(quotasYAML, 5); err != nil {
+		return err
+	}
+
+	return register(snapshotsYAML, 6)
 }
 
 func (t crdTask) removeVolumes(ctx context.Context) error {
@@ -335,6 +349,31 @@ func (t crdTask) removeInitRequests(ctx context.Context) error {
 	return nil
 }
 
+func (t crdTask) removeSnapshots(ctx context.Context) error {
+	snapshotList, err := t.client.Snapshot().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range snapshotList.Items {
+		snapshot := &snapshotList.Items[i]
+		snapshot.Finalizers = []string{}
+		_, err := t.client.Snapshot().Update(ctx, snapshot, metav1.UpdateOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		err = t.client.Snapshot().Delete(ctx, snapshot.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t crdTask) deleteCRDs(ctx context.Context, force bool) error {
 	if !force {
 		return nil
@@ -356,6 +395,10 @@ func (t crdTask) deleteCRDs(ctx context.Context, force bool) error {
 		return err
 	}
 
+	if err := t.removeSnapshots(ctx); err != nil {
+		return err
+	}
+
 	driveCRDName := consts.DriveResource + "." + consts.GroupName
 	err := t.client.CRD().Delete(ctx, driveCRDName, metav1.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -380,5 +423,17 @@ func (t crdTask) deleteCRDs(ctx context.Context, force bool) error {
 		return err
 	}
 
+	quotaCRDName := consts.QuotaResource + "." + consts.GroupName
+	err = t.client.CRD().Delete(ctx, quotaCRDName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	snapshotCRDName := consts.SnapshotResource + "." + consts.GroupName
+	err = t.client.CRD().Delete(ctx, snapshotCRDName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
 	return nil
 }
@@ -114,6 +114,7 @@ func (t deploymentTask) doCreateDeployment(ctx context.Context, args *Args, lega
 					"--leader-election",
 					"--feature-gates=Topology=true",
 					"--strict-topology",
+					"--extra-create-metadata",
 				},
 				Env: []corev1.EnvVar{csiEndpointEnvVar},
 				VolumeMounts: []corev1.VolumeMount{
@@ -180,22 +181,35 @@ func (t deploymentTask) doCreateDeployment(ctx context.Context, args *Args, lega
 		},
 	}
 
-	var selectorValue string
-	if !args.DryRun {
-		deployment, err := t.client.Kube().AppsV1().Deployments(namespace).Get(
-			ctx, name, metav1.GetOptions{},
+	if !legacy {
+		optional := true
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: webhookCertsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: webhookSecretName,
+					Optional:   &optional,
+				},
+			},
+		})
+		container := &podSpec.Containers[len(podSpec.Containers)-1]
+		container.VolumeMounts = append(
+			container.VolumeMounts,
+			k8s.NewVolumeMount(webhookCertsVolume, consts.AdmissionWebhookCertDir, corev1.MountPropagationNone, true),
+		)
+		container.Ports = append(
+			append([]corev1.ContainerPort{}, commonContainerPorts...),
+			corev1.ContainerPort{
+				ContainerPort: consts.AdmissionWebhookPort,
+				Name:          webhookPortName,
+				Protocol:      corev1.ProtocolTCP,
+			},
 		)
-		if err != nil && !apierrors.IsNotFound(err) {
-			return err
-		}
-		if err == nil {
-			if deployment.Spec.Selector != nil && deployment.Spec.Selector.MatchLabels != nil {
-				selectorValue = deployment.Spec.Selector.MatchLabels[selectorKey]
-			}
-		}
 	}
-	if selectorValue == "" {
-		selectorValue = fmt.Sprintf("%v-%v", consts.ControllerServerName, name)
+
+	selectorValue, err := getControllerSelectorValue(ctx, t.client, args, name)
+	if err != nil {
+		return err
 	}
 
 	replicas := int32(3)
@@ -245,6 +259,27 @@ func (t deploymentTask) doCreateDeployment(ctx context.Context, args *Args, lega
 	return args.writeObject(deployment)
 }
 
+// getControllerSelectorValue returns the pod selector value of existing deployment
+// or the default one.
+func getControllerSelectorValue(ctx context.Context, client *client.Client, args *Args, name string) (string, error) {
+	if !args.DryRun {
+		deployment, err := client.Kube().AppsV1().Deployments(namespace).Get(
+			ctx, name, metav1.GetOptions{},
+		)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		if err == nil {
+			if deployment.Spec.Selector != nil && deployment.Spec.Selector.MatchLabels != nil {
+				if value := deployment.Spec.Selector.MatchLabels[selectorKey]; value != "" {
+					return value, nil
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%v-%v", consts.ControllerServerName, name), nil
+}
+
 func (t deploymentTask) createDeployment(ctx context.Context, args *Args) (err error) {
 	if err := t.doCreateDeployment(ctx, args, false, 1); err != nil {
 		return err
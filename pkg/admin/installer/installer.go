@@ -35,6 +35,7 @@ func GetDefaultTasks(client *client.Client, legacyClient *legacyclient.Client) [
 		csiDriverTask{client},
 		storageClassTask{client},
 		daemonsetTask{client},
+		webhookTask{client},
 		deploymentTask{client},
 	}
 }
@@ -95,7 +96,18 @@ func Uninstall(ctx context.Context, quiet, force bool, tasks []Task) (err error)
 		ForceUninstall: force,
 		Quiet:          quiet,
 	}
+	// Remove the admission webhook first so that it does not reject removal of finalizers.
 	for _, task := range tasks {
+		if _, ok := task.(webhookTask); ok {
+			if err := task.Delete(ctx, args); err != nil {
+				return err
+			}
+		}
+	}
+	for _, task := range tasks {
+		if _, ok := task.(webhookTask); ok {
+			continue
+		}
 		if err := task.Delete(ctx, args); err != nil {
 			return err
 		}
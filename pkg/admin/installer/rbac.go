@@ -159,11 +159,19 @@ func (t rbacTask) createClusterRole(ctx context.Context, args *Args) (err error)
 				createVerb, deleteVerb, getVerb, listVerb, patchVerb, updateVerb, watchVerb,
 			),
 			newPolicyRule(
-				[]string{consts.DriveResource, consts.VolumeResource, consts.NodeResource, consts.InitRequestResource},
+				[]string{consts.DriveResource, consts.VolumeResource, consts.NodeResource, consts.InitRequestResource, consts.QuotaResource, consts.SnapshotResource},
 				[]string{consts.GroupName},
 				createVerb, deleteVerb, getVerb, listVerb, updateVerb, watchVerb,
 			),
 			newPolicyRule([]string{"pods"}, nil, getVerb, listVerb, watchVerb),
+			newPolicyRule([]string{"volumesnapshotclasses"}, []string{"snapshot.storage.k8s.io"}, getVerb, listVerb, watchVerb),
+			newPolicyRule([]string{"volumesnapshots"}, []string{"snapshot.storage.k8s.io"}, getVerb, listVerb, watchVerb),
+			newPolicyRule(
+				[]string{"volumesnapshotcontents"},
+				[]string{"snapshot.storage.k8s.io"},
+				getVerb, listVerb, patchVerb, updateVerb, watchVerb,
+			),
+			newPolicyRule([]string{"volumesnapshotcontents/status"}, []string{"snapshot.storage.k8s.io"}, patchVerb, updateVerb),
 			newPolicyRule([]string{"secrets"}, nil, getVerb, listVerb, watchVerb),
 		},
 		AggregationRule: nil,
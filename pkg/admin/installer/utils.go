@@ -133,6 +133,27 @@ func deploymentComponent(name string) *Component {
 	}
 }
 
+func secretComponent(name string) *Component {
+	return &Component{
+		Name: name,
+		Kind: "Secret",
+	}
+}
+
+func serviceComponent(name string) *Component {
+	return &Component{
+		Name: name,
+		Kind: "Service",
+	}
+}
+
+func validatingWebhookConfigurationComponent(name string) *Component {
+	return &Component{
+		Name: name,
+		Kind: "ValidatingWebhookConfiguration",
+	}
+}
+
 func migrateLog(ctx context.Context, args *Args, errMsg string, showInProgress bool) error {
 	switch {
 	case args.ProgressCh != nil:
@@ -0,0 +1,364 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/minio/directpv/pkg/admission"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	webhookName            = "validate." + consts.GroupName
+	webhookServiceName     = consts.Identity + "-webhook"
+	webhookSecretName      = consts.Identity + "-webhook-tls"
+	webhookCertsVolume     = "webhook-certs"
+	webhookPortName        = "webhook"
+	webhookCACertKey       = "ca.crt"
+	webhookCertValidity    = 10 * 365 * 24 * time.Hour
+	webhookCertRenewBefore = 30 * 24 * time.Hour
+)
+
+type webhookTask struct {
+	client *client.Client
+}
+
+func (webhookTask) Name() string {
+	return "AdmissionWebhook"
+}
+
+func (webhookTask) Start(ctx context.Context, args *Args) error {
+	if !sendStartMessage(ctx, args.ProgressCh, 3) {
+		return errSendProgress
+	}
+	return nil
+}
+
+func (webhookTask) End(ctx context.Context, args *Args, err error) error {
+	if !sendEndMessage(ctx, args.ProgressCh, err) {
+		return errSendProgress
+	}
+	return nil
+}
+
+func (t webhookTask) Execute(ctx context.Context, args *Args) error {
+	caCert, err := t.createSecret(ctx, args)
+	if err != nil {
+		return err
+	}
+	if err := t.createService(ctx, args); err != nil {
+		return err
+	}
+	return t.createValidatingWebhookConfiguration(ctx, args, caCert)
+}
+
+func (t webhookTask) Delete(ctx context.Context, _ *Args) error {
+	err := t.client.Kube().AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, webhookName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = t.client.Kube().CoreV1().Services(namespace).Delete(ctx, webhookServiceName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = t.client.Kube().CoreV1().Secrets(namespace).Delete(ctx, webhookSecretName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func encodePEM(blockType string, data []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data})
+}
+
+// generateWebhookCerts generates self-signed CA and the serving certificate
+// of the webhook service.
+func generateWebhookCerts() (caCert, cert, key []byte, err error) {
+	now := time.Now()
+	notAfter := now.Add(webhookCertValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: consts.Identity + "-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dnsName := fmt.Sprintf("%v.%v.svc", webhookServiceName, namespace)
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{webhookServiceName, webhookServiceName + "." + namespace, dnsName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(serverKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return encodePEM("CERTIFICATE", caDER), encodePEM("CERTIFICATE", serverDER), encodePEM("EC PRIVATE KEY", keyDER), nil
+}
+
+// isWebhookSecretValid checks whether the existing secret can be reused.
+func isWebhookSecretValid(secret *corev1.Secret) bool {
+	if len(secret.Data[webhookCACertKey]) == 0 {
+		return false
+	}
+	keyPair, err := tls.X509KeyPair(secret.Data[admission.CertFile], secret.Data[admission.KeyFile])
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return false
+	}
+	return time.Now().Add(webhookCertRenewBefore).Before(cert.NotAfter)
+}
+
+func (t webhookTask) createSecret(ctx context.Context, args *Args) (caCert []byte, err error) {
+	if !sendProgressMessage(ctx, args.ProgressCh, "Creating admission webhook secret", 1, nil) {
+		return nil, errSendProgress
+	}
+	defer func() {
+		if err == nil {
+			if !sendProgressMessage(ctx, args.ProgressCh, "Created admission webhook secret", 1, secretComponent(webhookSecretName)) {
+				err = errSendProgress
+			}
+		}
+	}()
+
+	secretsClient := t.client.Kube().CoreV1().Secrets(namespace)
+
+	var existing *corev1.Secret
+	if !args.DryRun && !args.Declarative {
+		existing, err = secretsClient.Get(ctx, webhookSecretName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			existing = nil
+		case err != nil:
+			return nil, err
+		case isWebhookSecretValid(existing):
+			existing.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+			return existing.Data[webhookCACertKey], args.writeObject(existing)
+		}
+	}
+
+	caCert, cert, key, err := generateWebhookCerts()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate admission webhook certificates; %w", err)
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhookSecretName,
+			Namespace: namespace,
+			Labels:    defaultLabels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			webhookCACertKey:   caCert,
+			admission.CertFile: cert,
+			admission.KeyFile:  key,
+		},
+	}
+
+	if !args.DryRun && !args.Declarative {
+		if existing == nil {
+			_, err = secretsClient.Create(ctx, secret, metav1.CreateOptions{})
+		} else {
+			secret.ResourceVersion = existing.ResourceVersion
+			_, err = secretsClient.Update(ctx, secret, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return caCert, args.writeObject(secret)
+}
+
+func (t webhookTask) createService(ctx context.Context, args *Args) (err error) {
+	if !sendProgressMessage(ctx, args.ProgressCh, "Creating admission webhook service", 2, nil) {
+		return errSendProgress
+	}
+	defer func() {
+		if err == nil {
+			if !sendProgressMessage(ctx, args.ProgressCh, "Created admission webhook service", 2, serviceComponent(webhookServiceName)) {
+				err = errSendProgress
+			}
+		}
+	}()
+
+	selectorValue, err := getControllerSelectorValue(ctx, t.client, args, consts.ControllerServerName)
+	if err != nil {
+		return err
+	}
+
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhookServiceName,
+			Namespace: namespace,
+			Labels:    defaultLabels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{selectorKey: selectorValue},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       webhookPortName,
+					Port:       443,
+					TargetPort: intstr.FromString(webhookPortName),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if !args.DryRun && !args.Declarative {
+		_, err = t.client.Kube().CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return args.writeObject(service)
+}
+
+func (t webhookTask) createValidatingWebhookConfiguration(ctx context.Context, args *Args, caCert []byte) (err error) {
+	if !sendProgressMessage(ctx, args.ProgressCh, "Creating validating webhook configuration", 3, nil) {
+		return errSendProgress
+	}
+	defer func() {
+		if err == nil {
+			if !sendProgressMessage(ctx, args.ProgressCh, "Created validating webhook configuration", 3, validatingWebhookConfigurationComponent(webhookName)) {
+				err = errSendProgress
+			}
+		}
+	}()
+
+	path := consts.AdmissionWebhookPath
+	failurePolicy := admissionregistrationv1.Ignore
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	matchPolicy := admissionregistrationv1.Equivalent
+	timeoutSeconds := int32(5)
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   webhookName,
+			Labels: defaultLabels,
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: webhookName,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: namespace,
+						Name:      webhookServiceName,
+						Path:      &path,
+					},
+					CABundle: caCert,
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+							admissionregistrationv1.Delete,
+						},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{consts.GroupName},
+							APIVersions: []string{"*"},
+							Resources:   []string{consts.DriveResource, consts.VolumeResource},
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicy,
+				MatchPolicy:             &matchPolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          &timeoutSeconds,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+
+	if !args.DryRun && !args.Declarative {
+		configClient := t.client.Kube().AdmissionregistrationV1().ValidatingWebhookConfigurations()
+		existing, err := configClient.Get(ctx, webhookName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			_, err = configClient.Create(ctx, config, metav1.CreateOptions{})
+		case err == nil:
+			config.ResourceVersion = existing.ResourceVersion
+			_, err = configClient.Update(ctx, config, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return args.writeObject(config)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -62,6 +63,9 @@ func (client *Client) LabelDrives(ctx context.Context, args LabelDriveArgs, labe
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationLabelDrives, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	for _, label := range labels {
 		if label.Key.IsReserved() {
 			action := "use"
@@ -94,6 +98,7 @@ func (client *Client) LabelDrives(ctx context.Context, args LabelDriveArgs, labe
 		var verb string
 		for i := range labels {
 			updateFunc := func() (err error) {
+				before := drive.DeepCopy()
 				if labels[i].Remove {
 					if ok := drive.RemoveLabel(labels[i].Key); !ok {
 						return
@@ -119,6 +124,7 @@ func (client *Client) LabelDrives(ctx context.Context, args LabelDriveArgs, labe
 						},
 					)
 				} else {
+					record.add(consts.DriveKind, drive.Name, before, drive)
 					log(
 						LogMessage{
 							Type:             InfoLogType,
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -50,6 +51,9 @@ func (client *Client) LabelVolumes(ctx context.Context, args LabelVolumeArgs, la
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationLabelVolumes, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	for _, label := range labels {
 		if label.Key.IsReserved() {
 			action := "use"
@@ -85,6 +89,7 @@ func (client *Client) LabelVolumes(ctx context.Context, args LabelVolumeArgs, la
 		volume := &result.Volume
 		for i := range labels {
 			updateFunc := func() (err error) {
+				before := volume.DeepCopy()
 				if labels[i].Remove {
 					if ok := volume.RemoveLabel(labels[i].Key); !ok {
 						return
@@ -110,6 +115,7 @@ func (client *Client) LabelVolumes(ctx context.Context, args LabelVolumeArgs, la
 						},
 					)
 				} else {
+					record.add(consts.VolumeKind, volume.Name, before, volume)
 					log(
 						LogMessage{
 							Type:             InfoLogType,
@@ -23,6 +23,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -34,11 +35,14 @@ type MoveArgs struct {
 }
 
 // Move - moves the volume references from source to destination
-func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) error {
+func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) (err error) {
 	if log == nil {
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationMove, false, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	if args.Source == args.Destination {
 		return errors.New("source and destination drives are same")
 	}
@@ -52,6 +56,10 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 		return errors.New("source drive is not cordoned")
 	}
 
+	if srcDrive.GetBackend() != directpvtypes.BackendDirectory {
+		return fmt.Errorf("volumes of %v backend cannot be moved", srcDrive.GetBackend())
+	}
+
 	sourceVolumeNames := srcDrive.GetVolumes()
 	if len(sourceVolumeNames) == 0 {
 		return fmt.Errorf("no volumes found in source drive %v", args.Source)
@@ -89,6 +97,9 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 	if destDrive.Status.Status != directpvtypes.DriveStatusReady {
 		return errors.New("destination drive is not in ready state")
 	}
+	if destDrive.GetBackend() != directpvtypes.BackendDirectory {
+		return fmt.Errorf("volumes cannot be moved to %v backend", destDrive.GetBackend())
+	}
 
 	if srcDrive.GetAccessTier() != destDrive.GetAccessTier() {
 		return fmt.Errorf("source drive access-tier %v and destination drive access-tier %v differ",
@@ -102,6 +113,7 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 			humanize.Comma(destDrive.Status.FreeCapacity))
 	}
 
+	destDriveBefore := destDrive.DeepCopy()
 	for _, volume := range volumes {
 		if destDrive.AddVolumeFinalizer(volume.Name) {
 			destDrive.Status.FreeCapacity -= volume.Status.TotalCapacity
@@ -115,6 +127,7 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 	if err != nil {
 		return fmt.Errorf("unable to move volumes to destination drive; %w", err)
 	}
+	record.add(consts.DriveKind, destDrive.Name, destDriveBefore, destDrive)
 
 	for _, volume := range volumes {
 		log(
@@ -127,6 +140,7 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 		)
 	}
 
+	srcDriveBefore := srcDrive.DeepCopy()
 	srcDrive.ResetFinalizers()
 	_, err = client.Drive().Update(
 		ctx, srcDrive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()},
@@ -134,5 +148,6 @@ func (client *Client) Move(ctx context.Context, args MoveArgs, log LogFunc) erro
 	if err != nil {
 		return fmt.Errorf("unable to remove volume references in source drive; %w", err)
 	}
+	record.add(consts.DriveKind, srcDrive.Name, srcDriveBefore, srcDrive)
 	return nil
 }
@@ -0,0 +1,188 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// NodeDrainArgs represents the args to drain the node(s)
+type NodeDrainArgs struct {
+	Nodes []string
+	// SuspendVolumes suspends the volumes of the node in SuspendMode.
+	SuspendVolumes bool
+	SuspendMode    directpvtypes.SuspendMode
+	DryRun         bool
+}
+
+// NodeDrainResult represents the drained node
+type NodeDrainResult struct {
+	NodeID  directpvtypes.NodeID
+	Drives  []CordonResult
+	Volumes []SuspendVolumeResult
+}
+
+// NodeDrain cordons all drives of the node(s) and optionally suspends their volumes.
+// Drives and volumes changed by drain are labeled to be reverted by NodeRestore.
+func (client *Client) NodeDrain(ctx context.Context, args NodeDrainArgs, log LogFunc) (results []NodeDrainResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	if len(args.Nodes) == 0 {
+		return nil, errors.New("no node provided")
+	}
+
+	record := client.newAuditRecord(AuditOperationNodeDrain, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	mode := args.SuspendMode
+	if mode == "" {
+		mode = directpvtypes.SuspendModeEmpty
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	for _, node := range args.Nodes {
+		nodeID := directpvtypes.NodeID(node)
+		result := NodeDrainResult{NodeID: nodeID}
+		var processed bool
+
+		for dresult := range client.NewDriveLister().NodeSelector(directpvtypes.ToLabelValues([]string{node})).List(ctx) {
+			if dresult.Err != nil {
+				err = dresult.Err
+				return
+			}
+
+			processed = true
+
+			drive := dresult.Drive
+			if drive.IsUnschedulable() {
+				continue
+			}
+
+			if err = client.checkPendingVolumes(ctx, &drive); err != nil {
+				return
+			}
+
+			before := drive.DeepCopy()
+			drive.Unschedulable()
+			drive.SetLabel(directpvtypes.DrainedLabelKey, "true")
+			if !args.DryRun {
+				if _, err = client.Drive().Update(ctx, &drive, metav1.UpdateOptions{}); err != nil {
+					err = fmt.Errorf("unable to cordon drive %v; %w", drive.GetDriveID(), err)
+					return
+				}
+			}
+
+			record.add(consts.DriveKind, drive.Name, before, &drive)
+
+			log(
+				LogMessage{
+					Type:             InfoLogType,
+					Message:          "drive cordoned",
+					Values:           map[string]any{"nodeId": nodeID, "driveName": drive.GetDriveName()},
+					FormattedMessage: fmt.Sprintf("Drive %v/%v cordoned\n", nodeID, drive.GetDriveName()),
+				},
+			)
+
+			result.Drives = append(result.Drives, CordonResult{
+				NodeID:    nodeID,
+				DriveName: drive.GetDriveName(),
+				DriveID:   drive.GetDriveID(),
+			})
+		}
+
+		if !processed {
+			continue
+		}
+
+		if args.SuspendVolumes {
+			if result.Volumes, err = client.suspendNodeVolumes(ctx, node, mode, args.DryRun, record, log); err != nil {
+				return
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoMatchingResourcesFound
+	}
+	return
+}
+
+func (client *Client) suspendNodeVolumes(ctx context.Context, node string, mode directpvtypes.SuspendMode, dryRun bool, record *auditRecord, log LogFunc) (results []SuspendVolumeResult, err error) {
+	volumeClient := client.Volume()
+	for result := range client.NewVolumeLister().NodeSelector(directpvtypes.ToLabelValues([]string{node})).List(ctx) {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		if result.Volume.IsSuspended() {
+			continue
+		}
+
+		updateFunc := func() error {
+			volume, err := volumeClient.Get(ctx, result.Volume.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			before := volume.DeepCopy()
+			volume.SuspendWithMode(mode)
+			volume.SetLabel(directpvtypes.DrainedLabelKey, "true")
+			if !dryRun {
+				if _, err := volumeClient.Update(ctx, volume, metav1.UpdateOptions{}); err != nil {
+					return err
+				}
+			}
+			record.add(consts.VolumeKind, volume.Name, before, volume)
+			return nil
+		}
+		if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			return nil, fmt.Errorf("unable to suspend volume %v; %w", result.Volume.Name, err)
+		}
+
+		log(
+			LogMessage{
+				Type:             InfoLogType,
+				Message:          "volume suspended",
+				Values:           map[string]any{"node": node, "volume": result.Volume.Name, "mode": mode},
+				FormattedMessage: fmt.Sprintf("Volume %v/%v suspended in %v mode\n", node, result.Volume.Name, mode),
+			},
+		)
+
+		results = append(results, SuspendVolumeResult{
+			NodeID:       result.Volume.GetNodeID(),
+			VolumeName:   result.Volume.Name,
+			DriveID:      result.Volume.GetDriveID(),
+			DriveName:    result.Volume.GetDriveName(),
+			PodName:      result.Volume.GetPodName(),
+			PodNamespace: result.Volume.GetPodNS(),
+			Mode:         mode,
+		})
+	}
+	return
+}
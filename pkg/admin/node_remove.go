@@ -0,0 +1,204 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeRemoveArgs represents the args to remove the node(s)
+type NodeRemoveArgs struct {
+	Nodes  []string
+	DryRun bool
+}
+
+// NodeRemoveResult represents the removed node
+type NodeRemoveResult struct {
+	NodeID       directpvtypes.NodeID
+	Drives       []RemoveResult
+	InitRequests []string
+	// NodeRemoved denotes the DirectPVNode object of the node is removed.
+	NodeRemoved bool
+}
+
+type nodeObjects struct {
+	nodeID       directpvtypes.NodeID
+	drives       []types.Drive
+	initRequests []types.InitRequest
+	node         *types.Node
+}
+
+// NodeRemove removes the drives, the init requests and the DirectPVNode object of the node(s).
+// The nodes must not have volumes and DirectPV must not be running on them.
+func (client *Client) NodeRemove(ctx context.Context, args NodeRemoveArgs, log LogFunc) (results []NodeRemoveResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	if len(args.Nodes) == 0 {
+		return nil, errors.New("no node provided")
+	}
+
+	record := client.newAuditRecord(AuditOperationNodeRemove, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	// Verify all nodes before removing anything.
+	var objectsList []*nodeObjects
+	for _, node := range args.Nodes {
+		objects, err := client.getNodeObjects(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if objects != nil {
+			objectsList = append(objectsList, objects)
+		}
+	}
+
+	if len(objectsList) == 0 {
+		return nil, ErrNoMatchingResourcesFound
+	}
+
+	for _, objects := range objectsList {
+		result := NodeRemoveResult{NodeID: objects.nodeID}
+
+		for i := range objects.drives {
+			drive := &objects.drives[i]
+			before := drive.DeepCopy()
+			drive.RemoveFinalizers()
+			if !args.DryRun {
+				if _, err := client.Drive().Update(ctx, drive, metav1.UpdateOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return results, fmt.Errorf("unable to remove drive %v; %w", drive.GetDriveID(), err)
+				}
+				if err := client.Drive().Delete(ctx, drive.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return results, fmt.Errorf("unable to remove drive %v; %w", drive.GetDriveID(), err)
+				}
+			}
+			record.add(consts.DriveKind, drive.Name, before, nil)
+			log(
+				LogMessage{
+					Type:             InfoLogType,
+					Message:          "removing drive",
+					Values:           map[string]any{"node": objects.nodeID, "driveName": drive.GetDriveName()},
+					FormattedMessage: fmt.Sprintf("Removing drive %v/%v\n", objects.nodeID, drive.GetDriveName()),
+				},
+			)
+			result.Drives = append(result.Drives, RemoveResult{
+				NodeID:    objects.nodeID,
+				DriveName: drive.GetDriveName(),
+				DriveID:   drive.GetDriveID(),
+			})
+		}
+
+		for i := range objects.initRequests {
+			initRequest := &objects.initRequests[i]
+			if !args.DryRun {
+				if err := client.InitRequest().Delete(ctx, initRequest.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return results, fmt.Errorf("unable to remove init request %v; %w", initRequest.Name, err)
+				}
+			}
+			record.add(consts.InitRequestKind, initRequest.Name, initRequest, nil)
+			result.InitRequests = append(result.InitRequests, initRequest.Name)
+		}
+
+		if objects.node != nil {
+			if !args.DryRun {
+				if err := client.Node().Delete(ctx, objects.node.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return results, fmt.Errorf("unable to remove node %v; %w", objects.nodeID, err)
+				}
+			}
+			record.add(consts.NodeKind, objects.node.Name, objects.node, nil)
+			result.NodeRemoved = true
+		}
+
+		log(
+			LogMessage{
+				Type:             InfoLogType,
+				Message:          "node removed",
+				Values:           map[string]any{"node": objects.nodeID},
+				FormattedMessage: fmt.Sprintf("Node %v removed\n", objects.nodeID),
+			},
+		)
+
+		results = append(results, result)
+	}
+
+	return
+}
+
+// getNodeObjects returns the DirectPV objects of the node after verifying the node is removable.
+// It returns nil if the node has no DirectPV objects.
+func (client *Client) getNodeObjects(ctx context.Context, node string) (*nodeObjects, error) {
+	csiNode, err := client.Kube().StorageV1().CSINodes().Get(ctx, node, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		for _, driver := range csiNode.Spec.Drivers {
+			if driver.Name == consts.Identity {
+				return nil, fmt.Errorf("node %v is still in use; remove node %v from DirectPV DaemonSet and try again", node, node)
+			}
+		}
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("unable to get CSI node %v; %w", node, err)
+	}
+
+	nodes := directpvtypes.ToLabelValues([]string{node})
+
+	volumes, err := client.NewVolumeLister().NodeSelector(nodes).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) != 0 {
+		return nil, fmt.Errorf("node %v has %v volume(s); remove them and try again", node, len(volumes))
+	}
+
+	objects := &nodeObjects{nodeID: directpvtypes.NodeID(node)}
+
+	if objects.drives, err = client.NewDriveLister().NodeSelector(nodes).Get(ctx); err != nil {
+		return nil, err
+	}
+	for _, drive := range objects.drives {
+		if drive.GetVolumeCount() > 0 {
+			return nil, fmt.Errorf("drive %v/%v still has volume(s); remove them and try again", node, drive.GetDriveName())
+		}
+	}
+
+	if objects.initRequests, err = client.NewInitRequestLister().NodeSelector(nodes).Get(ctx); err != nil {
+		return nil, err
+	}
+
+	if objects.node, err = client.Node().Get(ctx, node, metav1.GetOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		objects.node = nil
+	}
+
+	if len(objects.drives) == 0 && len(objects.initRequests) == 0 && objects.node == nil {
+		return nil, nil
+	}
+	return objects, nil
+}
@@ -0,0 +1,156 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// NodeRestoreArgs represents the args to restore the drained node(s)
+type NodeRestoreArgs struct {
+	Nodes  []string
+	DryRun bool
+}
+
+// NodeRestoreResult represents the restored node
+type NodeRestoreResult struct {
+	NodeID  directpvtypes.NodeID
+	Drives  []UncordonResult
+	Volumes []ResumeVolumeResult
+}
+
+// NodeRestore reverts NodeDrain by uncordoning the drives and resuming the volumes drained on the node(s).
+func (client *Client) NodeRestore(ctx context.Context, args NodeRestoreArgs, log LogFunc) (results []NodeRestoreResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	if len(args.Nodes) == 0 {
+		return nil, errors.New("no node provided")
+	}
+
+	record := client.newAuditRecord(AuditOperationNodeRestore, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	drainedLabel := map[directpvtypes.LabelKey]directpvtypes.LabelValue{directpvtypes.DrainedLabelKey: "true"}
+	volumeClient := client.Volume()
+	for _, node := range args.Nodes {
+		nodeID := directpvtypes.NodeID(node)
+		result := NodeRestoreResult{NodeID: nodeID}
+		nodes := directpvtypes.ToLabelValues([]string{node})
+
+		for dresult := range client.NewDriveLister().NodeSelector(nodes).LabelSelector(drainedLabel).List(ctx) {
+			if dresult.Err != nil {
+				err = dresult.Err
+				return
+			}
+
+			drive := dresult.Drive
+			before := drive.DeepCopy()
+			drive.Schedulable()
+			drive.RemoveLabel(directpvtypes.DrainedLabelKey)
+			if !args.DryRun {
+				if _, err = client.Drive().Update(ctx, &drive, metav1.UpdateOptions{}); err != nil {
+					err = fmt.Errorf("unable to uncordon drive %v; %w", drive.GetDriveID(), err)
+					return
+				}
+			}
+
+			record.add(consts.DriveKind, drive.Name, before, &drive)
+
+			log(
+				LogMessage{
+					Type:             InfoLogType,
+					Message:          "drive uncordoned",
+					Values:           map[string]any{"nodeId": nodeID, "driveName": drive.GetDriveName()},
+					FormattedMessage: fmt.Sprintf("Drive %v/%v uncordoned\n", nodeID, drive.GetDriveName()),
+				},
+			)
+
+			result.Drives = append(result.Drives, UncordonResult{
+				NodeID:    nodeID,
+				DriveName: drive.GetDriveName(),
+				DriveID:   drive.GetDriveID(),
+			})
+		}
+
+		for vresult := range client.NewVolumeLister().NodeSelector(nodes).LabelSelector(drainedLabel).List(ctx) {
+			if vresult.Err != nil {
+				err = vresult.Err
+				return
+			}
+
+			updateFunc := func() error {
+				volume, err := volumeClient.Get(ctx, vresult.Volume.Name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				before := volume.DeepCopy()
+				volume.Resume()
+				volume.RemoveLabel(directpvtypes.DrainedLabelKey)
+				if !args.DryRun {
+					if _, err := volumeClient.Update(ctx, volume, metav1.UpdateOptions{}); err != nil {
+						return err
+					}
+				}
+				record.add(consts.VolumeKind, volume.Name, before, volume)
+				return nil
+			}
+			if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+				err = fmt.Errorf("unable to resume volume %v; %w", vresult.Volume.Name, err)
+				return
+			}
+
+			log(
+				LogMessage{
+					Type:             InfoLogType,
+					Message:          "volume resumed",
+					Values:           map[string]any{"node": nodeID, "volume": vresult.Volume.Name},
+					FormattedMessage: fmt.Sprintf("Volume %v/%v resumed\n", nodeID, vresult.Volume.Name),
+				},
+			)
+
+			result.Volumes = append(result.Volumes, ResumeVolumeResult{
+				NodeID:       nodeID,
+				VolumeName:   vresult.Volume.Name,
+				DriveID:      vresult.Volume.GetDriveID(),
+				DriveName:    vresult.Volume.GetDriveName(),
+				PodName:      vresult.Volume.GetPodName(),
+				PodNamespace: vresult.Volume.GetPodNS(),
+			})
+		}
+
+		if len(result.Drives) != 0 || len(result.Volumes) != 0 {
+			results = append(results, result)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoMatchingResourcesFound
+	}
+	return
+}
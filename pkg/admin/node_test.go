@@ -0,0 +1,175 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNodeDrainRestore(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{TotalCapacity: 100 * GiB, FreeCapacity: 90 * GiB}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{TotalCapacity: 100 * GiB, FreeCapacity: 100 * GiB}, "node-1", "sdb", directpvtypes.AccessTierDefault)
+	drive2.Unschedulable()
+	drive3 := types.NewDrive("drive-3", types.DriveStatus{TotalCapacity: 100 * GiB, FreeCapacity: 100 * GiB}, "node-2", "sda", directpvtypes.AccessTierDefault)
+	volume1 := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10*GiB)
+	volume1.Status.Status = directpvtypes.VolumeStatusReady
+	drive1.AddVolumeFinalizer("volume-1")
+
+	client := newFakeAdminClient([]runtime.Object{drive1, drive2, drive3, volume1}, nil)
+	ctx := t.Context()
+
+	if _, err := client.NodeDrain(ctx, NodeDrainArgs{Nodes: []string{"node-3"}}, nil); !errors.Is(err, ErrNoMatchingResourcesFound) {
+		t.Fatalf("expected: %v, got: %v", ErrNoMatchingResourcesFound, err)
+	}
+
+	results, err := client.NodeDrain(ctx, NodeDrainArgs{Nodes: []string{"node-1"}, SuspendVolumes: true, DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Drives) != 1 || len(results[0].Volumes) != 1 {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	drive, err := client.Drive().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.IsUnschedulable() {
+		t.Fatalf("drive must not be cordoned in dry run mode")
+	}
+
+	if _, err = client.NodeDrain(ctx, NodeDrainArgs{Nodes: []string{"node-1"}, SuspendVolumes: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if drive, err = client.Drive().Get(ctx, "drive-1", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !drive.IsUnschedulable() {
+		t.Fatalf("drive must be cordoned")
+	}
+	volume, err := client.Volume().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !volume.IsSuspended() {
+		t.Fatalf("volume must be suspended")
+	}
+	if drive, err = client.Drive().Get(ctx, "drive-3", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if drive.IsUnschedulable() {
+		t.Fatalf("drive of other node must not be cordoned")
+	}
+
+	restoreResults, err := client.NodeRestore(ctx, NodeRestoreArgs{Nodes: []string{"node-1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restoreResults) != 1 || len(restoreResults[0].Drives) != 1 || len(restoreResults[0].Volumes) != 1 {
+		t.Fatalf("unexpected results %+v", restoreResults)
+	}
+	if drive, err = client.Drive().Get(ctx, "drive-1", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if drive.IsUnschedulable() {
+		t.Fatalf("drive must be uncordoned")
+	}
+	// drive-2 was cordoned before drain, hence it is left cordoned.
+	if drive, err = client.Drive().Get(ctx, "drive-2", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !drive.IsUnschedulable() {
+		t.Fatalf("drive cordoned before drain must not be uncordoned")
+	}
+	if volume, err = client.Volume().Get(ctx, "volume-1", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if volume.IsSuspended() {
+		t.Fatalf("volume must be resumed")
+	}
+
+	if _, err = client.NodeRestore(ctx, NodeRestoreArgs{Nodes: []string{"node-1"}}, nil); !errors.Is(err, ErrNoMatchingResourcesFound) {
+		t.Fatalf("expected: %v, got: %v", ErrNoMatchingResourcesFound, err)
+	}
+}
+
+func TestNodeRemove(t *testing.T) {
+	const GiB = 1024 * 1024 * 1024
+
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{TotalCapacity: 100 * GiB, FreeCapacity: 90 * GiB}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{TotalCapacity: 100 * GiB, FreeCapacity: 100 * GiB}, "node-2", "sda", directpvtypes.AccessTierDefault)
+	volume1 := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10*GiB)
+	drive1.AddVolumeFinalizer("volume-1")
+	initRequest := types.NewInitRequest("request-1", "node-2", nil)
+	csiNode := &storagev1.CSINode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-3"},
+		Spec:       storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: consts.Identity, NodeID: "node-3"}}},
+	}
+
+	client := newFakeAdminClient(
+		[]runtime.Object{drive1, drive2, volume1, initRequest, types.NewNode("node-1", nil), types.NewNode("node-2", nil), types.NewNode("node-3", nil)},
+		[]runtime.Object{csiNode},
+	)
+	ctx := t.Context()
+
+	if _, err := client.NodeRemove(ctx, NodeRemoveArgs{Nodes: []string{"node-1"}}, nil); err == nil {
+		t.Fatalf("expected error for node having volumes")
+	}
+	if _, err := client.NodeRemove(ctx, NodeRemoveArgs{Nodes: []string{"node-3"}}, nil); err == nil {
+		t.Fatalf("expected error for node running DirectPV")
+	}
+
+	results, err := client.NodeRemove(ctx, NodeRemoveArgs{Nodes: []string{"node-2"}, DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Drives) != 1 || len(results[0].InitRequests) != 1 || !results[0].NodeRemoved {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if _, err = client.Drive().Get(ctx, "drive-2", metav1.GetOptions{}); err != nil {
+		t.Fatalf("drive must not be removed in dry run mode; %v", err)
+	}
+
+	if _, err = client.NodeRemove(ctx, NodeRemoveArgs{Nodes: []string{"node-2"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Drive().Get(ctx, "drive-2", metav1.GetOptions{}); err == nil {
+		t.Fatalf("drive must be removed")
+	}
+	if _, err = client.InitRequest().Get(ctx, initRequest.Name, metav1.GetOptions{}); err == nil {
+		t.Fatalf("init request must be removed")
+	}
+	if _, err = client.Node().Get(ctx, "node-2", metav1.GetOptions{}); err == nil {
+		t.Fatalf("node must be removed")
+	}
+	if _, err = client.Drive().Get(ctx, "drive-1", metav1.GetOptions{}); err != nil {
+		t.Fatalf("drive of other node must not be removed; %v", err)
+	}
+
+	if _, err = client.NodeRemove(ctx, NodeRemoveArgs{Nodes: []string{"node-2"}}, nil); !errors.Is(err, ErrNoMatchingResourcesFound) {
+		t.Fatalf("expected: %v, got: %v", ErrNoMatchingResourcesFound, err)
+	}
+}
@@ -0,0 +1,139 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/xfs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// RemountVolumeArgs denotes the args for remounting the volumes with new mount options
+type RemountVolumeArgs struct {
+	Nodes         []string
+	Drives        []string
+	PodNames      []string
+	PodNamespaces []string
+	PVCNames      []string
+	PVCNamespaces []string
+	VolumeNames   []string
+	MountOptions  []string
+	DryRun        bool
+}
+
+// RemountVolumeResult represents the remounted volume
+type RemountVolumeResult struct {
+	NodeID       directpvtypes.NodeID
+	VolumeName   string
+	MountOptions []string
+}
+
+// RemountVolumes sets mount options of the volumes; node server remounts staged volumes with them.
+func (client *Client) RemountVolumes(ctx context.Context, args RemountVolumeArgs, log LogFunc) (results []RemountVolumeResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	record := client.newAuditRecord(AuditOperationRemountVolumes, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	flags, fsOptions, err := xfs.ParseMountOptions(args.MountOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(fsOptions) != 0 {
+		return nil, fmt.Errorf("filesystem mount options %v cannot be changed by remount", fsOptions)
+	}
+
+	// Filesystem mount options of a volume are retained as they are applied on staging.
+	getMountOptions := func(volume *types.Volume) []string {
+		_, fsOptions, _ := xfs.ParseMountOptions(volume.Status.MountOptions)
+		return append(slices.Clone(flags), fsOptions...)
+	}
+
+	var processed bool
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	resultCh := client.NewVolumeLister().
+		NodeSelector(directpvtypes.ToLabelValues(args.Nodes)).
+		DriveNameSelector(directpvtypes.ToLabelValues(args.Drives)).
+		PodNameSelector(directpvtypes.ToLabelValues(args.PodNames)).
+		PodNSSelector(directpvtypes.ToLabelValues(args.PodNamespaces)).
+		PVCNameSelector(directpvtypes.ToLabelValues(args.PVCNames)).
+		PVCNamespaceSelector(directpvtypes.ToLabelValues(args.PVCNamespaces)).
+		VolumeNameSelector(args.VolumeNames).
+		List(ctx)
+	for result := range resultCh {
+		if result.Err != nil {
+			err = result.Err
+			return
+		}
+		processed = true
+		mountOptions := getMountOptions(&result.Volume)
+		if slices.Equal(result.Volume.Status.MountOptions, mountOptions) {
+			continue
+		}
+		volumeClient := client.Volume()
+		updateFunc := func() error {
+			volume, err := volumeClient.Get(ctx, result.Volume.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			before := volume.DeepCopy()
+			volume.Status.MountOptions = getMountOptions(volume)
+			if !args.DryRun {
+				if _, err := volumeClient.Update(ctx, volume, metav1.UpdateOptions{}); err != nil {
+					return err
+				}
+			}
+			record.add(consts.VolumeKind, volume.Name, before, volume)
+			return nil
+		}
+		if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			err = fmt.Errorf("unable to remount volume %v; %w", result.Volume.Name, err)
+			return
+		}
+
+		log(
+			LogMessage{
+				Type:             InfoLogType,
+				Message:          "volume mount options updated",
+				Values:           map[string]any{"node": result.Volume.GetNodeID(), "volume": result.Volume.Name, "mountOptions": mountOptions},
+				FormattedMessage: fmt.Sprintf("Volume %v/%v mount options set to %v\n", result.Volume.GetNodeID(), result.Volume.Name, mountOptions),
+			},
+		)
+
+		results = append(results, RemountVolumeResult{
+			NodeID:       result.Volume.GetNodeID(),
+			VolumeName:   result.Volume.Name,
+			MountOptions: mountOptions,
+		})
+	}
+	if !processed {
+		return nil, ErrNoMatchingResourcesFound
+	}
+	return
+}
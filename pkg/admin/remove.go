@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -47,6 +48,9 @@ func (client *Client) Remove(ctx context.Context, args RemoveArgs, log LogFunc)
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationRemove, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 	var failed bool
 
@@ -74,6 +78,7 @@ func (client *Client) Remove(ctx context.Context, args RemoveArgs, log LogFunc)
 			if volumeCount > 0 {
 				failed = true
 			} else {
+				before := result.Drive.DeepCopy()
 				result.Drive.Status.Status = directpvtypes.DriveStatusRemoved
 				var err error
 				if !args.DryRun {
@@ -91,6 +96,7 @@ func (client *Client) Remove(ctx context.Context, args RemoveArgs, log LogFunc)
 						},
 					)
 				} else {
+					record.add(consts.DriveKind, result.Drive.Name, before, &result.Drive)
 					log(
 						LogMessage{
 							Type:             InfoLogType,
@@ -112,6 +112,9 @@ func (client *Client) Repair(ctx context.Context, args RepairArgs, log LogFunc)
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationRepair, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
 
@@ -231,6 +234,7 @@ func (client *Client) Repair(ctx context.Context, args RepairArgs, log LogFunc)
 				},
 			)
 
+			record.add("Job", jobName, nil, map[string]any{"drive": result.Drive.Name, "node": nodeID, "command": containerArgs})
 			results = append(results, RepairResult{JobName: jobName, DriveName: result.Drive.GetDriveName(), DriveID: result.Drive.GetDriveID()})
 		}
 	}
@@ -0,0 +1,295 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreAction denotes the action taken on an object of the backup
+type RestoreAction string
+
+// Enum values of RestoreAction type
+const (
+	// RestoreActionCreate denotes the object is created.
+	RestoreActionCreate RestoreAction = "create"
+	// RestoreActionExists denotes the object already exists with the same content.
+	RestoreActionExists RestoreAction = "exists"
+	// RestoreActionConflict denotes the object already exists with different content; it is not changed.
+	RestoreActionConflict RestoreAction = "conflict"
+	// RestoreActionSkip denotes the object is not restorable.
+	RestoreActionSkip RestoreAction = "skip"
+)
+
+// RestoreArgs denotes the args for restoring the backup
+type RestoreArgs struct {
+	Backup *Backup
+	DryRun bool
+}
+
+// RestoreResult represents the action taken on an object of the backup
+type RestoreResult struct {
+	Kind    string
+	Name    string
+	Action  RestoreAction
+	Reason  string
+	Changes []AuditChange
+}
+
+// validateDrive checks whether the drive is present on its node by matching the FSUUID with
+// the devices reported by the node.
+func validateDrive(drive *types.Drive, nodes map[string]*types.Node) error {
+	node, found := nodes[string(drive.GetNodeID())]
+	if !found {
+		return fmt.Errorf("node %v not found", drive.GetNodeID())
+	}
+	fsuuid := drive.Status.FSUUID
+	if drive.Status.Encryption != nil {
+		fsuuid = drive.Status.Encryption.LUKSUUID
+	}
+	for _, device := range node.Status.Devices {
+		if device.FSUUID == fsuuid {
+			return nil
+		}
+	}
+	return fmt.Errorf("no device with FSUUID %v found on node %v", fsuuid, drive.GetNodeID())
+}
+
+// Restore creates the drives, volumes, quotas and persistent volumes in the backup which do not exist.
+// Drives are validated against the devices reported by the nodes, hence nodes must be refreshed
+// prior to this call. Capacities of the drives are reconciled with the restored volumes.
+func (client *Client) Restore(ctx context.Context, args RestoreArgs, log LogFunc) (results []RestoreResult, err error) {
+	if log == nil {
+		log = nullLogger
+	}
+
+	backup := args.Backup
+	record := client.newAuditRecord(AuditOperationRestore, args.DryRun, map[string]any{"version": backup.Version, "createdAt": backup.CreatedAt})
+	defer func() { record.write(ctx, err, log) }()
+
+	liveNodes, err := client.NewNodeLister().Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes := map[string]*types.Node{}
+	for i := range liveNodes {
+		nodes[liveNodes[i].Name] = &liveNodes[i]
+	}
+
+	addResult := func(kind, name string, action RestoreAction, reason string, changes []AuditChange) {
+		results = append(results, RestoreResult{Kind: kind, Name: name, Action: action, Reason: reason, Changes: changes})
+	}
+
+	compare := func(kind, name string, live, backup any) error {
+		changes, err := diffObjects(live, backup)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			addResult(kind, name, RestoreActionExists, "", nil)
+		} else {
+			addResult(kind, name, RestoreActionConflict, "object differs from backup", changes)
+		}
+		return nil
+	}
+
+	// Volumes being deleted are not restored.
+	volumes := map[directpvtypes.DriveID][]types.Volume{}
+	for _, volume := range backup.Volumes {
+		if volume.DeletionTimestamp != nil {
+			addResult(consts.VolumeKind, volume.Name, RestoreActionSkip, "volume was being deleted", nil)
+			continue
+		}
+		volumes[volume.GetDriveID()] = append(volumes[volume.GetDriveID()], volume)
+	}
+
+	// drives holds the drives which exist or to be created.
+	drives := map[directpvtypes.DriveID]*types.Drive{}
+	for i := range backup.Drives {
+		drive := backup.Drives[i].DeepCopy()
+		cleanObjectMeta(&drive.ObjectMeta)
+
+		liveDrive, err := client.Drive().Get(ctx, drive.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			cleanObjectMeta(&liveDrive.ObjectMeta)
+			if err := compare(consts.DriveKind, drive.Name, liveDrive, drive); err != nil {
+				return results, err
+			}
+			drives[liveDrive.GetDriveID()] = liveDrive
+			continue
+		case !apierrors.IsNotFound(err):
+			return results, err
+		}
+
+		if err := validateDrive(drive, nodes); err != nil {
+			addResult(consts.DriveKind, drive.Name, RestoreActionSkip, err.Error(), nil)
+			continue
+		}
+
+		// Reconcile capacity with the volumes to be restored.
+		before := drive.DeepCopy()
+		drive.ResetFinalizers()
+		drive.Status.AllocatedCapacity = 0
+		for _, volume := range volumes[drive.GetDriveID()] {
+			drive.AddVolumeFinalizer(volume.Name)
+			drive.Status.AllocatedCapacity += volume.Status.TotalCapacity
+		}
+		drive.Status.FreeCapacity = drive.Status.TotalCapacity - drive.Status.AllocatedCapacity
+		changes, err := diffObjects(before, drive)
+		if err != nil {
+			return results, err
+		}
+
+		if !args.DryRun {
+			if _, err := client.Drive().Create(ctx, drive, metav1.CreateOptions{}); err != nil {
+				return results, fmt.Errorf("unable to create drive %v; %w", drive.Name, err)
+			}
+		}
+		record.add(consts.DriveKind, drive.Name, nil, drive.Status)
+		addResult(consts.DriveKind, drive.Name, RestoreActionCreate, "", changes)
+		drives[drive.GetDriveID()] = drive
+	}
+
+	restoredVolumes := map[string]struct{}{}
+	for driveID := range volumes {
+		for i := range volumes[driveID] {
+			volume := volumes[driveID][i].DeepCopy()
+			cleanObjectMeta(&volume.ObjectMeta)
+
+			liveVolume, err := client.Volume().Get(ctx, volume.Name, metav1.GetOptions{})
+			switch {
+			case err == nil:
+				cleanObjectMeta(&liveVolume.ObjectMeta)
+				if err := compare(consts.VolumeKind, volume.Name, liveVolume, volume); err != nil {
+					return results, err
+				}
+				restoredVolumes[volume.Name] = struct{}{}
+				continue
+			case !apierrors.IsNotFound(err):
+				return results, err
+			}
+
+			drive, found := drives[driveID]
+			switch {
+			case !found:
+				addResult(consts.VolumeKind, volume.Name, RestoreActionSkip, fmt.Sprintf("drive %v is not restored", driveID), nil)
+				continue
+			case drive.Status.FSUUID != volume.Status.FSUUID:
+				addResult(consts.VolumeKind, volume.Name, RestoreActionSkip, fmt.Sprintf("FSUUID of drive %v does not match", driveID), nil)
+				continue
+			case !drive.VolumeExist(volume.Name) && drive.Status.FreeCapacity < volume.Status.TotalCapacity:
+				addResult(consts.VolumeKind, volume.Name, RestoreActionSkip, fmt.Sprintf("insufficient free capacity on drive %v", driveID), nil)
+				continue
+			}
+
+			if !args.DryRun {
+				if err := client.importVolume(ctx, volume, nil); err != nil {
+					return results, fmt.Errorf("unable to create volume %v; %w", volume.Name, err)
+				}
+			}
+			if !drive.VolumeExist(volume.Name) {
+				drive.AddVolumeFinalizer(volume.Name)
+				drive.Status.FreeCapacity -= volume.Status.TotalCapacity
+				drive.Status.AllocatedCapacity += volume.Status.TotalCapacity
+			}
+			record.add(consts.VolumeKind, volume.Name, nil, volume.Status)
+			addResult(consts.VolumeKind, volume.Name, RestoreActionCreate, "", nil)
+			restoredVolumes[volume.Name] = struct{}{}
+		}
+	}
+
+	for i := range backup.Quotas {
+		quota := backup.Quotas[i].DeepCopy()
+		cleanObjectMeta(&quota.ObjectMeta)
+		// Usage is updated by the controller on volume provisioning.
+		quota.Status = types.QuotaStatus{}
+		name := quota.Namespace + "/" + quota.Name
+
+		liveQuota, err := client.Quota(quota.Namespace).Get(ctx, quota.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			if err := compare(consts.QuotaKind, name, liveQuota.Spec, quota.Spec); err != nil {
+				return results, err
+			}
+			continue
+		case !apierrors.IsNotFound(err):
+			return results, err
+		}
+
+		if !args.DryRun {
+			if _, err := client.Quota(quota.Namespace).Create(ctx, quota, metav1.CreateOptions{}); err != nil {
+				if apierrors.IsNotFound(err) {
+					addResult(consts.QuotaKind, name, RestoreActionSkip, fmt.Sprintf("namespace %v not found", quota.Namespace), nil)
+					continue
+				}
+				return results, fmt.Errorf("unable to create quota %v; %w", name, err)
+			}
+		}
+		record.add(consts.QuotaKind, name, nil, quota.Spec)
+		addResult(consts.QuotaKind, name, RestoreActionCreate, "", nil)
+	}
+
+	for i := range backup.PersistentVolumes {
+		pv := backup.PersistentVolumes[i].DeepCopy()
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != consts.Identity {
+			addResult("PersistentVolume", pv.Name, RestoreActionSkip, "not provisioned by "+consts.Identity, nil)
+			continue
+		}
+		cleanObjectMeta(&pv.ObjectMeta)
+		pv.Status = corev1.PersistentVolumeStatus{}
+		if pv.Spec.ClaimRef != nil {
+			pv.Spec.ClaimRef.UID = ""
+			pv.Spec.ClaimRef.ResourceVersion = ""
+		}
+
+		livePV, err := client.Kube().CoreV1().PersistentVolumes().Get(ctx, pv.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			cleanObjectMeta(&livePV.ObjectMeta)
+			if err := compare("PersistentVolume", pv.Name, livePV.Spec, pv.Spec); err != nil {
+				return results, err
+			}
+			continue
+		case !apierrors.IsNotFound(err):
+			return results, err
+		}
+
+		if _, found := restoredVolumes[pv.Spec.CSI.VolumeHandle]; !found {
+			addResult("PersistentVolume", pv.Name, RestoreActionSkip, fmt.Sprintf("volume %v is not restored", pv.Spec.CSI.VolumeHandle), nil)
+			continue
+		}
+
+		if !args.DryRun {
+			if _, err := client.Kube().CoreV1().PersistentVolumes().Create(ctx, pv, metav1.CreateOptions{}); err != nil {
+				return results, fmt.Errorf("unable to create persistent volume %v; %w", pv.Name, err)
+			}
+		}
+		record.add("PersistentVolume", pv.Name, nil, pv.Spec)
+		addResult("PersistentVolume", pv.Name, RestoreActionCreate, "", nil)
+	}
+
+	return results, nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -37,6 +38,9 @@ func (client *Client) ResumeDrives(ctx context.Context, args ResumeDriveArgs, lo
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationResumeDrives, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -63,12 +67,14 @@ func (client *Client) ResumeDrives(ctx context.Context, args ResumeDriveArgs, lo
 			if err != nil {
 				return err
 			}
+			before := drive.DeepCopy()
 			drive.Resume()
 			if !args.DryRun {
 				if _, err := driveClient.Update(ctx, drive, metav1.UpdateOptions{}); err != nil {
 					return err
 				}
 			}
+			record.add(consts.DriveKind, drive.Name, before, drive)
 			return nil
 		}
 		if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
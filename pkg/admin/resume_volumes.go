@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -37,6 +38,9 @@ func (client *Client) ResumeVolumes(ctx context.Context, args ResumeVolumeArgs,
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationResumeVolumes, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -47,6 +51,8 @@ func (client *Client) ResumeVolumes(ctx context.Context, args ResumeVolumeArgs,
 		DriveNameSelector(directpvtypes.ToLabelValues(args.Drives)).
 		PodNameSelector(directpvtypes.ToLabelValues(args.PodNames)).
 		PodNSSelector(directpvtypes.ToLabelValues(args.PodNamespaces)).
+		PVCNameSelector(directpvtypes.ToLabelValues(args.PVCNames)).
+		PVCNamespaceSelector(directpvtypes.ToLabelValues(args.PVCNamespaces)).
 		VolumeNameSelector(args.VolumeNames).
 		List(ctx)
 	for result := range resultCh {
@@ -65,12 +71,14 @@ func (client *Client) ResumeVolumes(ctx context.Context, args ResumeVolumeArgs,
 			if err != nil {
 				return err
 			}
+			before := volume.DeepCopy()
 			volume.Resume()
 			if !args.DryRun {
 				if _, err := volumeClient.Update(ctx, volume, metav1.UpdateOptions{}); err != nil {
 					return err
 				}
 			}
+			record.add(consts.VolumeKind, volume.Name, before, volume)
 			return nil
 		}
 
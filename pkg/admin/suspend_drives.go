@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -51,6 +52,9 @@ func (client *Client) SuspendDrives(ctx context.Context, args SuspendDriveArgs,
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationSuspendDrives, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -79,12 +83,14 @@ func (client *Client) SuspendDrives(ctx context.Context, args SuspendDriveArgs,
 			if err != nil {
 				return err
 			}
+			before := drive.DeepCopy()
 			drive.Suspend()
 			if !args.DryRun {
 				if _, err := driveClient.Update(ctx, drive, metav1.UpdateOptions{}); err != nil {
 					return err
 				}
 			}
+			record.add(consts.DriveKind, drive.Name, before, drive)
 			return nil
 		}
 		if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
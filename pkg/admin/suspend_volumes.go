@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -31,8 +32,12 @@ type SuspendVolumeArgs struct {
 	Drives        []string
 	PodNames      []string
 	PodNamespaces []string
+	PVCNames      []string
+	PVCNamespaces []string
 	VolumeNames   []string
-	DryRun        bool
+	// Mode is how suspended volumes are published; defaults to SuspendModeEmpty.
+	Mode   directpvtypes.SuspendMode
+	DryRun bool
 }
 
 // SuspendVolumeResult represents the suspended volume
@@ -43,6 +48,7 @@ type SuspendVolumeResult struct {
 	DriveName    directpvtypes.DriveName
 	PodName      string
 	PodNamespace string
+	Mode         directpvtypes.SuspendMode
 }
 
 // SuspendVolumes suspends the volume
@@ -51,6 +57,14 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationSuspendVolumes, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
+	mode := args.Mode
+	if mode == "" {
+		mode = directpvtypes.SuspendModeEmpty
+	}
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -61,6 +75,8 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 		DriveNameSelector(directpvtypes.ToLabelValues(args.Drives)).
 		PodNameSelector(directpvtypes.ToLabelValues(args.PodNames)).
 		PodNSSelector(directpvtypes.ToLabelValues(args.PodNamespaces)).
+		PVCNameSelector(directpvtypes.ToLabelValues(args.PVCNames)).
+		PVCNamespaceSelector(directpvtypes.ToLabelValues(args.PVCNamespaces)).
 		VolumeNameSelector(args.VolumeNames).
 		List(ctx)
 	for result := range resultCh {
@@ -69,7 +85,7 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 			return
 		}
 		processed = true
-		if result.Volume.IsSuspended() {
+		if result.Volume.IsSuspended() && result.Volume.GetSuspendMode() == mode {
 			continue
 		}
 		volumeClient := client.Volume()
@@ -78,12 +94,14 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 			if err != nil {
 				return err
 			}
-			volume.Suspend()
+			before := volume.DeepCopy()
+			volume.SuspendWithMode(mode)
 			if !args.DryRun {
 				if _, err := volumeClient.Update(ctx, volume, metav1.UpdateOptions{}); err != nil {
 					return err
 				}
 			}
+			record.add(consts.VolumeKind, volume.Name, before, volume)
 			return nil
 		}
 		if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
@@ -95,8 +113,8 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 			LogMessage{
 				Type:             InfoLogType,
 				Message:          "volume suspended",
-				Values:           map[string]any{"node": result.Volume.GetNodeID(), "volume": result.Volume.Name},
-				FormattedMessage: fmt.Sprintf("Volume %v/%v suspended\n", result.Volume.GetNodeID(), result.Volume.Name),
+				Values:           map[string]any{"node": result.Volume.GetNodeID(), "volume": result.Volume.Name, "mode": mode},
+				FormattedMessage: fmt.Sprintf("Volume %v/%v suspended in %v mode\n", result.Volume.GetNodeID(), result.Volume.Name, mode),
 			},
 		)
 
@@ -107,6 +125,7 @@ func (client *Client) SuspendVolumes(ctx context.Context, args SuspendVolumeArgs
 			DriveName:    result.Volume.GetDriveName(),
 			PodName:      result.Volume.GetPodName(),
 			PodNamespace: result.Volume.GetPodNS(),
+			Mode:         mode,
 		})
 	}
 	if !processed {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,6 +37,9 @@ func (client *Client) Uncordon(ctx context.Context, args UncordonArgs, log LogFu
 		log = nullLogger
 	}
 
+	record := client.newAuditRecord(AuditOperationUncordon, args.DryRun, args)
+	defer func() { record.write(ctx, err, log) }()
+
 	var processed bool
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -59,6 +63,7 @@ func (client *Client) Uncordon(ctx context.Context, args UncordonArgs, log LogFu
 			continue
 		}
 
+		before := result.Drive.DeepCopy()
 		result.Drive.Schedulable()
 		if !args.DryRun {
 			_, err = client.Drive().Update(ctx, &result.Drive, metav1.UpdateOptions{})
@@ -68,6 +73,8 @@ func (client *Client) Uncordon(ctx context.Context, args UncordonArgs, log LogFu
 			return
 		}
 
+		record.add(consts.DriveKind, result.Drive.Name, before, &result.Drive)
+
 		log(
 			LogMessage{
 				Type:             InfoLogType,
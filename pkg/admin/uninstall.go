@@ -29,8 +29,12 @@ type UninstallArgs struct {
 	Dangerous bool
 }
 
-// Uninstall uninstalls directpv
-func (client Client) Uninstall(ctx context.Context, args UninstallArgs) error {
+// Uninstall uninstalls directpv. As the audit ConfigMap is deleted along with the namespace,
+// ConfigMapAuditSink must not be used to record this operation.
+func (client Client) Uninstall(ctx context.Context, args UninstallArgs) (err error) {
+	record := client.newAuditRecord(AuditOperationUninstall, false, args)
+	defer func() { record.write(ctx, err, nullLogger) }()
+
 	legacyClient, err := legacyclient.NewClient(client.K8s())
 	if err != nil {
 		return err
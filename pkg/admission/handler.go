@@ -0,0 +1,135 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// maxRequestSize is the maximum size of admission review request body.
+const maxRequestSize = 8 * 1024 * 1024
+
+func decodeObject(raw runtime.RawExtension, obj any) error {
+	if len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, obj)
+}
+
+func validateDriveRequest(ctx context.Context, request *admissionv1.AdmissionRequest) error {
+	var oldDrive, newDrive types.Drive
+	if err := decodeObject(request.OldObject, &oldDrive); err != nil {
+		return fmt.Errorf("unable to decode old drive; %w", err)
+	}
+	if err := decodeObject(request.Object, &newDrive); err != nil {
+		return fmt.Errorf("unable to decode drive; %w", err)
+	}
+	return ValidateDrive(ctx, request.Operation, request.UserInfo, &oldDrive, &newDrive)
+}
+
+func validateVolumeRequest(ctx context.Context, request *admissionv1.AdmissionRequest) error {
+	var oldVolume, newVolume types.Volume
+	if err := decodeObject(request.OldObject, &oldVolume); err != nil {
+		return fmt.Errorf("unable to decode old volume; %w", err)
+	}
+	if err := decodeObject(request.Object, &newVolume); err != nil {
+		return fmt.Errorf("unable to decode volume; %w", err)
+	}
+	return ValidateVolume(ctx, request.Operation, request.UserInfo, &oldVolume, &newVolume)
+}
+
+// Review validates the admission request and returns the admission response.
+func Review(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{
+		UID:     request.UID,
+		Allowed: true,
+	}
+
+	var err error
+	switch request.Resource.Resource {
+	case consts.DriveResource:
+		err = validateDriveRequest(ctx, request)
+	case consts.VolumeResource:
+		err = validateVolumeRequest(ctx, request)
+	}
+
+	if err != nil {
+		klog.V(3).InfoS("Admission request denied",
+			"resource", request.Resource.Resource,
+			"name", request.Name,
+			"operation", request.Operation,
+			"user", request.UserInfo.Username,
+			"reason", err,
+		)
+		response.Allowed = false
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonForbidden,
+			Code:    http.StatusForbidden,
+		}
+	}
+
+	return response
+}
+
+// ServeHTTP handles the admission review request.
+func ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to read request; %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	if err = json.Unmarshal(data, &review); err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode admission review; %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review request is empty", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = Review(r.Context(), review.Request)
+	review.Request = nil
+
+	if data, err = json.Marshal(review); err != nil {
+		http.Error(w, fmt.Sprintf("unable to encode admission review; %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(data); err != nil {
+		klog.ErrorS(err, "unable to write admission review response")
+	}
+}
@@ -0,0 +1,125 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admission
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/directpv/pkg/consts"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// CertFile is the TLS certificate file name in certificate directory.
+	CertFile = "tls.crt"
+
+	// KeyFile is the TLS private key file name in certificate directory.
+	KeyFile = "tls.key"
+)
+
+// ErrCertNotFound denotes the webhook TLS certificate is not found.
+var ErrCertNotFound = errors.New("admission webhook certificate not found")
+
+// certLoader reloads the key pair when the mounted secret is updated.
+type certLoader struct {
+	certFile string
+	keyFile  string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+}
+
+func (loader *certLoader) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	info, err := os.Stat(loader.certFile)
+	if err != nil {
+		if loader.cert != nil {
+			return loader.cert, nil
+		}
+		return nil, err
+	}
+
+	if loader.cert == nil || !info.ModTime().Equal(loader.modTime) {
+		cert, err := tls.LoadX509KeyPair(loader.certFile, loader.keyFile)
+		if err != nil {
+			if loader.cert != nil {
+				klog.ErrorS(err, "unable to reload admission webhook certificate")
+				return loader.cert, nil
+			}
+			return nil, err
+		}
+		loader.cert = &cert
+		loader.modTime = info.ModTime()
+	}
+
+	return loader.cert, nil
+}
+
+// Serve serves the admission webhook on the port with certificates in certDir.
+// ErrCertNotFound is returned if certificate files are not present in certDir.
+func Serve(ctx context.Context, port int, certDir string) error {
+	loader := &certLoader{
+		certFile: path.Join(certDir, CertFile),
+		keyFile:  path.Join(certDir, KeyFile),
+	}
+	if _, err := loader.getCertificate(nil); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrCertNotFound
+		}
+		return fmt.Errorf("unable to load admission webhook certificate; %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(consts.AdmissionWebhookPath, ServeHTTP)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: loader.getCertificate,
+		},
+	}
+
+	config := net.ListenConfig{}
+	listener, err := config.Listen(ctx, "tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	klog.V(3).Infof("Serving admission webhook at :%v", port)
+	if err = server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceAccountUser is the user name of DirectPV components.
+var serviceAccountUser = "system:serviceaccount:" + consts.AppName + ":" + consts.Identity
+
+// userMutableReservedLabels are reserved labels managed by the admin commands.
+var userMutableReservedLabels = map[directpvtypes.LabelKey]struct{}{
+	directpvtypes.SuspendLabelKey:     {},
+	directpvtypes.SuspendModeLabelKey: {},
+}
+
+func isPrivileged(userInfo authenticationv1.UserInfo) bool {
+	return userInfo.Username == serviceAccountUser
+}
+
+func validateReservedLabels(oldLabels, newLabels map[string]string, userInfo authenticationv1.UserInfo) error {
+	if isPrivileged(userInfo) {
+		return nil
+	}
+
+	keys := map[string]struct{}{}
+	for key := range oldLabels {
+		keys[key] = struct{}{}
+	}
+	for key := range newLabels {
+		keys[key] = struct{}{}
+	}
+
+	var tampered []string
+	for key := range keys {
+		labelKey := directpvtypes.LabelKey(key)
+		if !labelKey.IsReserved() {
+			continue
+		}
+		if _, found := userMutableReservedLabels[labelKey]; found {
+			continue
+		}
+		oldValue, oldFound := oldLabels[key]
+		newValue, newFound := newLabels[key]
+		if oldFound != newFound || oldValue != newValue {
+			tampered = append(tampered, key)
+		}
+	}
+
+	if len(tampered) != 0 {
+		sort.Strings(tampered)
+		return fmt.Errorf("reserved labels %v must not be modified", tampered)
+	}
+	return nil
+}
+
+func validateDriveStatusTransition(oldStatus, newStatus directpvtypes.DriveStatus) error {
+	if oldStatus == newStatus {
+		return nil
+	}
+
+	switch {
+	case oldStatus == directpvtypes.DriveStatusRemoved:
+		return fmt.Errorf("invalid status transition from %v to %v; removed drive cannot be brought back", oldStatus, newStatus)
+	case newStatus == directpvtypes.DriveStatusMoving && oldStatus != directpvtypes.DriveStatusReady:
+		return fmt.Errorf("invalid status transition from %v to %v; only ready drive can be moved", oldStatus, newStatus)
+	}
+
+	return nil
+}
+
+func validateVolumeStatusTransition(oldStatus, newStatus directpvtypes.VolumeStatus, userInfo authenticationv1.UserInfo) error {
+	switch newStatus {
+	case directpvtypes.VolumeStatusPending, directpvtypes.VolumeStatusReady:
+	default:
+		return fmt.Errorf("invalid volume status %v", newStatus)
+	}
+
+	if oldStatus == newStatus || isPrivileged(userInfo) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid status transition from %v to %v; volume status is managed by %v", oldStatus, newStatus, consts.AppPrettyName)
+}
+
+// validateDriveFinalizers rejects removal of volume finalizers of the drive unless the
+// volume is moved to another drive or the volume does not exist.
+func validateDriveFinalizers(ctx context.Context, userInfo authenticationv1.UserInfo, oldDrive, newDrive *types.Drive) error {
+	if isPrivileged(userInfo) {
+		return nil
+	}
+
+	var removed []string
+	for _, volumeName := range oldDrive.GetVolumes() {
+		if !newDrive.VolumeExist(volumeName) {
+			removed = append(removed, volumeName)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	drives, err := client.NewDriveLister().Get(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list drives; %w", err)
+	}
+
+	isMoved := func(volumeName string) bool {
+		for i := range drives {
+			if drives[i].Name != newDrive.Name && drives[i].VolumeExist(volumeName) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, volumeName := range removed {
+		if isMoved(volumeName) {
+			continue
+		}
+		_, err := client.VolumeClient().Get(ctx, volumeName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return fmt.Errorf("unable to get volume %v; %w", volumeName, err)
+		}
+		return fmt.Errorf("finalizer of volume %v must not be removed; volume still exists on the drive", volumeName)
+	}
+
+	return nil
+}
+
+// validateVolumeFinalizers rejects removal of protection finalizers of the volume. PV protection
+// is allowed to be removed only if its persistent volume does not exist, is released or failed.
+func validateVolumeFinalizers(ctx context.Context, userInfo authenticationv1.UserInfo, oldVolume, newVolume *types.Volume) error {
+	if isPrivileged(userInfo) {
+		return nil
+	}
+
+	if oldVolume.HasPurgeProtection() && !newVolume.HasPurgeProtection() {
+		return errors.New("purge protection finalizer of volume must not be removed")
+	}
+
+	if !oldVolume.HasPVProtection() || newVolume.HasPVProtection() {
+		return nil
+	}
+
+	pv, err := k8s.KubeClient().CoreV1().PersistentVolumes().Get(ctx, newVolume.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to get persistent volume %v; %w", newVolume.Name, err)
+	}
+
+	switch pv.Status.Phase {
+	case corev1.VolumeReleased, corev1.VolumeFailed:
+		return nil
+	}
+	return fmt.Errorf("PV protection finalizer of volume must not be removed; persistent volume %v is %v", pv.Name, pv.Status.Phase)
+}
+
+func validateDriveCapacity(drive *types.Drive) error {
+	switch {
+	case drive.Status.TotalCapacity < 0:
+		return fmt.Errorf("invalid total capacity %v; must not be negative", drive.Status.TotalCapacity)
+	case drive.Status.FreeCapacity < 0:
+		return fmt.Errorf("invalid free capacity %v; must not be negative", drive.Status.FreeCapacity)
+	case drive.Status.AllocatedCapacity < 0:
+		return fmt.Errorf("invalid allocated capacity %v; must not be negative", drive.Status.AllocatedCapacity)
+	case drive.Status.FreeCapacity > drive.Status.TotalCapacity:
+		return fmt.Errorf("free capacity %v must not exceed total capacity %v", drive.Status.FreeCapacity, drive.Status.TotalCapacity)
+	}
+	return nil
+}
+
+func validateVolumeCapacity(volume *types.Volume) error {
+	switch {
+	case volume.Status.TotalCapacity < 0:
+		return fmt.Errorf("invalid total capacity %v; must not be negative", volume.Status.TotalCapacity)
+	case volume.Status.AvailableCapacity < 0:
+		return fmt.Errorf("invalid available capacity %v; must not be negative", volume.Status.AvailableCapacity)
+	case volume.Status.UsedCapacity < 0:
+		return fmt.Errorf("invalid used capacity %v; must not be negative", volume.Status.UsedCapacity)
+	}
+	return nil
+}
+
+// ValidateDrive validates the drive admission request.
+func ValidateDrive(ctx context.Context, operation admissionv1.Operation, userInfo authenticationv1.UserInfo, oldDrive, newDrive *types.Drive) error {
+	switch operation {
+	case admissionv1.Create:
+		return validateDriveCapacity(newDrive)
+	case admissionv1.Update:
+		if err := validateDriveStatusTransition(oldDrive.Status.Status, newDrive.Status.Status); err != nil {
+			return err
+		}
+		if err := validateDriveCapacity(newDrive); err != nil {
+			return err
+		}
+		if err := validateReservedLabels(oldDrive.GetLabels(), newDrive.GetLabels(), userInfo); err != nil {
+			return err
+		}
+		return validateDriveFinalizers(ctx, userInfo, oldDrive, newDrive)
+	case admissionv1.Delete:
+		if count := oldDrive.GetVolumeCount(); count > 0 {
+			return fmt.Errorf("drive %v still contains %v volumes", oldDrive.GetDriveID(), count)
+		}
+		return nil
+	}
+	return nil
+}
+
+// ValidateVolume validates the volume admission request.
+func ValidateVolume(ctx context.Context, operation admissionv1.Operation, userInfo authenticationv1.UserInfo, oldVolume, newVolume *types.Volume) error {
+	switch operation {
+	case admissionv1.Create:
+		return validateVolumeCapacity(newVolume)
+	case admissionv1.Update:
+		if newVolume.Status.TotalCapacity < oldVolume.Status.TotalCapacity && !isPrivileged(userInfo) {
+			return errors.New("volume capacity must not be reduced")
+		}
+		if err := validateVolumeStatusTransition(oldVolume.Status.Status, newVolume.Status.Status, userInfo); err != nil {
+			return err
+		}
+		if err := validateVolumeCapacity(newVolume); err != nil {
+			return err
+		}
+		if err := validateReservedLabels(oldVolume.GetLabels(), newVolume.GetLabels(), userInfo); err != nil {
+			return err
+		}
+		return validateVolumeFinalizers(ctx, userInfo, oldVolume, newVolume)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+var (
+	adminUser   = authenticationv1.UserInfo{Username: "kubernetes-admin"}
+	serviceUser = authenticationv1.UserInfo{Username: serviceAccountUser}
+)
+
+func newTestDrive(status directpvtypes.DriveStatus) *types.Drive {
+	drive := types.NewDrive(
+		"drive-1",
+		types.DriveStatus{
+			Status:        status,
+			TotalCapacity: 100,
+			FreeCapacity:  100,
+		},
+		"node-1",
+		"sda",
+		directpvtypes.AccessTierDefault,
+	)
+	return drive
+}
+
+func TestValidateDrive(t *testing.T) {
+	driveWithVolume := newTestDrive(directpvtypes.DriveStatusReady)
+	driveWithVolume.AddVolumeFinalizer("volume-1")
+
+	negativeCapacity := newTestDrive(directpvtypes.DriveStatusReady)
+	negativeCapacity.Status.FreeCapacity = -1
+
+	excessFreeCapacity := newTestDrive(directpvtypes.DriveStatusReady)
+	excessFreeCapacity.Status.FreeCapacity = 200
+
+	nodeChanged := newTestDrive(directpvtypes.DriveStatusReady)
+	nodeChanged.SetLabel(directpvtypes.NodeLabelKey, "node-2")
+
+	suspended := newTestDrive(directpvtypes.DriveStatusReady)
+	suspended.SetLabel(directpvtypes.SuspendLabelKey, "true")
+
+	userLabeled := newTestDrive(directpvtypes.DriveStatusReady)
+	userLabeled.SetLabel(directpvtypes.LabelKey("example.com/rack"), "rack-1")
+
+	testCases := []struct {
+		operation   admissionv1.Operation
+		userInfo    authenticationv1.UserInfo
+		oldDrive    *types.Drive
+		newDrive    *types.Drive
+		expectedErr bool
+	}{
+		{admissionv1.Create, adminUser, &types.Drive{}, newTestDrive(directpvtypes.DriveStatusReady), false},
+		{admissionv1.Create, adminUser, &types.Drive{}, negativeCapacity, true},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), newTestDrive(directpvtypes.DriveStatusMoving), false},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusLost), newTestDrive(directpvtypes.DriveStatusMoving), true},
+		{admissionv1.Update, serviceUser, newTestDrive(directpvtypes.DriveStatusRemoved), newTestDrive(directpvtypes.DriveStatusReady), true},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), newTestDrive(directpvtypes.DriveStatusRemoved), false},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), excessFreeCapacity, true},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), nodeChanged, true},
+		{admissionv1.Update, serviceUser, newTestDrive(directpvtypes.DriveStatusReady), nodeChanged, false},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), suspended, false},
+		{admissionv1.Update, adminUser, newTestDrive(directpvtypes.DriveStatusReady), userLabeled, false},
+		{admissionv1.Delete, adminUser, driveWithVolume, &types.Drive{}, true},
+		{admissionv1.Delete, adminUser, newTestDrive(directpvtypes.DriveStatusRemoved), &types.Drive{}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateDrive(t.Context(), testCase.operation, testCase.userInfo, testCase.oldDrive, testCase.newDrive)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateVolume(t *testing.T) {
+	newVolume := func(size int64) *types.Volume {
+		return types.NewVolume("volume-1", "fsuuid", "node-1", "drive-1", "sda", size)
+	}
+
+	negativeUsed := newVolume(100)
+	negativeUsed.Status.UsedCapacity = -1
+
+	readyVolume := newVolume(100)
+	readyVolume.Status.Status = directpvtypes.VolumeStatusReady
+
+	invalidStatus := newVolume(100)
+	invalidStatus.Status.Status = directpvtypes.VolumeStatus("Deleted")
+
+	testCases := []struct {
+		operation   admissionv1.Operation
+		userInfo    authenticationv1.UserInfo
+		oldVolume   *types.Volume
+		newVolume   *types.Volume
+		expectedErr bool
+	}{
+		{admissionv1.Create, adminUser, &types.Volume{}, newVolume(100), false},
+		{admissionv1.Create, adminUser, &types.Volume{}, newVolume(-1), true},
+		{admissionv1.Update, adminUser, newVolume(100), newVolume(200), false},
+		{admissionv1.Update, adminUser, newVolume(200), newVolume(100), true},
+		{admissionv1.Update, adminUser, newVolume(100), negativeUsed, true},
+		{admissionv1.Update, serviceUser, newVolume(100), readyVolume, false},
+		{admissionv1.Update, serviceUser, readyVolume, newVolume(100), false},
+		{admissionv1.Update, adminUser, newVolume(100), readyVolume, true},
+		{admissionv1.Update, adminUser, readyVolume, newVolume(100), true},
+		{admissionv1.Update, serviceUser, newVolume(100), invalidStatus, true},
+		{admissionv1.Delete, adminUser, newVolume(100), &types.Volume{}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateVolume(t.Context(), testCase.operation, testCase.userInfo, testCase.oldVolume, testCase.newVolume)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateDriveFinalizers(t *testing.T) {
+	newDrive := func(driveID directpvtypes.DriveID, volumes ...string) *types.Drive {
+		drive := types.NewDrive(
+			driveID,
+			types.DriveStatus{Status: directpvtypes.DriveStatusReady, TotalCapacity: 100, FreeCapacity: 100},
+			"node-1",
+			directpvtypes.DriveName(driveID),
+			directpvtypes.AccessTierDefault,
+		)
+		for _, volume := range volumes {
+			drive.AddVolumeFinalizer(volume)
+		}
+		return drive
+	}
+
+	// volume-1 exists on drive-1, volume-2 is moved to drive-2 and volume-3 does not exist.
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(
+		newDrive("drive-1", "volume-1", "volume-2", "volume-3"),
+		newDrive("drive-2", "volume-2"),
+		types.NewVolume("volume-1", "fsuuid", "node-1", "drive-1", "drive-1", 10),
+		types.NewVolume("volume-2", "fsuuid", "node-1", "drive-1", "drive-1", 10),
+	))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	oldDrive := newDrive("drive-1", "volume-1", "volume-2", "volume-3")
+	testCases := []struct {
+		userInfo    authenticationv1.UserInfo
+		newDrive    *types.Drive
+		expectedErr bool
+	}{
+		{adminUser, newDrive("drive-1", "volume-1", "volume-2", "volume-3"), false},
+		{adminUser, newDrive("drive-1", "volume-2", "volume-3"), true},
+		{serviceUser, newDrive("drive-1", "volume-2", "volume-3"), false},
+		{adminUser, newDrive("drive-1", "volume-1", "volume-3"), false},
+		{adminUser, newDrive("drive-1", "volume-1", "volume-2"), false},
+		{adminUser, newDrive("drive-1"), true},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateDrive(t.Context(), admissionv1.Update, testCase.userInfo, oldDrive, testCase.newDrive)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateVolumeFinalizers(t *testing.T) {
+	newPV := func(name string, phase corev1.PersistentVolumePhase) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+	k8s.SetKubeInterface(fake.NewSimpleClientset(
+		newPV("bound-volume", corev1.VolumeBound),
+		newPV("released-volume", corev1.VolumeReleased),
+		newPV("failed-volume", corev1.VolumeFailed),
+	))
+
+	newVolume := func(name string) *types.Volume {
+		return types.NewVolume(name, "fsuuid", "node-1", "drive-1", "sda", 100)
+	}
+	withoutPVProtection := func(name string) *types.Volume {
+		volume := newVolume(name)
+		volume.RemovePVProtection()
+		return volume
+	}
+	withoutPurgeProtection := func(name string) *types.Volume {
+		volume := newVolume(name)
+		volume.RemovePurgeProtection()
+		return volume
+	}
+
+	testCases := []struct {
+		userInfo    authenticationv1.UserInfo
+		oldVolume   *types.Volume
+		newVolume   *types.Volume
+		expectedErr bool
+	}{
+		{adminUser, newVolume("bound-volume"), withoutPVProtection("bound-volume"), true},
+		{serviceUser, newVolume("bound-volume"), withoutPVProtection("bound-volume"), false},
+		{adminUser, newVolume("released-volume"), withoutPVProtection("released-volume"), false},
+		{adminUser, newVolume("failed-volume"), withoutPVProtection("failed-volume"), false},
+		{adminUser, newVolume("deleted-volume"), withoutPVProtection("deleted-volume"), false},
+		{adminUser, withoutPVProtection("bound-volume"), withoutPVProtection("bound-volume"), false},
+		{adminUser, newVolume("released-volume"), withoutPurgeProtection("released-volume"), true},
+		{serviceUser, newVolume("released-volume"), withoutPurgeProtection("released-volume"), false},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateVolume(t.Context(), admissionv1.Update, testCase.userInfo, testCase.oldVolume, testCase.newVolume)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	data, err := json.Marshal(newTestDrive(directpvtypes.DriveStatusReady))
+	if err != nil {
+		t.Fatal(err)
+	}
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "uid-1",
+			Resource:  metav1.GroupVersionResource{Group: consts.GroupName, Version: "v1beta1", Resource: consts.DriveResource},
+			Operation: admissionv1.Update,
+			UserInfo:  adminUser,
+			OldObject: runtime.RawExtension{Raw: data},
+			Object:    runtime.RawExtension{Raw: []byte(`{"status":{"status":"Ready","totalCapacity":100,"freeCapacity":-5}}`)},
+		},
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, consts.AdmissionWebhookPath, bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected: %v, got: %v", http.StatusOK, recorder.Code)
+	}
+
+	var result admissionv1.AdmissionReview
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Response == nil || result.Response.UID != "uid-1" || result.Response.Allowed {
+		t.Fatalf("expected denied response for uid-1; got: %+v", result.Response)
+	}
+}
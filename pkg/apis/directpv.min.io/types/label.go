@@ -53,6 +53,15 @@ const (
 	// PodNSLabelKey label key for pod namespace
 	PodNSLabelKey LabelKey = consts.GroupName + "/pod.namespace"
 
+	// WipeModeLabelKey label key for wipe mode
+	WipeModeLabelKey LabelKey = consts.GroupName + "/wipe-mode"
+
+	// PVCNameLabelKey label key for persistent volume claim name
+	PVCNameLabelKey LabelKey = consts.GroupName + "/pvc.name"
+
+	// PVCNamespaceLabelKey label key for persistent volume claim namespace
+	PVCNamespaceLabelKey LabelKey = consts.GroupName + "/pvc.namespace"
+
 	// LatestVersionLabelKey label key for group and version
 	LatestVersionLabelKey LabelKey = consts.GroupName + "/" + consts.LatestAPIVersion
 
@@ -80,6 +89,36 @@ const (
 	// SuspendLabelKey denotes if the volume is suspended.
 	SuspendLabelKey LabelKey = consts.GroupName + "/suspend"
 
+	// SuspendModeLabelKey denotes the suspend mode of the volume.
+	SuspendModeLabelKey LabelKey = consts.GroupName + "/suspend-mode"
+
+	// DrainedLabelKey denotes the drive or volume was changed by node drain.
+	DrainedLabelKey LabelKey = consts.GroupName + "/drained"
+
+	// NodeUnschedulableLabelKey denotes the drive is cordoned due to its unschedulable Kubernetes node.
+	NodeUnschedulableLabelKey LabelKey = consts.GroupName + "/node-unschedulable"
+
+	// BackendLabelKey denotes the volume backend of the drive or volume.
+	BackendLabelKey LabelKey = consts.GroupName + "/backend"
+
+	// GrowPolicyLabelKey denotes whether the drive filesystem is grown when its device is enlarged.
+	GrowPolicyLabelKey LabelKey = consts.GroupName + "/grow-policy"
+
+	// VolumeLabelKey denotes the source volume of the snapshot.
+	VolumeLabelKey LabelKey = consts.GroupName + "/volume"
+
+	// SnapshotLabelKey denotes the snapshot the volume is restored from.
+	SnapshotLabelKey LabelKey = consts.GroupName + "/snapshot"
+
+	// ShrinkToAnnotationKey denotes the requested reduced size of the volume.
+	ShrinkToAnnotationKey LabelKey = consts.GroupName + "/shrink-to"
+
+	// ShrinkUsedAnnotationKey denotes the used space of the volume reported by the node for shrink request.
+	ShrinkUsedAnnotationKey LabelKey = consts.GroupName + "/shrink-used"
+
+	// ShrinkApprovedAnnotationKey denotes the reduced size of the volume approved by the controller.
+	ShrinkApprovedAnnotationKey LabelKey = consts.GroupName + "/shrink-approved"
+
 	// VolumeClaimIDLabelKey label key to denote the unique allocation of drives for volumes
 	VolumeClaimIDLabelKey LabelKey = consts.GroupName + "/volume-claim-id"
 
@@ -94,25 +133,34 @@ const (
 )
 
 var reservedLabelKeys = map[LabelKey]struct{}{
-	NodeLabelKey:           {},
-	DriveNameLabelKey:      {},
-	AccessTierLabelKey:     {},
-	DriveLabelKey:          {},
-	VersionLabelKey:        {},
-	CreatedByLabelKey:      {},
-	PodNameLabelKey:        {},
-	PodNSLabelKey:          {},
-	LatestVersionLabelKey:  {},
-	TopologyDriverIdentity: {},
-	TopologyDriverRack:     {},
-	TopologyDriverZone:     {},
-	TopologyDriverRegion:   {},
-	MigratedLabelKey:       {},
-	RequestIDLabelKey:      {},
-	SuspendLabelKey:        {},
-	VolumeClaimIDLabelKey:  {},
-	ClaimIDLabelKey:        {},
-	ImageTagLabelKey:       {},
+	NodeLabelKey:              {},
+	DriveNameLabelKey:         {},
+	AccessTierLabelKey:        {},
+	DriveLabelKey:             {},
+	VersionLabelKey:           {},
+	CreatedByLabelKey:         {},
+	PodNameLabelKey:           {},
+	PodNSLabelKey:             {},
+	WipeModeLabelKey:          {},
+	PVCNameLabelKey:           {},
+	PVCNamespaceLabelKey:      {},
+	LatestVersionLabelKey:     {},
+	TopologyDriverIdentity:    {},
+	TopologyDriverRack:        {},
+	TopologyDriverZone:        {},
+	TopologyDriverRegion:      {},
+	MigratedLabelKey:          {},
+	RequestIDLabelKey:         {},
+	SuspendLabelKey:           {},
+	SuspendModeLabelKey:       {},
+	DrainedLabelKey:           {},
+	NodeUnschedulableLabelKey: {},
+	BackendLabelKey:           {},
+	VolumeClaimIDLabelKey:     {},
+	ClaimIDLabelKey:           {},
+	ImageTagLabelKey:          {},
+	VolumeLabelKey:            {},
+	SnapshotLabelKey:          {},
 }
 
 // IsReserved returns if the key is a reserved key
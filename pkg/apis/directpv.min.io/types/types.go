@@ -18,6 +18,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -120,6 +121,95 @@ func AccessTiersToStrings(accessTiers ...AccessTier) (slice []string) {
 	return slice
 }
 
+// WipeMode denotes how data of a deleted volume is wiped.
+type WipeMode string
+
+// Enum values of WipeMode type.
+const (
+	// WipeModeDelete removes the volume data.
+	WipeModeDelete WipeMode = "delete"
+	// WipeModeOverwrite overwrites the volume data with zeros before removing it.
+	WipeModeOverwrite WipeMode = "overwrite"
+	// WipeModeDiscard removes the volume data and discards freed blocks of the drive.
+	WipeModeDiscard WipeMode = "discard"
+)
+
+// ToWipeMode converts string value to wipe mode.
+func ToWipeMode(value string) (WipeMode, error) {
+	switch mode := WipeMode(strings.ToLower(value)); mode {
+	case WipeModeDelete, WipeModeOverwrite, WipeModeDiscard:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown wipe mode %v", value)
+	}
+}
+
+// SuspendMode denotes how a suspended volume is published.
+type SuspendMode string
+
+// Enum values of SuspendMode type.
+const (
+	// SuspendModeEmpty publishes an empty read-only directory instead of the volume data.
+	SuspendModeEmpty SuspendMode = "empty"
+	// SuspendModeReadOnly publishes the volume data read-only if the drive is healthy.
+	SuspendModeReadOnly SuspendMode = "read-only"
+	// SuspendModeFenced fails publishing the volume.
+	SuspendModeFenced SuspendMode = "fenced"
+)
+
+// ToSuspendMode converts string value to suspend mode.
+func ToSuspendMode(value string) (SuspendMode, error) {
+	switch mode := SuspendMode(strings.ToLower(value)); mode {
+	case SuspendModeEmpty, SuspendModeReadOnly, SuspendModeFenced:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown suspend mode %v", value)
+	}
+}
+
+// Backend denotes how volumes are provisioned on a drive.
+type Backend string
+
+// Enum values of Backend type.
+const (
+	// BackendDirectory provisions volumes as directories with project quota on the drive filesystem.
+	BackendDirectory Backend = "directory"
+	// BackendLVMThin provisions volumes as thin logical volumes with their own filesystem in the
+	// LVM thin pool of the drive.
+	BackendLVMThin Backend = "lvm-thin"
+)
+
+// ToBackend converts string value to backend.
+func ToBackend(value string) (Backend, error) {
+	switch backend := Backend(strings.ToLower(value)); backend {
+	case BackendDirectory, BackendLVMThin:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("unknown backend %v", value)
+	}
+}
+
+// GrowPolicy denotes how a drive filesystem is grown when its device is enlarged.
+type GrowPolicy string
+
+// Enum values of GrowPolicy type.
+const (
+	// GrowPolicyManual reports the device is enlarged without growing the filesystem.
+	GrowPolicyManual GrowPolicy = "manual"
+	// GrowPolicyAuto grows the filesystem online when the device is enlarged.
+	GrowPolicyAuto GrowPolicy = "auto"
+)
+
+// ToGrowPolicy converts string value to grow policy.
+func ToGrowPolicy(value string) (GrowPolicy, error) {
+	switch policy := GrowPolicy(strings.ToLower(value)); policy {
+	case GrowPolicyManual, GrowPolicyAuto:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown grow policy %v", value)
+	}
+}
+
 // VolumeConditionType denotes volume condition. Allows maximum upto 316 chars.
 type VolumeConditionType string
 
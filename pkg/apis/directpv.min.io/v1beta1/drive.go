@@ -50,6 +50,21 @@ type DriveStatus struct {
 	// +optional
 	Make string `json:"make,omitempty"`
 	// +optional
+	FSType string `json:"fsType,omitempty"`
+	// +optional
+	FormatProfile *FormatProfile `json:"formatProfile,omitempty"`
+	// +optional
+	Encryption *DriveEncryption `json:"encryption,omitempty"`
+	// LVM denotes the LVM thin pool of the drive having lvm-thin backend.
+	// +optional
+	LVM *DriveLVM `json:"lvm,omitempty"`
+	// Virtual denotes the file-backed loop device of the virtual drive.
+	// +optional
+	Virtual *DriveVirtual `json:"virtual,omitempty"`
+	// DeviceID denotes the stable identifier of the device e.g. dm UUID, MD UUID or WWN.
+	// +optional
+	DeviceID string `json:"deviceID,omitempty"`
+	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -57,6 +72,29 @@ type DriveStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// DriveEncryption denotes LUKS2 encryption and dm-crypt mapping of the drive.
+type DriveEncryption struct {
+	// +optional
+	KeyProvider string `json:"keyProvider,omitempty"`
+	KeyRef      string `json:"keyRef"`
+	LUKSUUID    string `json:"luksUUID"`
+	MapperName  string `json:"mapperName"`
+}
+
+// DriveLVM denotes the LVM volume group of the drive. The drive filesystem is on the metadata
+// logical volume and volumes are thin logical volumes in the thin pool.
+type DriveLVM struct {
+	VGName   string `json:"vgName"`
+	MetaLV   string `json:"metaLV"`
+	ThinPool string `json:"thinPool"`
+}
+
+// DriveVirtual denotes the loop device of the virtual drive backed by a file under DirectPV root.
+// Virtual drives are meant for development and testing only.
+type DriveVirtual struct {
+	BackingFile string `json:"backingFile"`
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:resource:scope=Cluster
@@ -116,6 +154,43 @@ func (drive DirectPVDrive) IsUnschedulable() bool {
 	return drive.Spec.Unschedulable
 }
 
+// GetFSType returns filesystem type of this drive; empty value denotes XFS of drives created by older versions.
+func (drive DirectPVDrive) GetFSType() string {
+	if drive.Status.FSType == "" {
+		return "xfs"
+	}
+	return drive.Status.FSType
+}
+
+// IsEncrypted returns whether this drive is LUKS2 encrypted.
+func (drive DirectPVDrive) IsEncrypted() bool {
+	return drive.Status.Encryption != nil
+}
+
+// IsVirtual returns whether this drive is a file-backed virtual drive.
+func (drive DirectPVDrive) IsVirtual() bool {
+	return drive.Status.Virtual != nil
+}
+
+// GetDevicePath returns path of the device having the filesystem of this drive.
+func (drive DirectPVDrive) GetDevicePath() string {
+	if drive.IsEncrypted() {
+		return "/dev/mapper/" + drive.Status.Encryption.MapperName
+	}
+	if drive.Status.LVM != nil {
+		return "/dev/" + drive.Status.LVM.VGName + "/" + drive.Status.LVM.MetaLV
+	}
+	return "/dev/" + string(drive.GetDriveName())
+}
+
+// GetBackend returns backend of this drive.
+func (drive DirectPVDrive) GetBackend() types.Backend {
+	if drive.Status.LVM != nil {
+		return types.BackendLVMThin
+	}
+	return types.BackendDirectory
+}
+
 // GetDriveID returns this drive's ID.
 func (drive DirectPVDrive) GetDriveID() types.DriveID {
 	return types.DriveID(drive.Name)
@@ -269,6 +344,15 @@ func (drive DirectPVDrive) GetAccessTier() types.AccessTier {
 	return types.AccessTier(drive.getLabel(types.AccessTierLabelKey))
 }
 
+// GetGrowPolicy returns grow policy of this drive.
+func (drive DirectPVDrive) GetGrowPolicy() types.GrowPolicy {
+	policy, err := types.ToGrowPolicy(string(drive.getLabel(types.GrowPolicyLabelKey)))
+	if err != nil {
+		return types.GrowPolicyManual
+	}
+	return policy
+}
+
 // SetMountErrorCondition sets mount error condition to this drive.
 func (drive *DirectPVDrive) SetMountErrorCondition(message string) {
 	drive.setErrorCondition(string(types.DriveConditionTypeMountError), string(types.DriveConditionReasonMountError), message)
@@ -101,6 +101,53 @@ type InitDevice struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Force bool   `json:"force"`
+	// +optional
+	FSType string `json:"fsType,omitempty"`
+	// +optional
+	FormatProfile *FormatProfile `json:"formatProfile,omitempty"`
+	// +optional
+	Encryption *Encryption `json:"encryption,omitempty"`
+	// Partitions denotes the number of equally sized partitions created on the device by a new
+	// GPT; each partition is initialized as a drive.
+	// +optional
+	Partitions int `json:"partitions,omitempty"`
+	// Backend denotes how volumes are provisioned on the drive; defaults to directory.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+	// VirtualSize denotes the size of a file-backed loop device created under DirectPV root and
+	// initialized as a virtual drive instead of the device of ID; meant for development and testing only.
+	// +optional
+	VirtualSize int64 `json:"virtualSize,omitempty"`
+}
+
+// Encryption denotes LUKS2 encryption of the device.
+type Encryption struct {
+	// +optional
+	KeyProvider string `json:"keyProvider,omitempty"`
+	KeyRef      string `json:"keyRef"`
+}
+
+// FormatProfile denotes the named mkfs.xfs options to format the device.
+type FormatProfile struct {
+	Name string `json:"name"`
+	// +optional
+	BlockSize string `json:"blockSize,omitempty"`
+	// +optional
+	InodeSize string `json:"inodeSize,omitempty"`
+	// +optional
+	LogSize string `json:"logSize,omitempty"`
+	// +optional
+	StripeUnit string `json:"stripeUnit,omitempty"`
+	// +optional
+	StripeWidth uint32 `json:"stripeWidth,omitempty"`
+	// +optional
+	CRC *bool `json:"crc,omitempty"`
+	// +optional
+	BigTime *bool `json:"bigtime,omitempty"`
+	// +optional
+	InobtCount *bool `json:"inobtcount,omitempty"`
+	// +optional
+	RmapBT *bool `json:"rmapbt,omitempty"`
 }
 
 // InitRequestStatus represents the status of the InitRequest.
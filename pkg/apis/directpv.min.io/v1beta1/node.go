@@ -106,6 +106,19 @@ type NodeStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// +optional
+	// +listType=atomic
+	VolumeDirs []VolumeDir `json:"volumeDirs,omitempty"`
+}
+
+// VolumeDir denotes a volume directory, having no volume object, found on a drive of the node.
+type VolumeDir struct {
+	Name    string        `json:"name"`
+	DriveID types.DriveID `json:"driveID"`
+	FSUUID  string        `json:"fsuuid"`
+	// Size is the project quota limit of the volume directory; zero denotes no quota is set.
+	// +optional
+	Size int64 `json:"size,omitempty"`
 }
 
 // Device denotes the device information in a drive
@@ -122,4 +135,13 @@ type Device struct {
 	FSUUID string `json:"fsuuid,omitempty"`
 	// +optional
 	DeniedReason string `json:"deniedReason,omitempty"`
+	// Type denotes the device type e.g. disk, partition, multipath, lvm, crypt, dm or md.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// StableID denotes the stable identifier of the device e.g. dm UUID, MD UUID or WWN.
+	// +optional
+	StableID string `json:"stableID,omitempty"`
+	// Components denotes the devices underlying a device-mapper or mdraid device.
+	// +optional
+	Components []string `json:"components,omitempty"`
 }
@@ -35,16 +35,29 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVInitRequestList": schema_pkg_apis_directpvminio_v1beta1_DirectPVInitRequestList(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVNode":            schema_pkg_apis_directpvminio_v1beta1_DirectPVNode(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVNodeList":        schema_pkg_apis_directpvminio_v1beta1_DirectPVNodeList(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVQuota":           schema_pkg_apis_directpvminio_v1beta1_DirectPVQuota(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVQuotaList":       schema_pkg_apis_directpvminio_v1beta1_DirectPVQuotaList(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVSnapshot":        schema_pkg_apis_directpvminio_v1beta1_DirectPVSnapshot(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVSnapshotList":    schema_pkg_apis_directpvminio_v1beta1_DirectPVSnapshotList(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolume":          schema_pkg_apis_directpvminio_v1beta1_DirectPVVolume(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolumeList":      schema_pkg_apis_directpvminio_v1beta1_DirectPVVolumeList(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveEncryption":         schema_pkg_apis_directpvminio_v1beta1_DriveEncryption(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveLVM":                schema_pkg_apis_directpvminio_v1beta1_DriveLVM(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveSpec":               schema_pkg_apis_directpvminio_v1beta1_DriveSpec(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveStatus":             schema_pkg_apis_directpvminio_v1beta1_DriveStatus(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveVirtual":            schema_pkg_apis_directpvminio_v1beta1_DriveVirtual(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.Encryption":              schema_pkg_apis_directpvminio_v1beta1_Encryption(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.FormatProfile":           schema_pkg_apis_directpvminio_v1beta1_FormatProfile(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.InitDevice":              schema_pkg_apis_directpvminio_v1beta1_InitDevice(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.InitDeviceResult":        schema_pkg_apis_directpvminio_v1beta1_InitDeviceResult(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.InitRequestSpec":         schema_pkg_apis_directpvminio_v1beta1_InitRequestSpec(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.InitRequestStatus":       schema_pkg_apis_directpvminio_v1beta1_InitRequestStatus(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.NodeSpec":                schema_pkg_apis_directpvminio_v1beta1_NodeSpec(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.NodeStatus":              schema_pkg_apis_directpvminio_v1beta1_NodeStatus(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaSpec":               schema_pkg_apis_directpvminio_v1beta1_QuotaSpec(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaStatus":             schema_pkg_apis_directpvminio_v1beta1_QuotaStatus(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.SnapshotStatus":          schema_pkg_apis_directpvminio_v1beta1_SnapshotStatus(ref),
+		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeDir":               schema_pkg_apis_directpvminio_v1beta1_VolumeDir(ref),
 		"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeStatus":            schema_pkg_apis_directpvminio_v1beta1_VolumeStatus(ref),
 	}
 }
@@ -108,6 +121,35 @@ func schema_pkg_apis_directpvminio_v1beta1_Device(ref common.ReferenceCallback)
 							Format: "",
 						},
 					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type denotes the device type e.g. disk, partition, multipath, lvm, crypt, dm or md.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stableID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StableID denotes the stable identifier of the device e.g. dm UUID, MD UUID or WWN.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"components": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Components denotes the devices underlying a device-mapper or mdraid device.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"name", "id", "majorMinor", "size"},
 			},
@@ -409,11 +451,11 @@ func schema_pkg_apis_directpvminio_v1beta1_DirectPVNodeList(ref common.Reference
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolume(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVQuota(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DirectPVVolume denotes volume CRD object.",
+				Description: "DirectPVQuota denotes quota CRD object limiting DirectPV capacity of a namespace.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -436,26 +478,32 @@ func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolume(ref common.ReferenceCa
 							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaSpec"),
+						},
+					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeStatus"),
+							Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaStatus"),
 						},
 					},
 				},
-				Required: []string{"metadata", "status"},
+				Required: []string{"metadata", "spec"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaSpec", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.QuotaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolumeList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVQuotaList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DirectPVVolumeList denotes list of volumes.",
+				Description: "DirectPVQuotaList denotes list of quotas.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -486,7 +534,7 @@ func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolumeList(ref common.Referen
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolume"),
+										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVQuota"),
 									},
 								},
 							},
@@ -497,210 +545,667 @@ func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolumeList(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolume", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVQuota", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_DriveSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVSnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DriveSpec represents DirectPV drive specification values.",
+				Description: "DirectPVSnapshot denotes snapshot CRD object of a thin volume.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"unschedulable": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"relabel": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.SnapshotStatus"),
 						},
 					},
 				},
+				Required: []string{"metadata", "status"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.SnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_DriveStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVSnapshotList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DriveStatus denotes drive information.",
+				Description: "DirectPVSnapshotList denotes list of snapshots.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"totalCapacity": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int64",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"allocatedCapacity": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int64",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"freeCapacity": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int64",
+							Description: "metdata is the standard list metadata.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
 					},
-					"fsuuid": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVSnapshot"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVSnapshot", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolume(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectPVVolume denotes volume CRD object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeStatus"),
 						},
 					},
-					"topology": {
+				},
+				Required: []string{"metadata", "status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_DirectPVVolumeList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectPVVolumeList denotes list of volumes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"make": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"conditions": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"type",
-								},
-								"x-kubernetes-list-type":       "map",
-								"x-kubernetes-patch-merge-key": "type",
-								"x-kubernetes-patch-strategy":  "merge",
-							},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "metdata is the standard list metadata.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
+					},
+					"items": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolume"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"totalCapacity", "allocatedCapacity", "freeCapacity", "fsuuid", "status", "topology"},
+				Required: []string{"items"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DirectPVVolume", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_InitDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DriveEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "InitDevice represents the device requested for initialization.",
+				Description: "DriveEncryption denotes LUKS2 encryption and dm-crypt mapping of the drive.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"id": {
+					"keyProvider": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"keyRef": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"name": {
+					"luksUUID": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"force": {
+					"mapperName": {
 						SchemaProps: spec.SchemaProps{
-							Default: false,
-							Type:    []string{"boolean"},
+							Default: "",
+							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
 				},
-				Required: []string{"id", "name", "force"},
+				Required: []string{"keyRef", "luksUUID", "mapperName"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_InitDeviceResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DriveLVM(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "InitDeviceResult represents the result of the InitDeviceRequest.",
+				Description: "DriveLVM denotes the LVM volume group of the drive. The drive filesystem is on the metadata logical volume and volumes are thin logical volumes in the thin pool.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"vgName": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"error": {
+					"metaLV": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"thinPool": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
 				},
-				Required: []string{"name"},
+				Required: []string{"vgName", "metaLV", "thinPool"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_directpvminio_v1beta1_InitRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_directpvminio_v1beta1_DriveSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "InitRequestSpec represents the spec for InitRequest.",
+				Description: "DriveSpec represents DirectPV drive specification values.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"devices": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "atomic",
-							},
+					"unschedulable": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
 						},
+					},
+					"relabel": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_DriveStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DriveStatus denotes drive information.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"totalCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"allocatedCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"freeCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"fsuuid": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"topology": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"make": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"fsType": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"formatProfile": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.FormatProfile"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveEncryption"),
+						},
+					},
+					"lvm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LVM denotes the LVM thin pool of the drive having lvm-thin backend.",
+							Ref:         ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveLVM"),
+						},
+					},
+					"virtual": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Virtual denotes the file-backed loop device of the virtual drive.",
+							Ref:         ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveVirtual"),
+						},
+					},
+					"deviceID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeviceID denotes the stable identifier of the device e.g. dm UUID, MD UUID or WWN.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type":       "map",
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"totalCapacity", "allocatedCapacity", "freeCapacity", "fsuuid", "status", "topology"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveEncryption", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveLVM", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.DriveVirtual", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.FormatProfile", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_DriveVirtual(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DriveVirtual denotes the loop device of the virtual drive backed by a file under DirectPV root. Virtual drives are meant for development and testing only.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"backingFile": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"backingFile"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_Encryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Encryption denotes LUKS2 encryption of the device.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"keyProvider": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"keyRef": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"keyRef"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_FormatProfile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FormatProfile denotes the named mkfs.xfs options to format the device.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"blockSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"inodeSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"logSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"stripeUnit": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"stripeWidth": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"crc": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"bigtime": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"inobtcount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"rmapbt": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_InitDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitDevice represents the device requested for initialization.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"force": {
+						SchemaProps: spec.SchemaProps{
+							Default: false,
+							Type:    []string{"boolean"},
+							Format:  "",
+						},
+					},
+					"fsType": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"formatProfile": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.FormatProfile"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.Encryption"),
+						},
+					},
+					"partitions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Partitions denotes the number of equally sized partitions created on the device by a new GPT; each partition is initialized as a drive.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backend denotes how volumes are provisioned on the drive; defaults to directory.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"virtualSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualSize denotes the size of a file-backed loop device created under DirectPV root and initialized as a virtual drive instead of the device of ID; meant for development and testing only.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"id", "name", "force"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.Encryption", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.FormatProfile"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_InitDeviceResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitDeviceResult represents the result of the InitDeviceRequest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_InitRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitRequestSpec represents the spec for InitRequest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"devices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
 										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.InitDevice"),
 									},
@@ -825,12 +1330,218 @@ func schema_pkg_apis_directpvminio_v1beta1_NodeStatus(ref common.ReferenceCallba
 							},
 						},
 					},
+					"volumeDirs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeDir"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"devices"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.Device", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.Device", "github.com/minio/directpv/pkg/apis/directpv.min.io/v1beta1.VolumeDir", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_QuotaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QuotaSpec denotes the capacity limits of a namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity limits the total capacity of volumes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes limits the number of volumes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"accessTiers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccessTiers limits the capacity of volumes by drive access-tier.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes limits the capacity of volumes by node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_QuotaStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QuotaStatus denotes the capacity usage of a namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"accessTiers": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_SnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotStatus denotes snapshot information.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the size of the source volume when the snapshot is requested.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readyToUse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyToUse denotes whether the thin logical volume of the snapshot is created.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"creationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreationTime is the time the snapshot is taken.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"size", "readyToUse"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_directpvminio_v1beta1_VolumeDir(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeDir denotes a volume directory, having no volume object, found on a drive of the node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"driveID": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"fsuuid": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the project quota limit of the volume directory; zero denotes no quota is set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name", "driveID", "fsuuid"},
+			},
+		},
 	}
 }
 
@@ -897,6 +1608,25 @@ func schema_pkg_apis_directpvminio_v1beta1_VolumeStatus(ref common.ReferenceCall
 							Format:  "",
 						},
 					},
+					"mountOptions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
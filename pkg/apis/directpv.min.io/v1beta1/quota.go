@@ -0,0 +1,93 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta1
+
+import (
+	"github.com/minio/directpv/pkg/consts"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectPVQuotaList denotes list of quotas.
+type DirectPVQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metdata is the standard list metadata.
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	Items           []DirectPVQuota `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:resource:scope=Namespaced,path=directpvquotas,singular=directpvquota
+// +kubebuilder:storageversion
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectPVQuota denotes quota CRD object limiting DirectPV capacity of a namespace.
+type DirectPVQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec QuotaSpec `json:"spec"`
+	// +optional
+	Status QuotaStatus `json:"status,omitempty"`
+}
+
+// QuotaSpec denotes the capacity limits of a namespace.
+type QuotaSpec struct {
+	// Capacity limits the total capacity of volumes.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Volumes limits the number of volumes.
+	// +optional
+	Volumes *int64 `json:"volumes,omitempty"`
+	// AccessTiers limits the capacity of volumes by drive access-tier.
+	// +optional
+	AccessTiers map[string]resource.Quantity `json:"accessTiers,omitempty"`
+	// Nodes limits the capacity of volumes by node.
+	// +optional
+	Nodes map[string]resource.Quantity `json:"nodes,omitempty"`
+}
+
+// QuotaStatus denotes the capacity usage of a namespace.
+type QuotaStatus struct {
+	// +optional
+	Capacity resource.Quantity `json:"capacity,omitempty"`
+	// +optional
+	Volumes int64 `json:"volumes,omitempty"`
+	// +optional
+	AccessTiers map[string]resource.Quantity `json:"accessTiers,omitempty"`
+	// +optional
+	Nodes map[string]resource.Quantity `json:"nodes,omitempty"`
+}
+
+// NewDirectPVQuota creates new DirectPV quota.
+func NewDirectPVQuota(name, namespace string, spec QuotaSpec) *DirectPVQuota {
+	return &DirectPVQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: Group + "/" + Version,
+			Kind:       consts.QuotaKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
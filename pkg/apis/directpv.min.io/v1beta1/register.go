@@ -65,6 +65,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DirectPVNodeList{},
 		&DirectPVInitRequest{},
 		&DirectPVInitRequestList{},
+		&DirectPVQuota{},
+		&DirectPVQuotaList{},
+		&DirectPVSnapshot{},
+		&DirectPVSnapshotList{},
 	)
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,118 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta1
+
+import (
+	"github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/consts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const snapshotFinalizerDataProtection = Group + "/data-protection"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectPVSnapshotList denotes list of snapshots.
+type DirectPVSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metdata is the standard list metadata.
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	Items           []DirectPVSnapshot `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectPVSnapshot denotes snapshot CRD object of a thin volume.
+type DirectPVSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Status SnapshotStatus `json:"status"`
+}
+
+// SnapshotStatus denotes snapshot information.
+type SnapshotStatus struct {
+	// Size is the size of the source volume when the snapshot is requested.
+	Size int64 `json:"size"`
+	// ReadyToUse denotes whether the thin logical volume of the snapshot is created.
+	ReadyToUse bool `json:"readyToUse"`
+	// CreationTime is the time the snapshot is taken.
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+}
+
+// NewDirectPVSnapshot creates new DirectPV snapshot of the volume.
+func NewDirectPVSnapshot(name string, volume *DirectPVVolume) *DirectPVSnapshot {
+	return &DirectPVSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: Group + "/" + Version,
+			Kind:       consts.SnapshotKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Finalizers: []string{snapshotFinalizerDataProtection},
+			Labels: map[string]string{
+				string(types.VolumeLabelKey):    volume.Name,
+				string(types.DriveLabelKey):     string(volume.GetDriveID()),
+				string(types.NodeLabelKey):      string(volume.GetNodeID()),
+				string(types.DriveNameLabelKey): string(volume.GetDriveName()),
+				string(types.VersionLabelKey):   Version,
+				string(types.CreatedByLabelKey): consts.ControllerName,
+			},
+		},
+		Status: SnapshotStatus{
+			Size: volume.Status.TotalCapacity,
+		},
+	}
+}
+
+func (snapshot DirectPVSnapshot) getLabel(key types.LabelKey) types.LabelValue {
+	values := snapshot.GetLabels()
+	return types.ToLabelValue(values[string(key)])
+}
+
+// GetVolumeName returns the source volume name of this snapshot.
+func (snapshot DirectPVSnapshot) GetVolumeName() string {
+	return string(snapshot.getLabel(types.VolumeLabelKey))
+}
+
+// GetDriveID returns drive ID of this snapshot.
+func (snapshot DirectPVSnapshot) GetDriveID() types.DriveID {
+	return types.DriveID(snapshot.getLabel(types.DriveLabelKey))
+}
+
+// GetNodeID returns node ID of this snapshot.
+func (snapshot DirectPVSnapshot) GetNodeID() types.NodeID {
+	return types.NodeID(snapshot.getLabel(types.NodeLabelKey))
+}
+
+// RemoveDataProtection removes data protection of this snapshot.
+func (snapshot *DirectPVSnapshot) RemoveDataProtection() {
+	finalizers := []string{}
+	for _, finalizer := range snapshot.Finalizers {
+		if finalizer != snapshotFinalizerDataProtection {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	snapshot.Finalizers = finalizers
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -40,6 +41,9 @@ type VolumeStatus struct {
 	UsedCapacity      int64              `json:"usedCapacity"`
 	Status            types.VolumeStatus `json:"status"`
 	// +optional
+	// +listType=atomic
+	MountOptions []string `json:"mountOptions,omitempty"`
+	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -150,6 +154,16 @@ func (volume DirectPVVolume) IsReleased() bool {
 	return len(volume.Finalizers) == 1 && volume.Finalizers[0] == volumeFinalizerPurgeProtection
 }
 
+// HasPVProtection returns whether this volume has PV protection.
+func (volume DirectPVVolume) HasPVProtection() bool {
+	return utils.Contains(volume.Finalizers, volumeFinalizerPVProtection)
+}
+
+// HasPurgeProtection returns whether this volume has purge protection.
+func (volume DirectPVVolume) HasPurgeProtection() bool {
+	return utils.Contains(volume.Finalizers, volumeFinalizerPurgeProtection)
+}
+
 // GetLabels overrides the definition to return non-nil map.
 func (volume *DirectPVVolume) GetLabels() map[string]string {
 	values := volume.ObjectMeta.GetLabels()
@@ -283,6 +297,91 @@ func (volume DirectPVVolume) GetPodNS() string {
 	return string(volume.getLabel(types.PodNSLabelKey))
 }
 
+// SetWipeMode sets wipe mode to this volume.
+func (volume *DirectPVVolume) SetWipeMode(mode types.WipeMode) {
+	if mode == "" {
+		return
+	}
+	volume.SetLabel(types.WipeModeLabelKey, types.LabelValue(mode))
+}
+
+// SetBackend sets the backend of this volume; directory backend is not set.
+func (volume *DirectPVVolume) SetBackend(backend types.Backend) {
+	if backend == "" || backend == types.BackendDirectory {
+		return
+	}
+	volume.SetLabel(types.BackendLabelKey, types.LabelValue(backend))
+}
+
+// GetBackend returns backend of this volume.
+func (volume DirectPVVolume) GetBackend() types.Backend {
+	backend, err := types.ToBackend(string(volume.getLabel(types.BackendLabelKey)))
+	if err != nil {
+		return types.BackendDirectory
+	}
+	return backend
+}
+
+// SetSnapshot sets the snapshot this volume is restored from.
+func (volume *DirectPVVolume) SetSnapshot(name string) {
+	volume.SetLabel(types.SnapshotLabelKey, types.LabelValue(name))
+}
+
+// GetSnapshot returns the snapshot this volume is restored from.
+func (volume DirectPVVolume) GetSnapshot() string {
+	return string(volume.getLabel(types.SnapshotLabelKey))
+}
+
+// GetWipeMode returns wipe mode of this volume.
+func (volume DirectPVVolume) GetWipeMode() types.WipeMode {
+	mode, err := types.ToWipeMode(string(volume.getLabel(types.WipeModeLabelKey)))
+	if err != nil {
+		return types.WipeModeDelete
+	}
+	return mode
+}
+
+// setPVCLabel sets the value as the label and the full value as the annotation of the key, as
+// the label value is limited to 63 characters and normalized.
+func (volume *DirectPVVolume) setPVCLabel(key types.LabelKey, value string) {
+	if value == "" {
+		return
+	}
+	volume.SetLabel(key, types.ToLabelValue(value))
+	if volume.Annotations == nil {
+		volume.Annotations = map[string]string{}
+	}
+	volume.Annotations[string(key)] = value
+}
+
+// getPVCLabel returns the full value from the annotation of the key if present, else the label value.
+func (volume DirectPVVolume) getPVCLabel(key types.LabelKey) string {
+	if value, found := volume.Annotations[string(key)]; found {
+		return value
+	}
+	return string(volume.getLabel(key))
+}
+
+// SetPVCName sets associated persistent volume claim name to this volume.
+func (volume *DirectPVVolume) SetPVCName(name string) {
+	volume.setPVCLabel(types.PVCNameLabelKey, name)
+}
+
+// GetPVCName returns associated persistent volume claim name of this volume.
+func (volume DirectPVVolume) GetPVCName() string {
+	return volume.getPVCLabel(types.PVCNameLabelKey)
+}
+
+// SetPVCNamespace sets associated persistent volume claim namespace to this volume.
+func (volume *DirectPVVolume) SetPVCNamespace(namespace string) {
+	volume.setPVCLabel(types.PVCNamespaceLabelKey, namespace)
+}
+
+// GetPVCNamespace returns associated persistent volume claim namespace of this volume.
+func (volume DirectPVVolume) GetPVCNamespace() string {
+	return volume.getPVCLabel(types.PVCNamespaceLabelKey)
+}
+
 // GetTenantName returns associated tenant name of this volume.
 func (volume DirectPVVolume) GetTenantName() string {
 	return string(volume.getLabel(types.LabelKey(Group + "/tenant")))
@@ -306,14 +405,33 @@ func (volume *DirectPVVolume) GetClaimID() string {
 	return string(volume.getLabel(types.ClaimIDLabelKey))
 }
 
+// GetSuspendMode returns suspend mode of this volume.
+func (volume DirectPVVolume) GetSuspendMode() types.SuspendMode {
+	mode, err := types.ToSuspendMode(string(volume.getLabel(types.SuspendModeLabelKey)))
+	if err != nil {
+		return types.SuspendModeEmpty
+	}
+	return mode
+}
+
 // Suspend suspends the volume by setting the label `directpv.min.io/suspend: true`.
 func (volume *DirectPVVolume) Suspend() bool {
 	return volume.SetLabel(types.SuspendLabelKey, types.ToLabelValue(strconv.FormatBool(true)))
 }
 
-// Resume reverts the suspended volume by removing the label `directpv.min.io/suspend`.
+// SuspendWithMode suspends the volume and records the suspend mode in the label `directpv.min.io/suspend-mode`.
+func (volume *DirectPVVolume) SuspendWithMode(mode types.SuspendMode) bool {
+	updated := volume.Suspend()
+	if mode == "" || mode == types.SuspendModeEmpty {
+		return volume.RemoveLabel(types.SuspendModeLabelKey) || updated
+	}
+	return volume.SetLabel(types.SuspendModeLabelKey, types.LabelValue(mode)) || updated
+}
+
+// Resume reverts the suspended volume by removing the labels `directpv.min.io/suspend` and `directpv.min.io/suspend-mode`.
 func (volume *DirectPVVolume) Resume() bool {
-	return volume.RemoveLabel(types.SuspendLabelKey)
+	removed := volume.RemoveLabel(types.SuspendModeLabelKey)
+	return volume.RemoveLabel(types.SuspendLabelKey) || removed
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
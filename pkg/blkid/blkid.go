@@ -0,0 +1,198 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package blkid probes on-disk signatures of filesystems, storage stack
+// members and partition tables without depending on udev or libblkid.
+package blkid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Usage denotes usage of a signature like ID_FS_USAGE of udev.
+type Usage string
+
+// Supported signature usages.
+const (
+	UsageFilesystem     Usage = "filesystem"
+	UsageRaid           Usage = "raid"
+	UsageCrypto         Usage = "crypto"
+	UsageOther          Usage = "other"
+	UsagePartitionTable Usage = "partition_table"
+)
+
+// Signature types; names are compatible with ID_FS_TYPE and ID_PART_TABLE_TYPE of udev.
+const (
+	TypeXFS    = "xfs"
+	TypeExt2   = "ext2"
+	TypeExt3   = "ext3"
+	TypeExt4   = "ext4"
+	TypeBtrfs  = "btrfs"
+	TypeLVM2   = "LVM2_member"
+	TypeLUKS   = "crypto_LUKS"
+	TypeMDRaid = "linux_raid_member"
+	TypeZFS    = "zfs_member"
+	TypeSwap   = "swap"
+	TypeGPT    = "gpt"
+	TypeDOS    = "dos"
+)
+
+const (
+	sectorSize  = 512
+	maxReadSize = 1024 * 1024
+)
+
+// Signature is a probed on-disk signature.
+type Signature struct {
+	Type  string
+	Usage Usage
+	UUID  string
+	Label string
+}
+
+// IsPartitionTable returns whether the signature is a partition table.
+func (s Signature) IsPartitionTable() bool {
+	return s.Usage == UsagePartitionTable
+}
+
+// Signatures is list of probed signatures.
+type Signatures []Signature
+
+// FS returns the first non partition table signature.
+func (signatures Signatures) FS() *Signature {
+	for i := range signatures {
+		if !signatures[i].IsPartitionTable() {
+			return &signatures[i]
+		}
+	}
+	return nil
+}
+
+// PartitionTable returns the first partition table signature.
+func (signatures Signatures) PartitionTable() *Signature {
+	for i := range signatures {
+		if signatures[i].IsPartitionTable() {
+			return &signatures[i]
+		}
+	}
+	return nil
+}
+
+type prober func(r io.ReaderAt, size int64) (*Signature, error)
+
+// Storage stack members are probed before filesystems as a member device
+// usually carries a stale or nested filesystem signature as well.
+var probers = []prober{
+	probeMDRaid,
+	probeLVM2,
+	probeLUKS,
+	probeZFS,
+	probeXFS,
+	probeExt,
+	probeBtrfs,
+	probeSwap,
+	probeGPT,
+	probeDOS,
+}
+
+// Probe returns signatures found in r of given size.
+func Probe(r io.ReaderAt, size int64) (signatures Signatures, err error) {
+	for _, probe := range probers {
+		signature, err := probe(r, size)
+		if err != nil {
+			return nil, err
+		}
+		if signature != nil {
+			signatures = append(signatures, *signature)
+		}
+	}
+	return signatures, nil
+}
+
+// ProbeDevice returns signatures found in given device or file.
+func ProbeDevice(device string) (Signatures, error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get size; device=%v; err=%w", device, err)
+	}
+
+	return Probe(file, size)
+}
+
+// readAt reads length bytes at offset; nil is returned if r is too short.
+func readAt(r io.ReaderAt, size, offset int64, length int) ([]byte, error) {
+	if offset < 0 || length > maxReadSize || offset+int64(length) > size {
+		return nil, nil
+	}
+
+	buf := make([]byte, length)
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return buf, nil
+}
+
+func isZero(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func toUUID(buf []byte) string {
+	if len(buf) != 16 || isZero(buf) {
+		return ""
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16])
+}
+
+// toGUID formats mixed-endian GUID used by GPT.
+func toGUID(buf []byte) string {
+	if len(buf) != 16 || isZero(buf) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(buf[0:4]),
+		binary.LittleEndian.Uint16(buf[4:6]),
+		binary.LittleEndian.Uint16(buf[6:8]),
+		buf[8:10],
+		buf[10:16],
+	)
+}
+
+func toLabel(buf []byte) string {
+	if i := strings.IndexByte(string(buf), 0); i >= 0 {
+		buf = buf[:i]
+	}
+	return strings.TrimSpace(string(buf))
+}
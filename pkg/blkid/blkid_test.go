@@ -0,0 +1,89 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package blkid
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestProbeDevice(t *testing.T) {
+	uuid := "6a1b3d2e-47c8-4a2f-9f0e-1c2d3e4f5a6b"
+	testCases := []struct {
+		filename           string
+		expectedSignatures Signatures
+	}{
+		{"../xfs/xfs.testdata", Signatures{{Type: TypeXFS, Usage: UsageFilesystem, UUID: "2dc39938-8a84-4078-abec-159bfae4aa0f"}}},
+		{"ext2.testdata", Signatures{{Type: TypeExt2, Usage: UsageFilesystem, UUID: uuid, Label: "DIRECTPV"}}},
+		{"ext3.testdata", Signatures{{Type: TypeExt3, Usage: UsageFilesystem, UUID: uuid, Label: "DIRECTPV"}}},
+		{"ext4.testdata", Signatures{{Type: TypeExt4, Usage: UsageFilesystem, UUID: uuid, Label: "DIRECTPV"}}},
+		{"btrfs.testdata", Signatures{{Type: TypeBtrfs, Usage: UsageFilesystem, UUID: uuid, Label: "DIRECTPV"}}},
+		{"swap.testdata", Signatures{{Type: TypeSwap, Usage: UsageOther, UUID: uuid, Label: "DIRECTPV"}}},
+		{"lvm2.testdata", Signatures{{Type: TypeLVM2, Usage: UsageRaid, UUID: "Abcdef-0123-4567-89AB-CDEF-abcd-ef0123"}}},
+		{"luks1.testdata", Signatures{{Type: TypeLUKS, Usage: UsageCrypto, UUID: uuid}}},
+		{"luks2.testdata", Signatures{{Type: TypeLUKS, Usage: UsageCrypto, UUID: uuid, Label: "DIRECTPV"}}},
+		{"mdraid090.testdata", Signatures{{Type: TypeMDRaid, Usage: UsageRaid, UUID: uuid}}},
+		{"mdraid10.testdata", Signatures{{Type: TypeMDRaid, Usage: UsageRaid, UUID: uuid, Label: "node1:md0"}}},
+		{"mdraid12.testdata", Signatures{{Type: TypeMDRaid, Usage: UsageRaid, UUID: uuid, Label: "node1:md0"}}},
+		{"zfs.testdata", Signatures{{Type: TypeZFS, Usage: UsageFilesystem}}},
+		{"gpt.testdata", Signatures{{Type: TypeGPT, Usage: UsagePartitionTable, UUID: uuid}}},
+		{"dos.testdata", Signatures{{Type: TypeDOS, Usage: UsagePartitionTable, UUID: "1c2d3e4f"}}},
+		{"../xfs/zero.testdata", nil},
+		{"../xfs/empty.testdata", nil},
+	}
+
+	for i, testCase := range testCases {
+		signatures, err := ProbeDevice(testCase.filename)
+		if err != nil {
+			t.Fatalf("case %v: %v: %v", i+1, testCase.filename, err)
+		}
+
+		if !reflect.DeepEqual(signatures, testCase.expectedSignatures) {
+			t.Fatalf("case %v: %v: expected: %+v, got: %+v", i+1, testCase.filename, testCase.expectedSignatures, signatures)
+		}
+	}
+}
+
+func TestProbeNested(t *testing.T) {
+	md, err := os.ReadFile("mdraid10.testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext4, err := os.ReadFile("ext4.testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// MD 1.0 keeps its superblock at the end; member data starts with the filesystem.
+	copy(md, ext4)
+	signatures, err := Probe(bytes.NewReader(md), int64(len(md)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(signatures) != 2 {
+		t.Fatalf("expected: 2 signatures, got: %+v", signatures)
+	}
+	if fs := signatures.FS(); fs == nil || fs.Type != TypeMDRaid {
+		t.Fatalf("expected: %v, got: %+v", TypeMDRaid, fs)
+	}
+	if pt := signatures.PartitionTable(); pt != nil {
+		t.Fatalf("expected: no partition table, got: %+v", pt)
+	}
+}
@@ -0,0 +1,150 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package blkid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// Refer https://www.kernel.org/doc/html/latest/filesystems/xfs/xfs-ondisk-format.html
+func probeXFS(r io.ReaderAt, size int64) (*Signature, error) {
+	buf, err := readAt(r, size, 0, sectorSize)
+	if buf == nil || err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(buf[0:4], []byte("XFSB")) {
+		return nil, nil
+	}
+
+	return &Signature{
+		Type:  TypeXFS,
+		Usage: UsageFilesystem,
+		UUID:  toUUID(buf[32:48]),
+		Label: toLabel(buf[108:120]),
+	}, nil
+}
+
+const (
+	extSuperBlockOffset = 1024
+	extMagic            = 0xEF53
+
+	extFeatureCompatHasJournal = 0x0004
+
+	extFeatureIncompatJournalDev = 0x0008
+
+	// Features understood by ext3; anything else makes the filesystem ext4.
+	extFeatureIncompatExt3 = 0x0002 | 0x0004 | 0x0010 // filetype, recover, meta_bg
+	extFeatureROCompatExt3 = 0x0001 | 0x0002 | 0x0004 // sparse_super, large_file, btree_dir
+	extFeatureIncompatExt2 = 0x0002 | 0x0010          // filetype, meta_bg
+	extFeatureROCompatExt2 = extFeatureROCompatExt3
+)
+
+// Refer https://www.kernel.org/doc/html/latest/filesystems/ext4/super.html
+func probeExt(r io.ReaderAt, size int64) (*Signature, error) {
+	buf, err := readAt(r, size, extSuperBlockOffset, 0x88)
+	if buf == nil || err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint16(buf[0x38:]) != extMagic {
+		return nil, nil
+	}
+
+	compat := binary.LittleEndian.Uint32(buf[0x5C:])
+	incompat := binary.LittleEndian.Uint32(buf[0x60:])
+	roCompat := binary.LittleEndian.Uint32(buf[0x64:])
+
+	if incompat&extFeatureIncompatJournalDev != 0 {
+		// External journal device is not a filesystem.
+		return nil, nil
+	}
+
+	var fsType string
+	switch {
+	case incompat&^extFeatureIncompatExt3 != 0, roCompat&^extFeatureROCompatExt3 != 0:
+		fsType = TypeExt4
+	case compat&extFeatureCompatHasJournal != 0:
+		fsType = TypeExt3
+	case incompat&^extFeatureIncompatExt2 != 0, roCompat&^extFeatureROCompatExt2 != 0:
+		fsType = TypeExt4
+	default:
+		fsType = TypeExt2
+	}
+
+	return &Signature{
+		Type:  fsType,
+		Usage: UsageFilesystem,
+		UUID:  toUUID(buf[0x68:0x78]),
+		Label: toLabel(buf[0x78:0x88]),
+	}, nil
+}
+
+const btrfsSuperBlockOffset = 64 * 1024
+
+// Refer https://btrfs.readthedocs.io/en/latest/dev/On-disk-format.html#superblock
+func probeBtrfs(r io.ReaderAt, size int64) (*Signature, error) {
+	buf, err := readAt(r, size, btrfsSuperBlockOffset, 0x22b)
+	if buf == nil || err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(buf[0x40:0x48], []byte("_BHRfS_M")) {
+		return nil, nil
+	}
+
+	return &Signature{
+		Type:  TypeBtrfs,
+		Usage: UsageFilesystem,
+		UUID:  toUUID(buf[0x20:0x30]),
+		Label: toLabel(buf[0x12b:0x22b]),
+	}, nil
+}
+
+// Swap header is at the end of first page; page size of the system
+// created the swap area is unknown, hence all common page sizes are tried.
+func probeSwap(r io.ReaderAt, size int64) (*Signature, error) {
+	for _, pageSize := range []int64{4096, 8192, 16384, 65536} {
+		buf, err := readAt(r, size, pageSize-10, 10)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			return nil, nil
+		}
+
+		switch string(buf) {
+		case "SWAP-SPACE":
+			return &Signature{Type: TypeSwap, Usage: UsageOther}, nil
+		case "SWAPSPACE2":
+			header, err := readAt(r, size, 1024, 44)
+			if header == nil || err != nil {
+				return nil, err
+			}
+			signature := &Signature{Type: TypeSwap, Usage: UsageOther}
+			if binary.LittleEndian.Uint32(header[0:4]) == 1 {
+				signature.UUID = toUUID(header[12:28])
+				signature.Label = toLabel(header[28:44])
+			}
+			return signature, nil
+		}
+	}
+
+	return nil, nil
+}
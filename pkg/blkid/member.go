@@ -0,0 +1,161 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package blkid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const mdMagic = 0xa92b4efc
+
+// Refer https://raid.wiki.kernel.org/index.php/RAID_superblock_formats
+func probeMDRaid(r io.ReaderAt, size int64) (*Signature, error) {
+	// Version 1.1 is at start, 1.2 is at 4KiB and 1.0 is at 8KiB before
+	// end of the device aligned to 4KiB.
+	for _, offset := range []int64{0, 4096, ((size >> 9) - 16) &^ 7 << 9} {
+		buf, err := readAt(r, size, offset, 64)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			continue
+		}
+
+		if binary.LittleEndian.Uint32(buf[0:4]) == mdMagic && binary.LittleEndian.Uint32(buf[4:8]) == 1 {
+			return &Signature{
+				Type:  TypeMDRaid,
+				Usage: UsageRaid,
+				UUID:  toUUID(buf[16:32]),
+				Label: toLabel(buf[32:64]),
+			}, nil
+		}
+	}
+
+	// Version 0.90 is at 64KiB before end of the device aligned to 64KiB.
+	buf, err := readAt(r, size, size&^(64*1024-1)-64*1024, 64)
+	if buf == nil || err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(buf[0:4]) != mdMagic || binary.LittleEndian.Uint32(buf[4:8]) != 0 {
+		return nil, nil
+	}
+
+	// UUID is formed by set_uuid0 (word 5) and set_uuid1..3 (words 13 to 15).
+	uuid := make([]byte, 0, 16)
+	for _, word := range []int{5, 13, 14, 15} {
+		uuid = binary.BigEndian.AppendUint32(uuid, binary.LittleEndian.Uint32(buf[word*4:]))
+	}
+
+	return &Signature{
+		Type:  TypeMDRaid,
+		Usage: UsageRaid,
+		UUID:  toUUID(uuid),
+	}, nil
+}
+
+// Refer https://github.com/lvmteam/lvm2/blob/main/lib/format_text/layout.h
+func probeLVM2(r io.ReaderAt, size int64) (*Signature, error) {
+	// Label is in any of first four sectors.
+	for sector := int64(0); sector < 4; sector++ {
+		buf, err := readAt(r, size, sector*sectorSize, sectorSize)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			return nil, nil
+		}
+
+		if !bytes.Equal(buf[0:8], []byte("LABELONE")) || !bytes.Equal(buf[0x18:0x20], []byte("LVM2 001")) {
+			continue
+		}
+
+		signature := &Signature{Type: TypeLVM2, Usage: UsageRaid}
+		if offset := binary.LittleEndian.Uint32(buf[0x14:0x18]); offset <= sectorSize-32 {
+			if uuid := buf[offset : offset+32]; !isZero(uuid) {
+				signature.UUID = fmt.Sprintf(
+					"%s-%s-%s-%s-%s-%s-%s",
+					uuid[0:6], uuid[6:10], uuid[10:14], uuid[14:18], uuid[18:22], uuid[22:26], uuid[26:32],
+				)
+			}
+		}
+		return signature, nil
+	}
+
+	return nil, nil
+}
+
+// Refer https://gitlab.com/cryptsetup/cryptsetup/-/wikis/Specification
+func probeLUKS(r io.ReaderAt, size int64) (*Signature, error) {
+	buf, err := readAt(r, size, 0, 208)
+	if buf == nil || err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(buf[0:6], []byte("LUKS\xba\xbe")) {
+		return nil, nil
+	}
+
+	signature := &Signature{
+		Type:  TypeLUKS,
+		Usage: UsageCrypto,
+		UUID:  toLabel(buf[168:208]),
+	}
+	if binary.BigEndian.Uint16(buf[6:8]) == 2 {
+		signature.Label = toLabel(buf[24:72])
+	}
+	return signature, nil
+}
+
+const (
+	zfsLabelSize         = 256 * 1024
+	zfsUberblockOffset   = 128 * 1024
+	zfsUberblockSize     = 1024
+	zfsUberblockMagic    = 0x00bab10c
+	zfsMinUberblockCount = 4
+)
+
+// Refer https://github.com/openzfs/zfs/blob/master/include/sys/vdev_impl.h
+func probeZFS(r io.ReaderAt, size int64) (*Signature, error) {
+	// Each vdev has two labels at start and two labels at end of the device.
+	end := size &^ (zfsLabelSize - 1)
+	for _, offset := range []int64{0, zfsLabelSize, end - 2*zfsLabelSize, end - zfsLabelSize} {
+		buf, err := readAt(r, size, offset+zfsUberblockOffset, zfsLabelSize-zfsUberblockOffset)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			continue
+		}
+
+		count := 0
+		for i := 0; i < len(buf); i += zfsUberblockSize {
+			if binary.LittleEndian.Uint64(buf[i:]) == zfsUberblockMagic || binary.BigEndian.Uint64(buf[i:]) == zfsUberblockMagic {
+				count++
+			}
+		}
+
+		if count >= zfsMinUberblockCount {
+			return &Signature{Type: TypeZFS, Usage: UsageFilesystem}, nil
+		}
+	}
+
+	return nil, nil
+}
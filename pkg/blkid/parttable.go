@@ -0,0 +1,120 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package blkid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	mbrPartitionTableOffset = 446
+	mbrPartitionEntrySize   = 16
+	mbrProtectiveType       = 0xee
+)
+
+// readMBR returns partition entries of a valid MBR.
+func readMBR(r io.ReaderAt, size int64) (buf []byte, entries [][]byte, err error) {
+	if buf, err = readAt(r, size, 0, sectorSize); buf == nil || err != nil {
+		return nil, nil, err
+	}
+
+	if buf[510] != 0x55 || buf[511] != 0xaa {
+		return nil, nil, nil
+	}
+
+	for i := 0; i < 4; i++ {
+		offset := mbrPartitionTableOffset + i*mbrPartitionEntrySize
+		entry := buf[offset : offset+mbrPartitionEntrySize]
+		if entry[0] != 0x00 && entry[0] != 0x80 {
+			// Invalid boot indicator; this is not an MBR.
+			return nil, nil, nil
+		}
+		entries = append(entries, entry)
+	}
+
+	return buf, entries, nil
+}
+
+// Refer https://uefi.org/specs/UEFI/2.10/05_GUID_Partition_Table_Format.html
+func probeGPT(r io.ReaderAt, size int64) (*Signature, error) {
+	_, entries, err := readMBR(r, size)
+	if entries == nil || err != nil {
+		return nil, err
+	}
+
+	protective := false
+	for _, entry := range entries {
+		if entry[4] == mbrProtectiveType {
+			protective = true
+			break
+		}
+	}
+	if !protective {
+		return nil, nil
+	}
+
+	// GPT header is at LBA 1 of 512 or 4096 bytes logical block size.
+	for _, offset := range []int64{sectorSize, 4096} {
+		buf, err := readAt(r, size, offset, 92)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			return nil, nil
+		}
+
+		if bytes.Equal(buf[0:8], []byte("EFI PART")) {
+			return &Signature{
+				Type:  TypeGPT,
+				Usage: UsagePartitionTable,
+				UUID:  toGUID(buf[56:72]),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func probeDOS(r io.ReaderAt, size int64) (*Signature, error) {
+	buf, entries, err := readMBR(r, size)
+	if entries == nil || err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, entry := range entries {
+		switch entry[4] {
+		case 0x00:
+		case mbrProtectiveType:
+			return nil, nil
+		default:
+			found = true
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	signature := &Signature{Type: TypeDOS, Usage: UsagePartitionTable}
+	if id := binary.LittleEndian.Uint32(buf[440:444]); id != 0 {
+		signature.UUID = fmt.Sprintf("%08x", id)
+	}
+	return signature, nil
+}
@@ -56,6 +56,16 @@ func InitRequestClient() types.LatestInitRequestInterface {
 	return client.InitRequest()
 }
 
+// QuotaClient gets latest versioned quota interface of the namespace.
+func QuotaClient(namespace string) types.LatestQuotaInterface {
+	return client.Quota(namespace)
+}
+
+// SnapshotClient gets latest versioned snapshot interface.
+func SnapshotClient() types.LatestSnapshotInterface {
+	return client.Snapshot()
+}
+
 // NewDriveLister returns the new drive lister
 func NewDriveLister() *DriveLister {
 	return client.NewDriveLister()
@@ -46,13 +46,29 @@ const (
 	EventReasonVolumeAdded             EventReason = "VolumeAdded"
 	EventReasonVolumeExpanded          EventReason = "VolumeExpanded"
 	EventReasonVolumeReleased          EventReason = "VolumeReleased"
+	EventReasonVolumeWiped             EventReason = "VolumeWiped"
+	EventReasonVolumeRemounted         EventReason = "VolumeRemounted"
+	EventReasonVolumeWipeError         EventReason = "VolumeWipeError"
+	EventReasonVolumeShrunk            EventReason = "VolumeShrunk"
+	EventReasonVolumeShrinkError       EventReason = "VolumeShrinkError"
+	EventReasonSnapshotCreated         EventReason = "SnapshotCreated"
+	EventReasonSnapshotError           EventReason = "SnapshotError"
 	EventReasonDriveMountError         EventReason = "DriveHasMountError"
 	EventReasonDriveMounted            EventReason = "DriveMounted"
+	EventReasonDriveAdopted            EventReason = "DriveAdopted"
 	EventReasonDriveHasMultipleMatches EventReason = "DriveHasMultipleMatches"
 	EventReasonDriveIOError            EventReason = "DriveHasIOError"
 	EventReasonDriveRelabelError       EventReason = "DriveHasRelabelError"
 	EventReasonInitError               EventReason = "InitError"
 	EventReasonDeviceNotFoundError     EventReason = "DeviceNotFoundError"
+	EventReasonDeviceAdded             EventReason = "DeviceAdded"
+	EventReasonDeviceRemoved           EventReason = "DeviceRemoved"
+	EventReasonDriveLost               EventReason = "DriveLost"
+	EventReasonDriveGrowAvailable      EventReason = "DriveGrowAvailable"
+	EventReasonDriveGrown              EventReason = "DriveGrown"
+	EventReasonDriveGrowError          EventReason = "DriveGrowError"
+	EventReasonDriveCordoned           EventReason = "DriveCordoned"
+	EventReasonDriveUncordoned         EventReason = "DriveUncordoned"
 )
 
 var (
@@ -68,3 +68,9 @@ func SetNodeInterface(i types.LatestNodeInterface) {
 func SetInitRequestInterface(i types.LatestInitRequestInterface) {
 	client.InitRequestClient = i
 }
+
+// SetClientsetInterface sets the extended clientset interface.
+// Note: To be used for writing test cases only
+func SetClientsetInterface(i types.ExtClientsetInterface) {
+	client.ClientsetInterface = i
+}
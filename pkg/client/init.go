@@ -87,6 +87,16 @@ func (c Client) InitRequest() types.LatestInitRequestInterface {
 	return c.InitRequestClient
 }
 
+// Quota returns the DirectPV Quota interface of the namespace
+func (c Client) Quota(namespace string) types.LatestQuotaInterface {
+	return c.ClientsetInterface.DirectpvLatest().DirectPVQuotas(namespace)
+}
+
+// Snapshot returns the DirectPV Snapshot interface
+func (c Client) Snapshot() types.LatestSnapshotInterface {
+	return c.ClientsetInterface.DirectpvLatest().DirectPVSnapshots()
+}
+
 // K8s returns the kubernetes client
 func (c Client) K8s() *k8s.Client {
 	return c.K8sClient
@@ -40,6 +40,8 @@ type VolumeLister struct {
 	driveIDs       []directpvtypes.LabelValue
 	podNames       []directpvtypes.LabelValue
 	podNSs         []directpvtypes.LabelValue
+	pvcNames       []directpvtypes.LabelValue
+	pvcNSs         []directpvtypes.LabelValue
 	statusList     []directpvtypes.VolumeStatus
 	volumeNames    []string
 	labels         map[directpvtypes.LabelKey]directpvtypes.LabelValue
@@ -86,6 +88,18 @@ func (lister *VolumeLister) PodNSSelector(podNSs []directpvtypes.LabelValue) *Vo
 	return lister
 }
 
+// PVCNameSelector adds filter listing by persistent volume claim names.
+func (lister *VolumeLister) PVCNameSelector(pvcNames []directpvtypes.LabelValue) *VolumeLister {
+	lister.pvcNames = pvcNames
+	return lister
+}
+
+// PVCNamespaceSelector adds filter listing by persistent volume claim namespaces.
+func (lister *VolumeLister) PVCNamespaceSelector(pvcNSs []directpvtypes.LabelValue) *VolumeLister {
+	lister.pvcNSs = pvcNSs
+	return lister
+}
+
 // StatusSelector adds filter listing by volume status.
 func (lister *VolumeLister) StatusSelector(statusList []directpvtypes.VolumeStatus) *VolumeLister {
 	lister.statusList = statusList
@@ -123,16 +137,20 @@ func (lister *VolumeLister) List(ctx context.Context) <-chan ListVolumeResult {
 		len(lister.driveIDs) == 0 &&
 		len(lister.podNames) == 0 &&
 		len(lister.podNSs) == 0 &&
+		len(lister.pvcNames) == 0 &&
+		len(lister.pvcNSs) == 0 &&
 		len(lister.statusList) == 0 &&
 		len(lister.labels) == 0 &&
 		len(lister.volumeNames) != 0
 
 	labelMap := map[directpvtypes.LabelKey][]directpvtypes.LabelValue{
-		directpvtypes.NodeLabelKey:      lister.nodes,
-		directpvtypes.DriveNameLabelKey: lister.driveNames,
-		directpvtypes.DriveLabelKey:     lister.driveIDs,
-		directpvtypes.PodNameLabelKey:   lister.podNames,
-		directpvtypes.PodNSLabelKey:     lister.podNSs,
+		directpvtypes.NodeLabelKey:         lister.nodes,
+		directpvtypes.DriveNameLabelKey:    lister.driveNames,
+		directpvtypes.DriveLabelKey:        lister.driveIDs,
+		directpvtypes.PodNameLabelKey:      lister.podNames,
+		directpvtypes.PodNSLabelKey:        lister.podNSs,
+		directpvtypes.PVCNameLabelKey:      lister.pvcNames,
+		directpvtypes.PVCNamespaceLabelKey: lister.pvcNSs,
 	}
 	for k, v := range lister.labels {
 		labelMap[k] = []directpvtypes.LabelValue{v}
@@ -31,6 +31,8 @@ type DirectpvV1beta1Interface interface {
 	DirectPVDrivesGetter
 	DirectPVInitRequestsGetter
 	DirectPVNodesGetter
+	DirectPVQuotasGetter
+	DirectPVSnapshotsGetter
 	DirectPVVolumesGetter
 }
 
@@ -51,6 +53,14 @@ func (c *DirectpvV1beta1Client) DirectPVNodes() DirectPVNodeInterface {
 	return newDirectPVNodes(c)
 }
 
+func (c *DirectpvV1beta1Client) DirectPVQuotas(namespace string) DirectPVQuotaInterface {
+	return newDirectPVQuotas(c, namespace)
+}
+
+func (c *DirectpvV1beta1Client) DirectPVSnapshots() DirectPVSnapshotInterface {
+	return newDirectPVSnapshots(c)
+}
+
 func (c *DirectpvV1beta1Client) DirectPVVolumes() DirectPVVolumeInterface {
 	return newDirectPVVolumes(c)
 }
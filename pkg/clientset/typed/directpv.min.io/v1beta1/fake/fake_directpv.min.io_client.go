@@ -40,6 +40,14 @@ func (c *FakeDirectpvV1beta1) DirectPVNodes() v1beta1.DirectPVNodeInterface {
 	return newFakeDirectPVNodes(c)
 }
 
+func (c *FakeDirectpvV1beta1) DirectPVQuotas(namespace string) v1beta1.DirectPVQuotaInterface {
+	return newFakeDirectPVQuotas(c, namespace)
+}
+
+func (c *FakeDirectpvV1beta1) DirectPVSnapshots() v1beta1.DirectPVSnapshotInterface {
+	return newFakeDirectPVSnapshots(c)
+}
+
 func (c *FakeDirectpvV1beta1) DirectPVVolumes() v1beta1.DirectPVVolumeInterface {
 	return newFakeDirectPVVolumes(c)
 }
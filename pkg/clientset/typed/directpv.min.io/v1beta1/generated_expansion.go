@@ -24,4 +24,8 @@ type DirectPVInitRequestExpansion interface{}
 
 type DirectPVNodeExpansion interface{}
 
+type DirectPVQuotaExpansion interface{}
+
+type DirectPVSnapshotExpansion interface{}
+
 type DirectPVVolumeExpansion interface{}
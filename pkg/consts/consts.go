@@ -58,6 +58,12 @@ const (
 	// InitRequestKind denotes the InitRequest CRD kind.
 	InitRequestKind = AppPrettyName + "InitRequest"
 
+	// QuotaKind is quota CRD kind.
+	QuotaKind = AppPrettyName + "Quota"
+
+	// SnapshotKind is snapshot CRD kind.
+	SnapshotKind = AppPrettyName + "Snapshot"
+
 	// DriveResource is drive CRD resource.
 	DriveResource = AppName + "drives"
 
@@ -70,6 +76,12 @@ const (
 	// InitRequestResource is initrequest CRD resource.
 	InitRequestResource = AppName + "initrequests"
 
+	// QuotaResource is quota CRD resource.
+	QuotaResource = AppName + "quotas"
+
+	// SnapshotResource is snapshot CRD resource.
+	SnapshotResource = AppName + "snapshots"
+
 	// AppRootDir is application root directory.
 	AppRootDir = "/var/lib/" + AppName
 
@@ -85,9 +97,21 @@ const (
 	// ReadinessPath is default readiness path.
 	ReadinessPath = "/ready"
 
+	// AdmissionWebhookPort is default admission webhook port.
+	AdmissionWebhookPort = 20443
+
+	// AdmissionWebhookPath is admission webhook validation path.
+	AdmissionWebhookPath = "/validate"
+
+	// AdmissionWebhookCertDir is admission webhook TLS certificate directory.
+	AdmissionWebhookCertDir = "/etc/" + AppName + "/webhook"
+
 	// MountRootDir is mount root directory.
 	MountRootDir = AppRootDir + "/mnt"
 
+	// VirtualDriveDir is the directory of backing files of virtual drives.
+	VirtualDriveDir = AppRootDir + "/virtual"
+
 	NodeServerName       = "node-server"
 	ControllerServerName = "controller"
 	NodeControllerName   = "node-controller"
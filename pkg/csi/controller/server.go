@@ -18,23 +18,61 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"sync"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/dustin/go-humanize"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/quota"
 	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/xfs"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
 var volumeClaimIDRegex = regexp.MustCompile("^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}$")
 
+// PVC metadata passed by csi-provisioner with --extra-create-metadata.
+const (
+	pvcNameParameter      = "csi.storage.k8s.io/pvc/name"
+	pvcNamespaceParameter = "csi.storage.k8s.io/pvc/namespace"
+)
+
+// mountOptionsParameter is storage class parameter of comma separated mount options of volumes.
+const mountOptionsParameter = consts.GroupName + "/mount-options"
+
+// maxExpansionCandidates is the maximum number of candidate drives suggested on failed volume expansion.
+const maxExpansionCandidates = 5
+
+// quotaMutex serializes quota check and allocation of volumes.
+var quotaMutex sync.Mutex
+
+func checkQuota(ctx context.Context, request quota.Request) error {
+	if err := quota.Check(ctx, request); err != nil {
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return status.Errorf(codes.Internal, "unable to check quota for volume %v; %v", request.VolumeName, err)
+	}
+	return nil
+}
+
+func updateQuotaStatus(ctx context.Context, namespace string) {
+	if err := quota.UpdateStatus(ctx, namespace); err != nil {
+		klog.ErrorS(err, "unable to update quota status", "namespace", namespace)
+	}
+}
+
 /*  Volume Lifecycle
  *
  *  Creation
@@ -99,6 +137,11 @@ func (c *Server) ControllerGetCapabilities(_ context.Context, _ *csi.ControllerG
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
 		},
 	}, nil
 }
@@ -146,11 +189,17 @@ func (c *Server) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 		}
 	}
 
-	if len(req.GetVolumeCapabilities()) > 0 && req.GetVolumeCapabilities()[0].GetMount().GetFsType() != "xfs" {
+	if len(req.GetVolumeCapabilities()) > 0 && !fs.Supported(req.GetVolumeCapabilities()[0].GetMount().GetFsType()) {
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported filesystem type %v for volume %v", req.GetVolumeCapabilities()[0].GetMount().GetFsType(), name)
 	}
 
-	var volumeClaimID string
+	var volumeClaimID, pvcName, pvcNamespace string
+	var wipeMode directpvtypes.WipeMode
+	var backend directpvtypes.Backend
+	var mountOptions []string
+	for _, vcap := range req.GetVolumeCapabilities() {
+		mountOptions = append(mountOptions, vcap.GetMount().GetMountFlags()...)
+	}
 	for key, value := range req.GetParameters() {
 		switch key {
 		case string(directpvtypes.AccessTierLabelKey):
@@ -162,6 +211,52 @@ func (c *Server) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 				return nil, status.Errorf(codes.InvalidArgument, "invalid volume claim ID %v; ", value)
 			}
 			volumeClaimID = value
+		case string(directpvtypes.WipeModeLabelKey):
+			mode, err := directpvtypes.ToWipeMode(value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid wipe mode %v for volume %v; %v", value, name, err)
+			}
+			wipeMode = mode
+		case string(directpvtypes.BackendLabelKey):
+			var err error
+			if backend, err = directpvtypes.ToBackend(value); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid backend %v for volume %v; %v", value, name, err)
+			}
+		case mountOptionsParameter:
+			mountOptions = append(mountOptions, strings.Split(value, ",")...)
+		case pvcNameParameter:
+			pvcName = value
+		case pvcNamespaceParameter:
+			pvcNamespace = value
+		}
+	}
+
+	flags, fsOptions, err := xfs.ParseMountOptions(mountOptions)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid mount options for volume %v; %v", name, err)
+	}
+	// Filesystem mount options are applied per volume only to thin volumes having own filesystem;
+	// other volumes share the filesystem of the drive.
+	if len(fsOptions) != 0 && backend != directpvtypes.BackendLVMThin {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"filesystem mount options %v for volume %v are supported by %v backend only",
+			fsOptions, name, directpvtypes.BackendLVMThin,
+		)
+	}
+
+	snapshot, err := getSourceSnapshot(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		switch {
+		case backend != directpvtypes.BackendLVMThin:
+			return nil, status.Errorf(codes.InvalidArgument, "volume %v can be restored from snapshot by %v backend only", name, directpvtypes.BackendLVMThin)
+		case !snapshot.Status.ReadyToUse:
+			return nil, status.Errorf(codes.Unavailable, "snapshot %v is not ready to use", snapshot.Name)
+		case requiredBytes >= 0 && requiredBytes < snapshot.Status.Size:
+			return nil, status.Errorf(codes.OutOfRange, "requested size %v is less than size %v of snapshot %v", requiredBytes, snapshot.Status.Size, snapshot.Name)
 		}
 	}
 
@@ -181,6 +276,23 @@ func (c *Server) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 		size = req.GetCapacityRange().GetRequiredBytes()
 	}
 
+	if pvcNamespace != "" {
+		quotaMutex.Lock()
+		defer quotaMutex.Unlock()
+
+		err = checkQuota(ctx, quota.Request{
+			Namespace:  pvcNamespace,
+			VolumeName: name,
+			Capacity:   size,
+			AccessTier: drive.GetAccessTier(),
+			NodeID:     drive.GetNodeID(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer updateQuotaStatus(ctx, pvcNamespace)
+	}
+
 	newVolume := types.NewVolume(
 		name,
 		drive.Status.FSUUID,
@@ -190,9 +302,17 @@ func (c *Server) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 		size,
 	)
 	newVolume.SetClaimID(volumeClaimID)
+	newVolume.SetWipeMode(wipeMode)
+	newVolume.SetBackend(backend)
+	newVolume.Status.MountOptions = append(flags, fsOptions...)
+	newVolume.SetPVCName(pvcName)
+	newVolume.SetPVCNamespace(pvcNamespace)
+	if snapshot != nil {
+		newVolume.SetSnapshot(snapshot.Name)
+	}
 
 	if _, err := client.VolumeClient().Create(ctx, newVolume, metav1.CreateOptions{}); err != nil {
-		if !errors.IsAlreadyExists(err) {
+		if !apierrors.IsAlreadyExists(err) {
 			return nil, status.Errorf(codes.Internal, "unable to create volume %v; %v", name, err)
 		}
 
@@ -266,7 +386,7 @@ func (c *Server) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest)
 		TypeMeta: types.NewVolumeTypeMeta(),
 	})
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			return &csi.DeleteVolumeResponse{}, nil
 		}
 		return nil, status.Errorf(codes.Internal, "unable to get volume %v; %v", volumeID, err)
@@ -309,7 +429,7 @@ func (c *Server) ControllerExpandVolume(ctx context.Context, req *csi.Controller
 	})
 	if err != nil {
 		code := codes.Internal
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			code = codes.NotFound
 		}
 		return nil, status.Errorf(code, "unable to get volume %v; %v", volumeID, err)
@@ -330,12 +450,40 @@ func (c *Server) ControllerExpandVolume(ctx context.Context, req *csi.Controller
 			volume.GetDriveID(), volumeID, err,
 		)
 	}
+	if pvcNamespace := volume.GetPVCNamespace(); pvcNamespace != "" {
+		quotaMutex.Lock()
+		defer quotaMutex.Unlock()
+
+		err = checkQuota(ctx, quota.Request{
+			Namespace:  pvcNamespace,
+			VolumeName: volumeID,
+			Capacity:   requiredBytes,
+			AccessTier: drive.GetAccessTier(),
+			NodeID:     drive.GetNodeID(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer updateQuotaStatus(ctx, pvcNamespace)
+	}
+
 	size := requiredBytes - volume.Status.TotalCapacity
 	if size > drive.Status.FreeCapacity {
+		candidates, err := getExpansionCandidates(ctx, drive, requiredBytes)
+		if err != nil {
+			klog.ErrorS(err, "unable to find candidate drives for volume expansion", "volume", volumeID)
+		}
+		suggestion := "no other drive has enough free capacity"
+		if len(candidates) != 0 {
+			if len(candidates) > maxExpansionCandidates {
+				candidates = append(candidates[:maxExpansionCandidates], fmt.Sprintf("and %v more", len(candidates)-maxExpansionCandidates))
+			}
+			suggestion = "move the volume to one of candidate drives " + strings.Join(candidates, ", ")
+		}
 		return nil, status.Errorf(
-			codes.OutOfRange,
-			"required bytes %v is greater than free capacity of drive %v for volume %v expansion",
-			requiredBytes, volume.GetDriveID(), volumeID,
+			codes.FailedPrecondition,
+			"additional %v bytes required for volume %v expansion exceeds free capacity %v bytes of drive %v; %v",
+			size, volumeID, drive.Status.FreeCapacity, volume.GetDriveID(), suggestion,
 		)
 	}
 	drive.Status.FreeCapacity -= size
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -27,6 +28,9 @@ import (
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -273,6 +277,11 @@ func TestControllerGetCapabilities(t *testing.T) {
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
 		},
 	}
 	if !reflect.DeepEqual(result, expectedResult) {
@@ -392,3 +401,257 @@ func TestControllerExpandVolume(t *testing.T) {
 		}
 	}
 }
+
+func TestControllerExpandShrunkVolume(t *testing.T) {
+	driveID := directpvtypes.DriveID(uuid.NewString())
+	drive := types.NewDrive(
+		driveID,
+		types.DriveStatus{
+			TotalCapacity:     100 * MiB,
+			FreeCapacity:      90 * MiB,
+			AllocatedCapacity: 10 * MiB,
+			FSUUID:            string(driveID),
+			Status:            directpvtypes.DriveStatusReady,
+			Topology:          map[string]string{},
+		},
+		"node-1",
+		"sda",
+		directpvtypes.AccessTierDefault,
+	)
+	// Volume of 40MiB is shrunk to 10MiB; its PV and PVC still have 40MiB.
+	volume := types.NewVolume("volume-1", uuid.NewString(), "node-1", driveID, "sda", 10*MiB)
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume, drive))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	ctx := t.Context()
+	req := &csi.ControllerExpandVolumeRequest{
+		VolumeId:      "volume-1",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: 30 * MiB},
+	}
+	resp, err := NewServer().ControllerExpandVolume(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.CapacityBytes != 30*MiB || !resp.NodeExpansionRequired {
+		t.Fatalf("unexpected response %v", resp)
+	}
+
+	drive, err = client.DriveClient().Get(ctx, string(driveID), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.FreeCapacity != 70*MiB {
+		t.Fatalf("drive free capacity: expected: %v, got: %v", 70*MiB, drive.Status.FreeCapacity)
+	}
+}
+
+func TestControllerExpandVolumeCandidates(t *testing.T) {
+	newDrive := func(driveName directpvtypes.DriveName, freeCapacity int64, accessTier directpvtypes.AccessTier) *types.Drive {
+		driveID := directpvtypes.DriveID(uuid.NewString())
+		return types.NewDrive(
+			driveID,
+			types.DriveStatus{
+				TotalCapacity: 100 * MiB,
+				FreeCapacity:  freeCapacity,
+				FSUUID:        string(driveID),
+				Status:        directpvtypes.DriveStatusReady,
+				Topology:      map[string]string{},
+			},
+			"node-1",
+			driveName,
+			accessTier,
+		)
+	}
+
+	drive := newDrive("sda", 5*MiB, directpvtypes.AccessTierDefault)
+	volume := types.NewVolume("volume-1", uuid.NewString(), "node-1", drive.GetDriveID(), "sda", 90*MiB)
+	objects := []runtime.Object{
+		drive,
+		volume,
+		newDrive("sdb", 100*MiB, directpvtypes.AccessTierDefault),
+		newDrive("sdc", 50*MiB, directpvtypes.AccessTierDefault),
+		newDrive("sdd", 100*MiB, directpvtypes.AccessTierHot),
+	}
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(objects...))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	req := &csi.ControllerExpandVolumeRequest{
+		VolumeId:      "volume-1",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: 100*MiB + 1},
+	}
+	_, err := NewServer().ControllerExpandVolume(t.Context(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected: FailedPrecondition; got: %v", err)
+	}
+	if msg := status.Convert(err).Message(); !strings.HasSuffix(msg, "no other drive has enough free capacity") {
+		t.Fatalf("unexpected error message %v", msg)
+	}
+
+	req.CapacityRange.RequiredBytes = 100 * MiB
+	_, err = NewServer().ControllerExpandVolume(t.Context(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected: FailedPrecondition; got: %v", err)
+	}
+	if msg := status.Convert(err).Message(); !strings.HasSuffix(msg, "candidate drives node-1/sdb") {
+		t.Fatalf("unexpected error message %v", msg)
+	}
+}
+
+func TestCreateVolumeQuota(t *testing.T) {
+	newDrive := func(driveID directpvtypes.DriveID, nodeID directpvtypes.NodeID) *types.Drive {
+		return types.NewDrive(
+			driveID,
+			types.DriveStatus{
+				TotalCapacity: 100 * MiB,
+				FreeCapacity:  100 * MiB,
+				FSUUID:        string(driveID),
+				Status:        directpvtypes.DriveStatusReady,
+				Topology:      map[string]string{"node": string(nodeID)},
+			},
+			nodeID,
+			directpvtypes.DriveName("sda"),
+			directpvtypes.AccessTierDefault,
+		)
+	}
+
+	newRequest := func(name, namespace string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 20 * MiB},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			Parameters: map[string]string{
+				pvcNameParameter:      "data-" + name,
+				pvcNamespaceParameter: namespace,
+			},
+		}
+	}
+
+	capacityLimit := resource.MustParse("50Mi")
+	quota := types.NewQuota("quota", "tenant-1", types.QuotaSpec{
+		Capacity: &capacityLimit,
+		Nodes:    map[string]resource.Quantity{"node-2": resource.MustParse("10Mi")},
+	})
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(newDrive("drive-1", "node-1"), quota))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+	client.SetClientsetInterface(clientset)
+
+	ctx := t.Context()
+	server := NewServer()
+
+	for _, name := range []string{"volume-1", "volume-2"} {
+		if _, err := server.CreateVolume(ctx, newRequest(name, "tenant-1")); err != nil {
+			t.Fatalf("volume %v: unexpected error: %v", name, err)
+		}
+	}
+
+	// Retried request of existing volume must not be counted twice.
+	if _, err := server.CreateVolume(ctx, newRequest("volume-2", "tenant-1")); err != nil {
+		t.Fatalf("volume-2: unexpected error on retry: %v", err)
+	}
+
+	_, err := server.CreateVolume(ctx, newRequest("volume-3", "tenant-1"))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("volume-3: expected: %v, got: %v", codes.ResourceExhausted, err)
+	}
+
+	// Namespaces without quota are not limited.
+	if _, err := server.CreateVolume(ctx, newRequest("volume-4", "tenant-2")); err != nil {
+		t.Fatalf("volume-4: unexpected error: %v", err)
+	}
+
+	result, err := clientset.DirectpvLatest().DirectPVQuotas("tenant-1").Get(ctx, "quota", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status.Volumes != 2 || result.Status.Capacity.Value() != 40*MiB {
+		t.Fatalf("unexpected quota status; %+v", result.Status)
+	}
+
+	volume, err := client.VolumeClient().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if volume.GetPVCName() != "data-volume-1" || volume.GetPVCNamespace() != "tenant-1" {
+		t.Fatalf("unexpected PVC labels; %v", volume.GetLabels())
+	}
+
+	// PVC names longer than label value limit are recorded in full.
+	longName := "data-" + strings.Repeat("x", 70) + ".tenant"
+	req := newRequest("volume-5", "tenant-2")
+	req.Parameters[pvcNameParameter] = longName
+	if _, err := server.CreateVolume(ctx, req); err != nil {
+		t.Fatalf("volume-5: unexpected error: %v", err)
+	}
+	if volume, err = client.VolumeClient().Get(ctx, "volume-5", metav1.GetOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if volume.GetPVCName() != longName {
+		t.Fatalf("expected: %v, got: %v", longName, volume.GetPVCName())
+	}
+	if value := volume.GetLabels()[string(directpvtypes.PVCNameLabelKey)]; len(value) > 63 {
+		t.Fatalf("label value %v exceeds 63 characters", value)
+	}
+}
+
+func TestCreateVolumeMountOptions(t *testing.T) {
+	drive := types.NewDrive(
+		"drive-1",
+		types.DriveStatus{
+			TotalCapacity: 100 * MiB,
+			FreeCapacity:  100 * MiB,
+			FSUUID:        "drive-1",
+			Status:        directpvtypes.DriveStatusReady,
+		},
+		"node-1",
+		directpvtypes.DriveName("sda"),
+		directpvtypes.AccessTierDefault,
+	)
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+	client.SetClientsetInterface(clientset)
+
+	newRequest := func(name, mountOptions string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 10 * MiB},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			Parameters: map[string]string{mountOptionsParameter: mountOptions},
+		}
+	}
+
+	ctx := t.Context()
+	server := NewServer()
+
+	// Filesystem mount options are not applicable to volumes sharing the drive filesystem.
+	_, err := server.CreateVolume(ctx, newRequest("volume-1", "noatime,logbsize=256k"))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected: %v, got: %v", codes.InvalidArgument, err)
+	}
+
+	if _, err = server.CreateVolume(ctx, newRequest("volume-2", "noatime,nodev")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	volume, err := client.VolumeClient().Get(ctx, "volume-2", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(volume.Status.MountOptions, []string{"noatime", "nodev"}) {
+		t.Fatalf("unexpected mount options %v", volume.Status.MountOptions)
+	}
+}
@@ -0,0 +1,106 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+func toCSISnapshot(snapshot *types.Snapshot) *csi.Snapshot {
+	creationTime := snapshot.CreationTimestamp
+	if snapshot.Status.CreationTime != nil {
+		creationTime = *snapshot.Status.CreationTime
+	}
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.Status.Size,
+		SnapshotId:     snapshot.Name,
+		SourceVolumeId: snapshot.GetVolumeName(),
+		CreationTime:   timestamppb.New(creationTime.Time),
+		ReadyToUse:     snapshot.Status.ReadyToUse,
+	}
+}
+
+// CreateSnapshot creates a snapshot of thin volume; the node server of the volume takes the
+// snapshot in the thin pool of the volume.
+// reference: https://github.com/container-storage-interface/spec/blob/master/spec.md#createsnapshot
+func (c *Server) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
+	klog.V(3).InfoS("Create snapshot requested", "name", req.GetName(), "volume", req.GetSourceVolumeId())
+
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty snapshot name in the request")
+	}
+	volumeID := req.GetSourceVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty source volume ID in the request")
+	}
+
+	volume, err := client.VolumeClient().Get(ctx, volumeID, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+	if err != nil {
+		code := codes.Internal
+		if apierrors.IsNotFound(err) {
+			code = codes.NotFound
+		}
+		return nil, status.Errorf(code, "unable to get volume %v; %v", volumeID, err)
+	}
+	if volume.GetBackend() != directpvtypes.BackendLVMThin {
+		return nil, status.Errorf(codes.InvalidArgument, "snapshot of volume %v is supported by %v backend only", volumeID, directpvtypes.BackendLVMThin)
+	}
+
+	snapshot, err := client.SnapshotClient().Create(ctx, types.NewSnapshot(name, volume), metav1.CreateOptions{})
+	if err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, status.Errorf(codes.Internal, "unable to create snapshot %v; %v", name, err)
+		}
+		if snapshot, err = client.SnapshotClient().Get(ctx, name, metav1.GetOptions{TypeMeta: types.NewSnapshotTypeMeta()}); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to get snapshot %v; %v", name, err)
+		}
+		if snapshot.GetVolumeName() != volumeID {
+			return nil, status.Errorf(codes.AlreadyExists, "snapshot %v already exists for volume %v", name, snapshot.GetVolumeName())
+		}
+	}
+
+	return &csi.CreateSnapshotResponse{Snapshot: toCSISnapshot(snapshot)}, nil
+}
+
+// DeleteSnapshot deletes the snapshot; the node server removes the snapshot from the thin pool.
+// reference: https://github.com/container-storage-interface/spec/blob/master/spec.md#deletesnapshot
+func (c *Server) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
+	klog.V(3).InfoS("Delete snapshot requested", "name", req.GetSnapshotId())
+
+	snapshotID := req.GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty snapshot ID in the request")
+	}
+
+	err := client.SnapshotClient().Delete(ctx, snapshotID, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "unable to delete snapshot %v; %v", snapshotID, err)
+	}
+	return &csi.DeleteSnapshotResponse{}, nil
+}
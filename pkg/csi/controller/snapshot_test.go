@@ -0,0 +1,190 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newThinDrive(driveID directpvtypes.DriveID, driveName directpvtypes.DriveName) *types.Drive {
+	return types.NewDrive(
+		driveID,
+		types.DriveStatus{
+			TotalCapacity: 100 * MiB,
+			FreeCapacity:  100 * MiB,
+			FSUUID:        string(driveID),
+			Status:        directpvtypes.DriveStatusReady,
+			LVM:           &types.DriveLVM{VGName: "directpv-" + string(driveID), MetaLV: "meta", ThinPool: "pool"},
+		},
+		"node-1",
+		driveName,
+		directpvtypes.AccessTierDefault,
+	)
+}
+
+func setSnapshotTestClients(objects ...runtime.Object) {
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(objects...))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+	client.SetClientsetInterface(clientset)
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	thinVolume := types.NewVolume("volume-1", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	thinVolume.SetBackend(directpvtypes.BackendLVMThin)
+	volume := types.NewVolume("volume-2", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	setSnapshotTestClients(thinVolume, volume)
+
+	ctx := t.Context()
+	server := NewServer()
+
+	testCases := []struct {
+		request      *csi.CreateSnapshotRequest
+		expectedCode codes.Code
+	}{
+		{&csi.CreateSnapshotRequest{SourceVolumeId: "volume-1"}, codes.InvalidArgument},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1"}, codes.InvalidArgument},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-3"}, codes.NotFound},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-2"}, codes.InvalidArgument},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-1"}, codes.OK},
+		// Retried request returns the existing snapshot.
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-1"}, codes.OK},
+	}
+	for i, testCase := range testCases {
+		resp, err := server.CreateSnapshot(ctx, testCase.request)
+		if status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+		if err != nil {
+			continue
+		}
+		snapshot := resp.GetSnapshot()
+		if snapshot.GetSnapshotId() != "snapshot-1" || snapshot.GetSourceVolumeId() != "volume-1" || snapshot.GetSizeBytes() != 10*MiB || snapshot.GetReadyToUse() {
+			t.Fatalf("case %v: unexpected snapshot %v", i+1, snapshot)
+		}
+	}
+
+	snapshot, err := client.SnapshotClient().Get(ctx, "snapshot-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.GetDriveID() != "drive-1" || snapshot.GetNodeID() != "node-1" {
+		t.Fatalf("unexpected drive %v or node %v", snapshot.GetDriveID(), snapshot.GetNodeID())
+	}
+
+	// Same snapshot name for other volume is rejected.
+	thinVolume2 := types.NewVolume("volume-3", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	thinVolume2.SetBackend(directpvtypes.BackendLVMThin)
+	if _, err = client.VolumeClient().Create(ctx, thinVolume2, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = server.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-3"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected: %v, got: %v", codes.AlreadyExists, err)
+	}
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	volume := types.NewVolume("volume-1", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	volume.SetBackend(directpvtypes.BackendLVMThin)
+	setSnapshotTestClients(types.NewSnapshot("snapshot-1", volume))
+
+	ctx := t.Context()
+	server := NewServer()
+
+	if _, err := server.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected: %v, got: %v", codes.InvalidArgument, err)
+	}
+	if _, err := server.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{SnapshotId: "snapshot-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SnapshotClient().Get(ctx, "snapshot-1", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected not found error; got: %v", err)
+	}
+	// Deleting removed snapshot succeeds.
+	if _, err := server.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{SnapshotId: "snapshot-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateVolumeFromSnapshot(t *testing.T) {
+	volume := types.NewVolume("volume-1", "drive-2", "node-1", "drive-2", "sdb", 10*MiB)
+	volume.SetBackend(directpvtypes.BackendLVMThin)
+	snapshot := types.NewSnapshot("snapshot-1", volume)
+	pendingSnapshot := types.NewSnapshot("snapshot-2", volume)
+	snapshot.Status.ReadyToUse = true
+	setSnapshotTestClients(newThinDrive("drive-1", "sda"), newThinDrive("drive-2", "sdb"), volume, snapshot, pendingSnapshot)
+
+	newRequest := func(name, snapshotID string, backend directpvtypes.Backend, size int64) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: size},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			Parameters: map[string]string{string(directpvtypes.BackendLabelKey): string(backend)},
+			VolumeContentSource: &csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Snapshot{
+					Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: snapshotID},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		request      *csi.CreateVolumeRequest
+		expectedCode codes.Code
+	}{
+		{newRequest("volume-2", "snapshot-3", directpvtypes.BackendLVMThin, 10*MiB), codes.NotFound},
+		{newRequest("volume-2", "snapshot-1", directpvtypes.BackendDirectory, 10*MiB), codes.InvalidArgument},
+		{newRequest("volume-2", "snapshot-2", directpvtypes.BackendLVMThin, 10*MiB), codes.Unavailable},
+		{newRequest("volume-2", "snapshot-1", directpvtypes.BackendLVMThin, 5*MiB), codes.OutOfRange},
+		{newRequest("volume-2", "snapshot-1", directpvtypes.BackendLVMThin, 20*MiB), codes.OK},
+	}
+
+	ctx := t.Context()
+	server := NewServer()
+	for i, testCase := range testCases {
+		_, err := server.CreateVolume(ctx, testCase.request)
+		if status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+	}
+
+	// Restored volume is placed in the thin pool of the snapshot.
+	restored, err := client.VolumeClient().Get(ctx, "volume-2", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.GetDriveID() != "drive-2" || restored.GetSnapshot() != "snapshot-1" || restored.Status.TotalCapacity != 20*MiB {
+		t.Fatalf("unexpected drive %v, snapshot %v or capacity %v", restored.GetDriveID(), restored.GetSnapshot(), restored.Status.TotalCapacity)
+	}
+}
@@ -21,36 +21,90 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/dustin/go-humanize"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func matchDrive(drive *types.Drive, req *csi.CreateVolumeRequest) bool {
+// RejectReason denotes why a drive is not selected for a volume.
+type RejectReason string
+
+// Enum values of RejectReason type.
+const (
+	RejectReasonTerminating RejectReason = "terminating"
+	RejectReasonNotReady    RejectReason = "not ready"
+	RejectReasonCordoned    RejectReason = "cordoned"
+	RejectReasonCapacity    RejectReason = "insufficient capacity"
+	RejectReasonFSType      RejectReason = "filesystem type mismatch"
+	RejectReasonBackend     RejectReason = "backend mismatch"
+	RejectReasonAccessTier  RejectReason = "access-tier mismatch"
+	RejectReasonClaimID     RejectReason = "volume claim ID in use"
+	RejectReasonLabel       RejectReason = "label mismatch"
+	RejectReasonTopology    RejectReason = "topology mismatch"
+	RejectReasonSnapshot    RejectReason = "snapshot on other drive"
+)
+
+// Rejection denotes the reason and the details of a drive not selected for a volume.
+type Rejection struct {
+	Reason  RejectReason
+	Message string
+}
+
+func reject(reason RejectReason, format string, args ...any) *Rejection {
+	return &Rejection{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// CheckDrive returns nil if the drive matches the create volume request, else the rejection.
+func CheckDrive(drive *types.Drive, req *csi.CreateVolumeRequest) *Rejection {
 	// Skip terminating drives
 	if !drive.GetDeletionTimestamp().IsZero() {
-		return false
+		return reject(RejectReasonTerminating, "drive is being deleted")
 	}
 
 	// Skip drives if status is not ready
 	if drive.Status.Status != directpvtypes.DriveStatusReady {
-		return false
+		return reject(RejectReasonNotReady, "drive is in %v status", drive.Status.Status)
 	}
 
 	// Skip drives if unschedulable
 	if drive.IsUnschedulable() {
-		return false
+		return reject(RejectReasonCordoned, "drive is cordoned")
 	}
 
 	// Match drive if it has requested capacity.
 	if req.GetCapacityRange() != nil && drive.Status.FreeCapacity < req.GetCapacityRange().GetRequiredBytes() {
-		return false
+		return reject(
+			RejectReasonCapacity,
+			"free capacity %v is less than requested %v",
+			humanize.IBytes(uint64(drive.Status.FreeCapacity)),
+			humanize.IBytes(uint64(req.GetCapacityRange().GetRequiredBytes())),
+		)
+	}
+
+	// Match drive by filesystem type if requested.
+	if len(req.GetVolumeCapabilities()) > 0 {
+		if fsType := req.GetVolumeCapabilities()[0].GetMount().GetFsType(); fsType != "" && drive.GetFSType() != fsType {
+			return reject(RejectReasonFSType, "filesystem type %v does not match requested %v", drive.GetFSType(), fsType)
+		}
+	}
+
+	// Match drive by backend; drives are selected for the default backend if not requested.
+	backend := directpvtypes.BackendDirectory
+	if value, found := req.GetParameters()[string(directpvtypes.BackendLabelKey)]; found {
+		backend, _ = directpvtypes.ToBackend(value)
+	}
+	if drive.GetBackend() != backend {
+		return reject(RejectReasonBackend, "backend %v does not match requested %v", drive.GetBackend(), backend)
 	}
 
 	// Match drive by access-tier if requested.
@@ -64,73 +118,163 @@ func matchDrive(drive *types.Drive, req *csi.CreateVolumeRequest) bool {
 		case string(directpvtypes.AccessTierLabelKey):
 			accessTiers, _ := directpvtypes.StringsToAccessTiers(value)
 			if len(accessTiers) > 0 && drive.GetAccessTier() != accessTiers[0] {
-				return false
+				return reject(RejectReasonAccessTier, "access-tier %v does not match requested %v", drive.GetAccessTier(), accessTiers[0])
 			}
 		case string(directpvtypes.VolumeClaimIDLabelKey):
 			if drive.HasVolumeClaimID(value) {
 				// Do not allocate another volume with this claim id
-				return false
+				return reject(RejectReasonClaimID, "drive has a volume with claim ID %v", value)
 			}
+		case string(directpvtypes.BackendLabelKey):
+			// Matched above.
+		case string(directpvtypes.WipeModeLabelKey), mountOptionsParameter:
+			// Not a drive property; applied to the volume.
 		default:
 			if labels[key] != value {
-				return false
+				return reject(RejectReasonLabel, "label %v=%v does not match requested %v", key, labels[key], value)
 			}
 		}
 	}
 
-	matchTopologies := func(topologies []*csi.Topology) bool {
+	// mismatchTopology returns the first segment of topologies not matching the drive.
+	mismatchTopology := func(topologies []*csi.Topology) string {
 		for _, topology := range topologies {
 			for key, value := range topology.GetSegments() {
 				if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
-					return false
+					return fmt.Sprintf("%v=%v", key, value)
 				}
 			}
 		}
-		return true
+		return ""
+	}
+
+	preferred := req.GetAccessibilityRequirements().GetPreferred()
+	requisite := req.GetAccessibilityRequirements().GetRequisite()
+
+	// Match drive if no topology constraints requested.
+	if len(preferred) == 0 && len(requisite) == 0 {
+		return nil
 	}
 
 	// Match drive by preferred topologies if requested.
-	if len(req.GetAccessibilityRequirements().GetPreferred()) > 0 && matchTopologies(req.GetAccessibilityRequirements().GetPreferred()) {
-		return true
+	var mismatch string
+	if len(preferred) > 0 {
+		if mismatch = mismatchTopology(preferred); mismatch == "" {
+			return nil
+		}
 	}
 
 	// Match drive by requisite topology if requested.
-	if len(req.GetAccessibilityRequirements().GetRequisite()) > 0 && matchTopologies(req.GetAccessibilityRequirements().GetRequisite()) {
-		return true
+	if len(requisite) > 0 {
+		if mismatch = mismatchTopology(requisite); mismatch == "" {
+			return nil
+		}
 	}
 
-	// Match drive if no topology constraints requested.
-	return len(req.GetAccessibilityRequirements().GetPreferred()) == 0 && len(req.GetAccessibilityRequirements().GetRequisite()) == 0
+	return reject(RejectReasonTopology, "drive does not match requested topology %v", mismatch)
+}
+
+// CheckSnapshot returns nil if the volume restored from the snapshot can be created on the drive,
+// else the rejection. Volume is restored in the thin pool having the snapshot.
+func CheckSnapshot(drive *types.Drive, snapshot *types.Snapshot) *Rejection {
+	if snapshot != nil && drive.GetDriveID() != snapshot.GetDriveID() {
+		return reject(RejectReasonSnapshot, "snapshot %v is on drive %v", snapshot.Name, snapshot.GetDriveID())
+	}
+	return nil
+}
+
+func matchDrive(drive *types.Drive, req *csi.CreateVolumeRequest) bool {
+	return CheckDrive(drive, req) == nil
 }
 
-func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest) (drives []types.Drive, err error) {
+func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest) (drives []types.Drive, rejected map[RejectReason]int, err error) {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
 
+	snapshot, err := getSourceSnapshot(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rejected = map[RejectReason]int{}
 	for result := range client.NewDriveLister().List(ctx) {
 		if result.Err != nil {
-			return nil, result.Err
+			return nil, nil, result.Err
 		}
 
 		if result.Drive.VolumeExist(req.GetName()) {
-			return []types.Drive{result.Drive}, nil
+			return []types.Drive{result.Drive}, nil, nil
 		}
 
-		if matchDrive(&result.Drive, req) {
+		if rejection := CheckSnapshot(&result.Drive, snapshot); rejection != nil {
+			rejected[rejection.Reason]++
+			continue
+		}
+
+		if rejection := CheckDrive(&result.Drive, req); rejection != nil {
+			rejected[rejection.Reason]++
+		} else {
 			drives = append(drives, result.Drive)
 		}
 	}
 
-	return drives, nil
+	return drives, rejected, nil
+}
+
+// getSourceSnapshot returns the snapshot requested as the content source of the volume, or nil if
+// no snapshot is requested.
+func getSourceSnapshot(ctx context.Context, req *csi.CreateVolumeRequest) (*types.Snapshot, error) {
+	contentSource := req.GetVolumeContentSource()
+	if contentSource == nil {
+		return nil, nil
+	}
+
+	snapshotID := contentSource.GetSnapshot().GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported content source for volume %v", req.GetName())
+	}
+
+	snapshot, err := client.SnapshotClient().Get(ctx, snapshotID, metav1.GetOptions{TypeMeta: types.NewSnapshotTypeMeta()})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "snapshot %v not found", snapshotID)
+		}
+		return nil, status.Errorf(codes.Internal, "unable to get snapshot %v; %v", snapshotID, err)
+	}
+	return snapshot, nil
+}
+
+// summarizeRejections returns rejected drive counts by reason like "3 cordoned, 1 topology mismatch".
+func summarizeRejections(rejected map[RejectReason]int) string {
+	reasons := make([]RejectReason, 0, len(rejected))
+	for reason := range rejected {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if rejected[reasons[i]] != rejected[reasons[j]] {
+			return rejected[reasons[i]] > rejected[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	var values []string
+	for _, reason := range reasons {
+		values = append(values, fmt.Sprintf("%v %v", rejected[reason], reason))
+	}
+	return strings.Join(values, ", ")
 }
 
 func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest) (*types.Drive, error) {
-	drives, err := getFilteredDrives(ctx, req)
+	drives, rejected, err := getFilteredDrives(ctx, req)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	if len(drives) == 0 {
+		summary := "; no drives available"
+		if len(rejected) != 0 {
+			summary = "; rejected drives: " + summarizeRejections(rejected)
+		}
 		if len(req.GetAccessibilityRequirements().GetPreferred()) != 0 || len(req.GetAccessibilityRequirements().GetRequisite()) != 0 {
 			requestedSize := "nil"
 			if req.GetCapacityRange() != nil {
@@ -140,12 +284,12 @@ func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest) (*types.Driv
 			if requestedNodes = getNodeNamesFromTopology(req.AccessibilityRequirements.GetPreferred()); len(requestedNodes) == 0 {
 				requestedNodes = getNodeNamesFromTopology(req.AccessibilityRequirements.GetRequisite())
 			}
-			return nil, status.Errorf(codes.ResourceExhausted, "no drive found for requested topology; requested node(s): %s; requested size: %s", strings.Join(requestedNodes, ","), requestedSize)
+			return nil, status.Errorf(codes.ResourceExhausted, "no drive found for requested topology; requested node(s): %s; requested size: %s%s", strings.Join(requestedNodes, ","), requestedSize, summary)
 		}
 		if req.GetCapacityRange() != nil {
-			return nil, status.Errorf(codes.OutOfRange, "no drive found for requested size %v", req.GetCapacityRange().GetRequiredBytes())
+			return nil, status.Errorf(codes.OutOfRange, "no drive found for requested size %v%s", req.GetCapacityRange().GetRequiredBytes(), summary)
 		}
-		return nil, status.Error(codes.FailedPrecondition, "no drive found")
+		return nil, status.Error(codes.FailedPrecondition, "no drive found"+summary)
 	}
 
 	maxFreeCapacity := int64(-1)
@@ -172,6 +316,40 @@ func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest) (*types.Driv
 	return &maxFreeCapacityDrives[n.Int64()], nil
 }
 
+// getExpansionCandidates returns node/drive names of drives which can hold the volume of
+// requested size with the same access-tier, backend and filesystem type of its current drive.
+func getExpansionCandidates(ctx context.Context, drive *types.Drive, requiredBytes int64) (candidates []string, err error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	req := &csi.CreateVolumeRequest{
+		CapacityRange: &csi.CapacityRange{RequiredBytes: requiredBytes},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{
+					Mount: &csi.VolumeCapability_MountVolume{FsType: drive.GetFSType()},
+				},
+			},
+		},
+		Parameters: map[string]string{
+			string(directpvtypes.AccessTierLabelKey): string(drive.GetAccessTier()),
+			string(directpvtypes.BackendLabelKey):    string(drive.GetBackend()),
+		},
+	}
+
+	for result := range client.NewDriveLister().List(ctx) {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		if result.Drive.Name != drive.Name && matchDrive(&result.Drive, req) {
+			candidates = append(candidates, fmt.Sprintf("%v/%v", result.Drive.GetNodeID(), result.Drive.GetDriveName()))
+		}
+	}
+
+	return candidates, nil
+}
+
 func getNodeNamesFromTopology(topologies []*csi.Topology) (requestedNodes []string) {
 	for _, topology := range topologies {
 		for key, value := range topology.GetSegments() {
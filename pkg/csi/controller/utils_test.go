@@ -456,6 +456,56 @@ func TestGetFilteredDrives(t *testing.T) {
 		Parameters: map[string]string{consts.GroupName + "/access-type": "hot"},
 	}
 
+	case15Result := []types.Drive{
+		*types.NewDrive(
+			"drive-2",
+			types.DriveStatus{Status: directpvtypes.DriveStatusReady, FSType: "ext4"},
+			"node-1",
+			directpvtypes.DriveName("sdb"),
+			directpvtypes.AccessTierDefault,
+		),
+	}
+	case15Objects := []runtime.Object{
+		types.NewDrive(
+			"drive-1",
+			types.DriveStatus{Status: directpvtypes.DriveStatusReady},
+			"node-1",
+			directpvtypes.DriveName("sda"),
+			directpvtypes.AccessTierDefault,
+		),
+		&case15Result[0],
+	}
+	case15Request := &csi.CreateVolumeRequest{
+		Name:               "volume-1",
+		VolumeCapabilities: []*csi.VolumeCapability{{AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "ext4"}}}},
+	}
+
+	case16Drives := []types.Drive{
+		*types.NewDrive(
+			"drive-1",
+			types.DriveStatus{Status: directpvtypes.DriveStatusReady},
+			"node-1",
+			directpvtypes.DriveName("sda"),
+			directpvtypes.AccessTierDefault,
+		),
+		*types.NewDrive(
+			"drive-2",
+			types.DriveStatus{
+				Status: directpvtypes.DriveStatusReady,
+				LVM:    &types.DriveLVM{VGName: "directpv-drive-2", MetaLV: "meta", ThinPool: "pool"},
+			},
+			"node-1",
+			directpvtypes.DriveName("sdb"),
+			directpvtypes.AccessTierDefault,
+		),
+	}
+	case16Objects := []runtime.Object{&case16Drives[0], &case16Drives[1]}
+	case16Request := &csi.CreateVolumeRequest{
+		Name:       "volume-1",
+		Parameters: map[string]string{string(directpvtypes.BackendLabelKey): "lvm-thin"},
+	}
+	case17Request := &csi.CreateVolumeRequest{Name: "volume-1"}
+
 	testCases := []struct {
 		objects        []runtime.Object
 		request        *csi.CreateVolumeRequest
@@ -475,12 +525,15 @@ func TestGetFilteredDrives(t *testing.T) {
 		{case12Objects, case12Request, case12Result},
 		{case13Objects, case13Request, case13Result},
 		{case14Objects, case14Request, case14Result},
+		{case15Objects, case15Request, case15Result},
+		{case16Objects, case16Request, case16Drives[1:]},
+		{case16Objects, case17Request, case16Drives[:1]},
 	}
 
 	for i, testCase := range testCases {
 		clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(testCase.objects...))
 		client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
-		result, err := getFilteredDrives(t.Context(), testCase.request)
+		result, _, err := getFilteredDrives(t.Context(), testCase.request)
 		if err != nil {
 			t.Fatalf("case %v: unexpected error: %v", i+1, err)
 		}
@@ -609,3 +662,61 @@ func TestGetDrive(t *testing.T) {
 		t.Fatalf("result: expected: %v, got: %v", []string{"drive-2", "drive-3"}, result.Name)
 	}
 }
+
+func TestCheckDrive(t *testing.T) {
+	newDrive := func() *types.Drive {
+		return types.NewDrive(
+			"drive-1",
+			types.DriveStatus{
+				Status:       directpvtypes.DriveStatusReady,
+				FreeCapacity: 2 * GiB,
+				Topology:     map[string]string{"node": "node-1"},
+			},
+			"node-1",
+			"sda",
+			directpvtypes.AccessTierDefault,
+		)
+	}
+
+	lostDrive := newDrive()
+	lostDrive.Status.Status = directpvtypes.DriveStatusLost
+	cordonedDrive := newDrive()
+	cordonedDrive.Unschedulable()
+
+	testCases := []struct {
+		drive          *types.Drive
+		request        *csi.CreateVolumeRequest
+		expectedReason RejectReason
+	}{
+		{newDrive(), &csi.CreateVolumeRequest{CapacityRange: &csi.CapacityRange{RequiredBytes: GiB}}, ""},
+		{lostDrive, &csi.CreateVolumeRequest{}, RejectReasonNotReady},
+		{cordonedDrive, &csi.CreateVolumeRequest{}, RejectReasonCordoned},
+		{newDrive(), &csi.CreateVolumeRequest{CapacityRange: &csi.CapacityRange{RequiredBytes: 3 * GiB}}, RejectReasonCapacity},
+		{newDrive(), &csi.CreateVolumeRequest{Parameters: map[string]string{string(directpvtypes.AccessTierLabelKey): "hot"}}, RejectReasonAccessTier},
+		{newDrive(), &csi.CreateVolumeRequest{Parameters: map[string]string{consts.GroupName + "/tier": "fast"}}, RejectReasonLabel},
+		{
+			newDrive(),
+			&csi.CreateVolumeRequest{
+				AccessibilityRequirements: &csi.TopologyRequirement{
+					Requisite: []*csi.Topology{{Segments: map[string]string{"node": "node-2"}}},
+				},
+			},
+			RejectReasonTopology,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var reason RejectReason
+		if rejection := CheckDrive(testCase.drive, testCase.request); rejection != nil {
+			reason = rejection.Reason
+		}
+		if reason != testCase.expectedReason {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedReason, reason)
+		}
+	}
+
+	summary := summarizeRejections(map[RejectReason]int{RejectReasonTopology: 1, RejectReasonCordoned: 3, RejectReasonCapacity: 1})
+	if expected := "3 cordoned, 1 insufficient capacity, 1 topology mismatch"; summary != expected {
+		t.Fatalf("expected: %v, got: %v", expected, summary)
+	}
+}
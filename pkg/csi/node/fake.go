@@ -20,34 +20,46 @@ import (
 	"context"
 	"errors"
 
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/sys"
-	"github.com/minio/directpv/pkg/xfs"
+	"github.com/minio/directpv/pkg/topology"
 )
 
 const testNodeName = "test-node"
 
 func createFakeServer() *Server {
 	return &Server{
-		nodeID:            testNodeName,
-		identity:          "test-identity",
-		rack:              "test-rack",
-		zone:              "test-zone",
-		region:            "test-region",
+		nodeID: testNodeName,
+		topology: topology.NewResolver(topology.Config{
+			Identity: "test-identity",
+			NodeID:   testNodeName,
+			Rack:     "test-rack",
+			Zone:     "test-zone",
+			Region:   "test-region",
+		}),
 		getMounts:         func() (*sys.MountInfo, error) { return nil, nil },
 		getDeviceByFSUUID: func(_ string) (string, error) { return "", nil },
-		bindMount:         func(_, _ string, _ bool) error { return nil },
+		bindMount:         func(_, _ string, _ bool, _ []string) error { return nil },
 		unmount:           func(_ string) error { return nil },
-		getQuota: func(_ context.Context, _, _ string) (quota *xfs.Quota, err error) {
-			return &xfs.Quota{}, nil
+		getFSType: func(_ context.Context, _ directpvtypes.DriveID) (string, error) {
+			return fs.XFS, nil
 		},
-		setQuota: func(_ context.Context, _, _, _ string, _ xfs.Quota, _ bool) (err error) {
+		getQuota: func(_ context.Context, _, _, _ string) (quota *fs.Quota, err error) {
+			return &fs.Quota{}, nil
+		},
+		setQuota: func(_ context.Context, _, _, _, _ string, _ fs.Quota, _ bool) (err error) {
 			return nil
 		},
+		getFSUsage: func(_ string) (total, used, available uint64, err error) {
+			return 0, 0, 0, nil
+		},
 		mkdir: func(path string) error {
 			if path == "" {
 				return errors.New("path is empty")
 			}
 			return nil
 		},
+		updateJournal: func(_ context.Context, _ directpvtypes.DriveID) error { return nil },
 	}
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/topology"
 )
 
 // LegacyServer denotes legacy node server.
@@ -30,24 +31,31 @@ type LegacyServer struct {
 
 // NewLegacyServer creates legacy node server.
 func NewLegacyServer(nodeID directpvtypes.NodeID, rack, zone, region string) *LegacyServer {
-	return &LegacyServer{Server: newServer("direct-csi-min-io", nodeID, rack, zone, region)}
+	resolver := topology.NewResolver(topology.Config{
+		Identity: "direct-csi-min-io",
+		NodeID:   nodeID,
+		Rack:     rack,
+		Zone:     zone,
+		Region:   region,
+	})
+	return &LegacyServer{Server: newServer(nodeID, resolver)}
 }
 
 // NodeGetInfo gets node information.
 // reference: https://github.com/container-storage-interface/spec/blob/master/spec.md#nodegetinfo
 func (server *LegacyServer) NodeGetInfo(_ context.Context, _ *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
-	topology := &csi.Topology{
-		Segments: map[string]string{
-			"direct.csi.min.io/identity": server.identity,
-			"direct.csi.min.io/rack":     server.rack,
-			"direct.csi.min.io/region":   server.region,
-			"direct.csi.min.io/zone":     server.zone,
-			"direct.csi.min.io/node":     string(server.nodeID),
-		},
-	}
-
+	// Legacy topology is not derived from node labels.
+	segments := server.topology.Get()
 	return &csi.NodeGetInfoResponse{
-		NodeId:             string(server.nodeID),
-		AccessibleTopology: topology,
+		NodeId: string(server.nodeID),
+		AccessibleTopology: &csi.Topology{
+			Segments: map[string]string{
+				"direct.csi.min.io/identity": segments[string(directpvtypes.TopologyDriverIdentity)],
+				"direct.csi.min.io/rack":     segments[string(directpvtypes.TopologyDriverRack)],
+				"direct.csi.min.io/region":   segments[string(directpvtypes.TopologyDriverRegion)],
+				"direct.csi.min.io/zone":     segments[string(directpvtypes.TopologyDriverZone)],
+				"direct.csi.min.io/node":     string(server.nodeID),
+			},
+		},
 	}, nil
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/minio/directpv/pkg/k8s"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
+	"github.com/minio/directpv/pkg/xfs"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -80,15 +81,29 @@ func getPodInfo(ctx context.Context, req *csi.NodePublishVolumeRequest) (podName
 	return
 }
 
-func isDriveSuspended(ctx context.Context, driveID directpvtypes.DriveID) bool {
-	drive, err := client.DriveClient().Get(ctx, string(driveID), metav1.GetOptions{
+// getSuspendMode returns effective suspend mode of the volume; empty value denotes the volume is not suspended.
+// Read-only suspended volumes fall back to empty mode if the drive is suspended or not ready.
+func getSuspendMode(ctx context.Context, volume *types.Volume) directpvtypes.SuspendMode {
+	drive, err := client.DriveClient().Get(ctx, string(volume.GetDriveID()), metav1.GetOptions{
 		TypeMeta: types.NewDriveTypeMeta(),
 	})
 	if err != nil {
-		klog.ErrorS(err, "unable to get the drive", "Drive ID", driveID)
-		return false
+		klog.ErrorS(err, "unable to get the drive", "Drive ID", volume.GetDriveID())
 	}
-	return drive.IsSuspended()
+	isDriveSuspended := err == nil && drive.IsSuspended()
+
+	if !volume.IsSuspended() {
+		if isDriveSuspended {
+			return directpvtypes.SuspendModeEmpty
+		}
+		return ""
+	}
+
+	mode := volume.GetSuspendMode()
+	if mode == directpvtypes.SuspendModeReadOnly && (err != nil || isDriveSuspended || drive.Status.Status != directpvtypes.DriveStatusReady) {
+		return directpvtypes.SuspendModeEmpty
+	}
+	return mode
 }
 
 // NodePublishVolume is node publish volume request handler.
@@ -116,12 +131,23 @@ func (server *Server) NodePublishVolume(ctx context.Context, req *csi.NodePublis
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	isSuspended := volume.IsSuspended() || isDriveSuspended(ctx, volume.GetDriveID())
+	suspendMode := getSuspendMode(ctx, volume)
+	if suspendMode == directpvtypes.SuspendModeFenced {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is suspended in fenced mode", volume.Name)
+	}
+	isSuspended := suspendMode == directpvtypes.SuspendModeEmpty
 	if !isSuspended && volume.Status.StagingTargetPath != req.GetStagingTargetPath() {
 		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is not yet staged, but requested with %v", volume.Name, req.GetStagingTargetPath())
 	}
 
-	if err := server.publishVolume(req, isSuspended); err != nil {
+	// Filesystem mount options are applied to the drive; only mount flags are applied here.
+	flags, _, err := xfs.ParseMountOptions(volume.Status.MountOptions)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid mount options of volume %v; %v", volume.Name, err)
+	}
+
+	readOnly := req.GetReadonly() || suspendMode == directpvtypes.SuspendModeReadOnly
+	if err := server.publishVolume(req, isSuspended, readOnly, flags); err != nil {
 		klog.Errorf("unable to publish volume %s; %v", volume.Name, err)
 		return nil, status.Errorf(codes.Internal, "unable to publish volume; %v", err)
 	}
@@ -146,7 +172,7 @@ func (server *Server) NodePublishVolume(ctx context.Context, req *csi.NodePublis
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
-func (server *Server) publishVolume(req *csi.NodePublishVolumeRequest, isSuspended bool) error {
+func (server *Server) publishVolume(req *csi.NodePublishVolumeRequest, isSuspended, readOnly bool, flags []string) error {
 	if err := server.mkdir(req.GetTargetPath()); err != nil && !errors.Is(err, os.ErrExist) {
 		return fmt.Errorf("unable to create target path; %w", err)
 	}
@@ -164,7 +190,7 @@ func (server *Server) publishVolume(req *csi.NodePublishVolumeRequest, isSuspend
 			klog.V(5).InfoS("stagingTargetPath is already bind-mounted to tmpfs mount", "stagingTargetPath", req.GetStagingTargetPath(), "targetPath", req.GetTargetPath())
 			return nil
 		}
-		if err := server.bindMount(consts.TmpMountDir, req.GetTargetPath(), true); err != nil {
+		if err := server.bindMount(consts.TmpMountDir, req.GetTargetPath(), true, nil); err != nil {
 			return fmt.Errorf("unable to bind mount target path %v to %v; %w", req.GetTargetPath(), consts.TmpMountDir, err)
 		}
 		return nil
@@ -186,7 +212,7 @@ func (server *Server) publishVolume(req *csi.NodePublishVolumeRequest, isSuspend
 	if !targetPathDevices.IsEmpty() && targetPathDevices.Equal(stagingTargetPathDevices) {
 		klog.V(5).InfoS("stagingTargetPath is already bind-mounted to targetPath", "stagingTargetPath", req.GetStagingTargetPath(), "targetPath", req.GetTargetPath())
 	} else {
-		if err := server.bindMount(req.GetStagingTargetPath(), req.GetTargetPath(), req.GetReadonly()); err != nil {
+		if err := server.bindMount(req.GetStagingTargetPath(), req.GetTargetPath(), readOnly, flags); err != nil {
 			return fmt.Errorf("unable to bind mount staging target path to target path; %w", err)
 		}
 	}
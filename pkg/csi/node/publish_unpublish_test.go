@@ -24,8 +24,11 @@ import (
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,6 +62,70 @@ func TestNodePublishVolume(t *testing.T) {
 	}
 }
 
+func TestNodePublishSuspendedVolume(t *testing.T) {
+	req := &csi.NodePublishVolumeRequest{
+		VolumeId:          "volume-1",
+		StagingTargetPath: "/path/staging",
+		TargetPath:        "/path/target",
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+	}
+
+	testCases := []struct {
+		mode             directpvtypes.SuspendMode
+		driveStatus      directpvtypes.DriveStatus
+		expectErr        bool
+		expectedSource   string
+		expectedReadOnly bool
+	}{
+		{directpvtypes.SuspendModeEmpty, directpvtypes.DriveStatusReady, false, consts.TmpMountDir, true},
+		{directpvtypes.SuspendModeReadOnly, directpvtypes.DriveStatusReady, false, "/path/staging", true},
+		{directpvtypes.SuspendModeReadOnly, directpvtypes.DriveStatusError, false, consts.TmpMountDir, true},
+		{directpvtypes.SuspendModeFenced, directpvtypes.DriveStatusReady, true, "", false},
+	}
+
+	for i, testCase := range testCases {
+		volume := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 100)
+		volume.Status.StagingTargetPath = "/path/staging"
+		volume.SuspendWithMode(testCase.mode)
+		drive := types.NewDrive("drive-1", types.DriveStatus{Status: testCase.driveStatus}, "node-1", "sda", directpvtypes.AccessTierDefault)
+
+		clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive, volume))
+		client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+		client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+
+		var source string
+		var readOnly bool
+		nodeServer := createFakeServer()
+		nodeServer.getMounts = func() (*sys.MountInfo, error) {
+			return sys.FakeMountInfo(
+				sys.MountEntry{MountPoint: "/path/staging", MountSource: "/dev/sda"},
+				sys.MountEntry{MountPoint: consts.TmpMountDir, MountSource: "tmpfs"},
+			), nil
+		}
+		nodeServer.bindMount = func(src, _ string, ro bool, _ []string) error {
+			source, readOnly = src, ro
+			return nil
+		}
+
+		_, err := nodeServer.NodePublishVolume(t.Context(), req)
+		if testCase.expectErr {
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("case %v: expected: FailedPrecondition; got: %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if source != testCase.expectedSource || readOnly != testCase.expectedReadOnly {
+			t.Fatalf("case %v: expected: (%v, %v); got: (%v, %v)", i+1, testCase.expectedSource, testCase.expectedReadOnly, source, readOnly)
+		}
+	}
+}
+
 func TestPublishUnpublishVolume(t *testing.T) {
 	testStagingPath := t.TempDir()
 	defer os.RemoveAll(testStagingPath)
@@ -22,8 +22,12 @@ import (
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/drive"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/journal"
 	"github.com/minio/directpv/pkg/metrics"
 	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/topology"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/xfs"
 	"google.golang.org/grpc/codes"
@@ -38,66 +42,60 @@ type Server struct {
 	csi.UnimplementedNodeServer
 
 	nodeID   directpvtypes.NodeID
-	identity string
-	rack     string
-	zone     string
-	region   string
+	topology *topology.Resolver
 
 	getMounts         func() (mountInfo *sys.MountInfo, err error)
 	getDeviceByFSUUID func(fsuuid string) (string, error)
-	bindMount         func(source, target string, readOnly bool) error
+	bindMount         func(source, target string, readOnly bool, flags []string) error
 	unmount           func(target string) error
-	getQuota          func(ctx context.Context, device, volumeName string) (quota *xfs.Quota, err error)
-	setQuota          func(ctx context.Context, device, path, volumeName string, quota xfs.Quota, update bool) (err error)
+	getFSType         func(ctx context.Context, driveID directpvtypes.DriveID) (string, error)
+	getQuota          func(ctx context.Context, fsType, device, volumeName string) (quota *fs.Quota, err error)
+	getFSUsage        func(mountPoint string) (total, used, available uint64, err error)
+	setQuota          func(ctx context.Context, fsType, device, path, volumeName string, quota fs.Quota, update bool) (err error)
 	mkdir             func(path string) error
+	updateJournal     func(ctx context.Context, driveID directpvtypes.DriveID) error
 }
 
-func newServer(identity string, nodeID directpvtypes.NodeID, rack, zone, region string) Server {
+func newServer(nodeID directpvtypes.NodeID, resolver *topology.Resolver) Server {
 	return Server{
 		nodeID:   nodeID,
-		identity: identity,
-		rack:     rack,
-		zone:     zone,
-		region:   region,
+		topology: resolver,
 
 		getMounts:         sys.NewMountInfo,
 		getDeviceByFSUUID: sys.GetDeviceByFSUUID,
 		bindMount:         xfs.BindMount,
 		unmount:           func(target string) error { return sys.Unmount(target, true, true, false) },
-		getQuota:          xfs.GetQuota,
-		setQuota:          xfs.SetQuota,
+		getFSType:         drive.GetFSType,
+		getQuota:          fs.GetQuota,
+		getFSUsage:        sys.GetFSUsage,
+		setQuota:          fs.SetQuota,
 		mkdir: func(dir string) error {
 			return sys.Mkdir(dir, 0o755)
 		},
+		updateJournal: journal.Update,
 	}
 }
 
 // NewServer creates node server.
 func NewServer(ctx context.Context,
-	identity string, nodeID directpvtypes.NodeID, rack, zone, region string,
+	nodeID directpvtypes.NodeID, resolver *topology.Resolver,
 	metricsPort int,
 ) *Server {
 	go metrics.ServeMetrics(ctx, nodeID, metricsPort)
-	server := newServer(identity, nodeID, rack, zone, region)
+	server := newServer(nodeID, resolver)
 	return &server
 }
 
 // NodeGetInfo gets node information.
 // reference: https://github.com/container-storage-interface/spec/blob/master/spec.md#nodegetinfo
-func (server *Server) NodeGetInfo(_ context.Context, _ *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
-	topology := &csi.Topology{
-		Segments: map[string]string{
-			string(directpvtypes.TopologyDriverIdentity): server.identity,
-			string(directpvtypes.TopologyDriverRack):     server.rack,
-			string(directpvtypes.TopologyDriverZone):     server.zone,
-			string(directpvtypes.TopologyDriverRegion):   server.region,
-			string(directpvtypes.TopologyDriverNode):     string(server.nodeID),
-		},
+func (server *Server) NodeGetInfo(ctx context.Context, _ *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
+	if err := server.topology.Refresh(ctx); err != nil {
+		klog.ErrorS(err, "unable to refresh node topology; using the last known topology", "node", server.nodeID)
 	}
 
 	return &csi.NodeGetInfoResponse{
 		NodeId:             string(server.nodeID),
-		AccessibleTopology: topology,
+		AccessibleTopology: &csi.Topology{Segments: server.topology.Get()},
 	}, nil
 }
 
@@ -142,6 +140,29 @@ func (server *Server) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVo
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	if volume.GetBackend() == directpvtypes.BackendLVMThin {
+		// Thin volume has its own filesystem; its usage is the usage of the mounted filesystem.
+		mountPoint := volume.Status.StagingTargetPath
+		if mountPoint == "" {
+			mountPoint = volumePath
+		}
+		total, used, available, err := server.getFSUsage(mountPoint)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "unable to get filesystem usage of thin volume; %v", err)
+		}
+		return &csi.NodeGetVolumeStatsResponse{
+			Usage: []*csi.VolumeUsage{
+				{
+					Available: int64(available),
+					Total:     int64(total),
+					Used:      int64(used),
+					Unit:      csi.VolumeUsage_BYTES,
+				},
+			},
+			VolumeCondition: &csi.VolumeCondition{},
+		}, nil
+	}
+
 	device, err := server.getDeviceByFSUUID(volume.Status.FSUUID)
 	if err != nil {
 		klog.ErrorS(
@@ -159,7 +180,11 @@ func (server *Server) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVo
 				" on the host to reload", volume.Status.FSUUID)
 		return nil, status.Errorf(codes.NotFound, "unable to find device by FSUUID %v; %v", volume.Status.FSUUID, err)
 	}
-	quota, err := server.getQuota(ctx, device, volumeID)
+	fsType, err := server.getFSType(ctx, volume.GetDriveID())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "unable to get drive %v; %v", volume.GetDriveID(), err)
+	}
+	quota, err := server.getQuota(ctx, fsType, device, volumeID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "unable to get quota information; %v", err)
 	}
@@ -226,32 +251,44 @@ func (server *Server) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandV
 		return &csi.NodeExpandVolumeResponse{CapacityBytes: requiredBytes}, nil
 	}
 
-	device, err := server.getDeviceByFSUUID(volume.Status.FSUUID)
-	if err != nil {
-		klog.ErrorS(
-			err,
-			"unable to find device by FSUUID; "+
-				"either device is removed or run command "+
-				"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
-				"on the host to reload",
-			"FSUUID", volume.Status.FSUUID)
-		client.Eventf(
-			volume, client.EventTypeWarning, client.EventReasonStageVolume,
-			"unable to find device by FSUUID %v; "+
-				"either device is removed or run command "+
-				"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
-				" on the host to reload", volume.Status.FSUUID)
-		return nil, status.Errorf(codes.Internal, "unable to find device by FSUUID %v; %v", volume.Status.FSUUID, err)
-	}
+	if volume.GetBackend() == directpvtypes.BackendLVMThin {
+		if err := drive.ExpandThinVolume(ctx, volume, requiredBytes); err != nil {
+			klog.ErrorS(err, "unable to expand thin volume", "volume", volume.Name)
+			return nil, status.Errorf(codes.Internal, "unable to expand thin volume; %v", err)
+		}
+	} else {
+		device, err := server.getDeviceByFSUUID(volume.Status.FSUUID)
+		if err != nil {
+			klog.ErrorS(
+				err,
+				"unable to find device by FSUUID; "+
+					"either device is removed or run command "+
+					"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
+					"on the host to reload",
+				"FSUUID", volume.Status.FSUUID)
+			client.Eventf(
+				volume, client.EventTypeWarning, client.EventReasonStageVolume,
+				"unable to find device by FSUUID %v; "+
+					"either device is removed or run command "+
+					"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
+					" on the host to reload", volume.Status.FSUUID)
+			return nil, status.Errorf(codes.Internal, "unable to find device by FSUUID %v; %v", volume.Status.FSUUID, err)
+		}
 
-	quota := xfs.Quota{
-		HardLimit: uint64(requiredBytes),
-		SoftLimit: uint64(requiredBytes),
-	}
+		fsType, err := server.getFSType(ctx, volume.GetDriveID())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to get drive %v; %v", volume.GetDriveID(), err)
+		}
+
+		quota := fs.Quota{
+			HardLimit: uint64(requiredBytes),
+			SoftLimit: uint64(requiredBytes),
+		}
 
-	if err := server.setQuota(ctx, device, volume.Status.DataPath, volume.Name, quota, true); err != nil {
-		klog.ErrorS(err, "unable to set quota on volume data path", "DataPath", volume.Status.DataPath)
-		return nil, status.Errorf(codes.Internal, "unable to set quota on volume data path; %v", err)
+		if err := server.setQuota(ctx, fsType, device, volume.Status.DataPath, volume.Name, quota, true); err != nil {
+			klog.ErrorS(err, "unable to set quota on volume data path", "DataPath", volume.Status.DataPath)
+			return nil, status.Errorf(codes.Internal, "unable to set quota on volume data path; %v", err)
+		}
 	}
 
 	volume.Status.TotalCapacity = requiredBytes
@@ -263,5 +300,9 @@ func (server *Server) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandV
 		return nil, status.Errorf(codes.Internal, "unable to update volume %v; %v", volumeID, err)
 	}
 
+	if err := server.updateJournal(ctx, volume.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to update drive journal", "drive", volume.GetDriveID(), "volume", volume.Name)
+	}
+
 	return &csi.NodeExpandVolumeResponse{CapacityBytes: requiredBytes}, nil
 }
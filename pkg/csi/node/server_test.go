@@ -21,10 +21,11 @@ import (
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/types"
-	"github.com/minio/directpv/pkg/xfs"
 )
 
 func init() {
@@ -45,7 +46,7 @@ func TestNodeExpandVolume(t *testing.T) {
 	nodeServer.getDeviceByFSUUID = func(_ string) (string, error) {
 		return "sda", nil
 	}
-	nodeServer.setQuota = func(_ context.Context, _, _, _ string, _ xfs.Quota, _ bool) error {
+	nodeServer.setQuota = func(_ context.Context, _, _, _, _ string, _ fs.Quota, _ bool) error {
 		return nil
 	}
 
@@ -57,3 +58,36 @@ func TestNodeExpandVolume(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNodeGetVolumeStatsThinVolume(t *testing.T) {
+	volumeID := "volume-id-1"
+	volume := types.NewVolume(volumeID, "fsuuid1", "node-1", "drive-1", "sda", 100*MiB)
+	volume.SetBackend(directpvtypes.BackendLVMThin)
+	volume.Status.DataPath = "/dev/directpv-vg/volume-id-1"
+	volume.Status.StagingTargetPath = "volume/id/1/staging/target/path"
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume))
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	var mountPoint string
+	nodeServer := createFakeServer()
+	nodeServer.getFSUsage = func(path string) (total, used, available uint64, err error) {
+		mountPoint = path
+		return 100 * MiB, 30 * MiB, 60 * MiB, nil
+	}
+
+	resp, err := nodeServer.NodeGetVolumeStats(t.Context(), &csi.NodeGetVolumeStatsRequest{
+		VolumeId:   volumeID,
+		VolumePath: "volume-id-1-volume-path",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mountPoint != volume.Status.StagingTargetPath {
+		t.Fatalf("mount point: expected: %v, got: %v", volume.Status.StagingTargetPath, mountPoint)
+	}
+	usage := resp.GetUsage()[0]
+	if usage.Total != 100*MiB || usage.Used != 30*MiB || usage.Available != 60*MiB {
+		t.Fatalf("unexpected usage %v", usage)
+	}
+}
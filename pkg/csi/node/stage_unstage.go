@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/drive"
 	"github.com/minio/directpv/pkg/types"
@@ -53,8 +54,8 @@ func (server *Server) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if volume.IsSuspended() || isDriveSuspended(ctx, volume.GetDriveID()) {
-		// Suspended volumes doesn't require staging.
+	if mode := getSuspendMode(ctx, volume); mode != "" && mode != directpvtypes.SuspendModeReadOnly {
+		// Suspended volumes doesn't require staging except read-only mode.
 		return &csi.NodeStageVolumeResponse{}, nil
 	}
 
@@ -72,6 +73,10 @@ func (server *Server) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 		return nil, status.Error(code, err.Error())
 	}
 
+	if err := server.updateJournal(ctx, volume.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to update drive journal", "drive", volume.GetDriveID(), "volume", volume.Name)
+	}
+
 	return &csi.NodeStageVolumeResponse{}, nil
 }
 
@@ -78,7 +78,7 @@ func TestNodeStageVolume(t *testing.T) {
 		nodeServer.getMounts = func() (*sys.MountInfo, error) {
 			return testCase.mountInfo, nil
 		}
-		nodeServer.bindMount = func(source, _ string, _ bool) error {
+		nodeServer.bindMount = func(source, _ string, _ bool, _ []string) error {
 			if testCase.mountInfo.FilterByMountSource(source).IsEmpty() {
 				return errors.New("source is not mounted")
 			}
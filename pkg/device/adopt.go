@@ -0,0 +1,203 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/journal"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// readJournal mounts the device to its drive mount directory and reads the journal on it.
+func readJournal(device device) (*journal.Journal, error) {
+	filesystem, err := fs.Get(device.FSType)
+	if err != nil {
+		return nil, err
+	}
+	if err = filesystem.Mount(utils.AddDevPrefix(device.Name), types.GetDriveMountDir(device.FSUUID)); err != nil {
+		return nil, err
+	}
+	return journal.Read(device.FSUUID)
+}
+
+// newAdoptedDrive returns the drive of the device described by the journal, holding the volumes
+// having volume directories on the drive.
+func newAdoptedDrive(
+	nodeID directpvtypes.NodeID,
+	device device,
+	j *journal.Journal,
+	volumeDirExists func(fsuuid, volumeName string) bool,
+) (*types.Drive, []*types.Volume) {
+	drive := types.NewDrive(
+		j.DriveID,
+		types.DriveStatus{
+			TotalCapacity: device.TotalCapacity,
+			FSUUID:        j.FSUUID,
+			FSType:        j.FSType,
+			Status:        directpvtypes.DriveStatusReady,
+			Make:          device.Make(),
+			DeviceID:      device.StableID(),
+		},
+		nodeID,
+		directpvtypes.DriveName(device.Name),
+		j.AccessTier,
+	)
+	for key, value := range j.Labels {
+		drive.SetLabel(directpvtypes.LabelKey(key), directpvtypes.LabelValue(value))
+	}
+
+	var volumes []*types.Volume
+	for _, v := range j.Volumes {
+		if !volumeDirExists(j.FSUUID, v.Name) {
+			klog.InfoS("volume directory not found; skipping volume", "drive", j.DriveID, "volume", v.Name)
+			continue
+		}
+		volume := types.NewVolume(v.Name, j.FSUUID, nodeID, j.DriveID, drive.GetDriveName(), v.Size)
+		volume.Status.DataPath = types.GetVolumeDir(j.FSUUID, v.Name)
+		volume.SetPVCName(v.PVCName)
+		volume.SetPVCNamespace(v.PVCNamespace)
+		volumes = append(volumes, volume)
+
+		drive.AddVolumeFinalizer(v.Name)
+		drive.Status.AllocatedCapacity += v.Size
+	}
+	drive.Status.FreeCapacity = max(drive.Status.TotalCapacity-drive.Status.AllocatedCapacity, 0)
+
+	return drive, volumes
+}
+
+// adoptDrive adopts the drive described by the journal to this node. A drive not found in the cluster
+// is created with its volumes; a lost drive of another node is moved to this node with its volumes.
+func adoptDrive(ctx context.Context, nodeID directpvtypes.NodeID, device device, j *journal.Journal) (bool, error) {
+	if j.LVM != nil {
+		klog.InfoS("drive of LVM thin pool cannot be adopted; skipping", "drive", j.DriveID, "device", device.Name)
+		return false, nil
+	}
+
+	drive, err := client.DriveClient().Get(ctx, string(j.DriveID), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		volumeDirExists := func(fsuuid, volumeName string) bool {
+			_, err := os.Stat(types.GetVolumeDir(fsuuid, volumeName))
+			return err == nil
+		}
+		drive, volumes := newAdoptedDrive(nodeID, device, j, volumeDirExists)
+		for _, volume := range volumes {
+			_, err := client.VolumeClient().Create(ctx, volume, metav1.CreateOptions{})
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				return false, err
+			}
+		}
+		if drive, err = client.DriveClient().Create(ctx, drive, metav1.CreateOptions{}); err != nil {
+			return false, err
+		}
+		client.Eventf(drive, client.EventTypeNormal, client.EventReasonDriveAdopted, "Drive adopted with %v volumes from journal", len(volumes))
+		return true, nil
+	}
+
+	switch {
+	case drive.GetNodeID() == nodeID:
+		return false, nil
+	case drive.Status.FSUUID != j.FSUUID:
+		return false, fmt.Errorf("FSUUID %v of drive %v does not match with journal", drive.Status.FSUUID, drive.GetDriveID())
+	case drive.Status.Status != directpvtypes.DriveStatusLost:
+		klog.InfoS("drive is not lost on its node; skipping adoption", "drive", drive.GetDriveID(), "node", drive.GetNodeID(), "status", drive.Status.Status)
+		return false, nil
+	}
+
+	volumes, err := client.NewVolumeLister().
+		DriveIDSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(drive.GetDriveID()))}).
+		Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	for i := range volumes {
+		volumes[i].SetNodeID(nodeID)
+		volumes[i].SetDriveName(directpvtypes.DriveName(device.Name))
+		// Staging and target paths were of the previous node.
+		volumes[i].Status.StagingTargetPath = ""
+		volumes[i].Status.TargetPath = ""
+		if _, err := client.VolumeClient().Update(ctx, &volumes[i], metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()}); err != nil {
+			return false, err
+		}
+	}
+
+	srcNodeID := drive.GetNodeID()
+	drive.SetNodeID(nodeID)
+	drive.SetDriveName(directpvtypes.DriveName(device.Name))
+	if drive, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()}); err != nil {
+		return false, err
+	}
+	client.Eventf(drive, client.EventTypeNormal, client.EventReasonDriveAdopted, "Drive adopted from node %v with %v volumes", srcNodeID, len(volumes))
+	return true, nil
+}
+
+// adoptDrives adopts drives of the devices having DirectPV filesystem but not matching any drive of
+// this node, by reading the journals on them. Devices not adopted are unmounted.
+func adoptDrives(ctx context.Context, nodeID directpvtypes.NodeID, drives []types.Drive, deviceMap map[string][]device) (adopted bool) {
+	known := make(utils.StringSet)
+	for i := range drives {
+		known.Set(drives[i].Status.FSUUID)
+	}
+
+	for fsuuid, devices := range deviceMap {
+		if known.Exist(fsuuid) || len(devices) != 1 || devices[0].Label != consts.AppCapsName {
+			continue
+		}
+
+		j, err := readJournal(devices[0])
+		switch {
+		case err != nil:
+			klog.ErrorS(err, "unable to read drive journal", "device", devices[0].Name)
+		case j == nil:
+		case j.FSUUID != fsuuid:
+			klog.ErrorS(errors.New("FSUUID mismatch"), "invalid drive journal", "device", devices[0].Name, "FSUUID", fsuuid, "journalFSUUID", j.FSUUID)
+		default:
+			ok, err := adoptDrive(ctx, nodeID, devices[0], j)
+			if err != nil {
+				klog.ErrorS(err, "unable to adopt drive", "device", devices[0].Name, "drive", j.DriveID)
+			}
+			if ok {
+				adopted = true
+				klog.InfoS("drive adopted", "device", devices[0].Name, "drive", j.DriveID)
+				continue
+			}
+		}
+
+		if err := sys.Unmount(types.GetDriveMountDir(fsuuid), true, true, false); err != nil {
+			klog.ErrorS(err, "unable to unmount device", "device", devices[0].Name)
+		}
+	}
+
+	return adopted
+}
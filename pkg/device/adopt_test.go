@@ -0,0 +1,113 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/journal"
+	"github.com/minio/directpv/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+func TestNewAdoptedDrive(t *testing.T) {
+	j := &journal.Journal{
+		Version:    journal.Version,
+		DriveID:    "drive-1",
+		NodeID:     "node-1",
+		FSUUID:     "fsuuid-1",
+		FSType:     "xfs",
+		AccessTier: directpvtypes.AccessTierHot,
+		Labels:     map[string]string{"example.org/rack": "rack-1"},
+		Volumes: []journal.Volume{
+			{Name: "volume-1", Size: 10, PVCName: "data-0", PVCNamespace: "tenant-1"},
+			{Name: "volume-2", Size: 20},
+		},
+	}
+	volumeDirExists := func(_, volumeName string) bool { return volumeName == "volume-1" }
+
+	drive, volumes := newAdoptedDrive("node-2", newTestDevice("sdb", 100, ""), j, volumeDirExists)
+	if drive.GetDriveID() != "drive-1" || drive.GetNodeID() != "node-2" || drive.GetDriveName() != "sdb" || drive.GetAccessTier() != directpvtypes.AccessTierHot {
+		t.Fatalf("unexpected drive %+v", drive)
+	}
+	if drive.GetLabels()["example.org/rack"] != "rack-1" {
+		t.Fatalf("unexpected drive labels %v", drive.GetLabels())
+	}
+	if drive.Status.AllocatedCapacity != 10 || drive.Status.FreeCapacity != 90 || !drive.VolumeExist("volume-1") || drive.VolumeExist("volume-2") {
+		t.Fatalf("unexpected drive status %+v", drive.Status)
+	}
+	if len(volumes) != 1 || volumes[0].GetNodeID() != "node-2" || volumes[0].GetPVCName() != "data-0" || volumes[0].Status.DataPath != types.GetVolumeDir("fsuuid-1", "volume-1") {
+		t.Fatalf("unexpected volumes %+v", volumes)
+	}
+}
+
+func TestAdoptDrive(t *testing.T) {
+	newDrive := func(status directpvtypes.DriveStatus) *types.Drive {
+		drive := types.NewDrive("drive-1", types.DriveStatus{FSUUID: "fsuuid-1", Status: status}, "node-1", "sda", directpvtypes.AccessTierDefault)
+		drive.AddVolumeFinalizer("volume-1")
+		return drive
+	}
+	j := &journal.Journal{Version: journal.Version, DriveID: "drive-1", NodeID: "node-1", FSUUID: "fsuuid-1"}
+	device := newTestDevice("sdb", 100, "")
+
+	testCases := []struct {
+		drive        *types.Drive
+		adopted      bool
+		expectedNode directpvtypes.NodeID
+	}{
+		{newDrive(directpvtypes.DriveStatusLost), true, "node-2"},
+		{newDrive(directpvtypes.DriveStatusReady), false, "node-1"},
+	}
+
+	for i, testCase := range testCases {
+		volume := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10)
+		volume.Status.StagingTargetPath = "/var/lib/kubelet/staging"
+		clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(testCase.drive, volume))
+		client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+		client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+		adopted, err := adoptDrive(t.Context(), "node-2", device, j)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i, err)
+		}
+		if adopted != testCase.adopted {
+			t.Fatalf("case %v: adopted: expected: %v, got: %v", i, testCase.adopted, adopted)
+		}
+
+		drive, err := client.DriveClient().Get(t.Context(), "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i, err)
+		}
+		volume, err = client.VolumeClient().Get(t.Context(), "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i, err)
+		}
+		if drive.GetNodeID() != testCase.expectedNode || volume.GetNodeID() != testCase.expectedNode {
+			t.Fatalf("case %v: node: expected: %v, got: %v, %v", i, testCase.expectedNode, drive.GetNodeID(), volume.GetNodeID())
+		}
+		if adopted && (drive.GetDriveName() != "sdb" || volume.Status.StagingTargetPath != "") {
+			t.Fatalf("case %v: unexpected drive %+v or volume %+v", i, drive, volume)
+		}
+	}
+}
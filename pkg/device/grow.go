@@ -0,0 +1,100 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// minGrowSize is the minimum size by which the device must exceed the filesystem to grow it.
+// Filesystem metadata keeps the filesystem always smaller than its device.
+const minGrowSize = 64 * 1024 * 1024 // 64 MiB
+
+type growFunc func(ctx context.Context, drive *types.Drive) (totalCapacity int64, err error)
+
+// growFS grows the filesystem of the mounted drive and returns its new total capacity.
+func growFS(ctx context.Context, drive *types.Drive) (int64, error) {
+	filesystem, err := fs.Get(drive.GetFSType())
+	if err != nil {
+		return 0, err
+	}
+	if err = filesystem.Grow(ctx, drive.GetDevicePath(), types.GetDriveMountDir(drive.Status.FSUUID)); err != nil {
+		return 0, err
+	}
+	_, _, totalCapacity, _, err := filesystem.Probe(drive.GetDevicePath())
+	if err != nil {
+		return 0, err
+	}
+	return int64(totalCapacity), nil
+}
+
+// growDrive grows the filesystem of the drive if the device is enlarged and the grow policy of
+// the drive allows it.
+func growDrive(ctx context.Context, drive *types.Drive, device device, grow growFunc) (updated bool) {
+	// Thin pool of LVM drive is not grown.
+	if drive.Status.Status != directpvtypes.DriveStatusReady || drive.IsSuspended() || drive.Status.LVM != nil {
+		return false
+	}
+
+	deviceSize := int64(device.Size)
+	if deviceSize-drive.Status.TotalCapacity < minGrowSize {
+		return false
+	}
+
+	if drive.GetGrowPolicy() != directpvtypes.GrowPolicyAuto {
+		client.Eventf(
+			drive,
+			client.EventTypeNormal,
+			client.EventReasonDriveGrowAvailable,
+			"device %v is enlarged to %v bytes; set label %v=%v to grow the drive",
+			device.Name, humanize.Comma(deviceSize), directpvtypes.GrowPolicyLabelKey, directpvtypes.GrowPolicyAuto,
+		)
+		return false
+	}
+
+	totalCapacity, err := grow(ctx, drive)
+	if err == nil && totalCapacity <= drive.Status.TotalCapacity {
+		err = fmt.Errorf("filesystem size %v is not increased", totalCapacity)
+	}
+	if err != nil {
+		client.Eventf(drive, client.EventTypeWarning, client.EventReasonDriveGrowError, "unable to grow the drive; %v", err)
+		klog.ErrorS(err, "unable to grow the drive", "drive", drive.GetDriveID(), "device", device.Name)
+		return false
+	}
+
+	client.Eventf(
+		drive,
+		client.EventTypeNormal,
+		client.EventReasonDriveGrown,
+		"drive is grown from %v to %v bytes",
+		humanize.Comma(drive.Status.TotalCapacity), humanize.Comma(totalCapacity),
+	)
+	drive.Status.TotalCapacity = totalCapacity
+	drive.Status.FreeCapacity = drive.Status.TotalCapacity - drive.Status.AllocatedCapacity
+	if drive.Status.FreeCapacity < 0 {
+		drive.Status.FreeCapacity = 0
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2023 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/types"
+)
+
+func TestGrowDrive(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	newDrive := func(status directpvtypes.DriveStatus, policy directpvtypes.GrowPolicy) *types.Drive {
+		drive := types.NewDrive(
+			directpvtypes.DriveID("sda-id"),
+			types.DriveStatus{
+				Status:            status,
+				FSUUID:            "fsuuid-1",
+				TotalCapacity:     10 * gib,
+				AllocatedCapacity: 4 * gib,
+				FreeCapacity:      6 * gib,
+			},
+			directpvtypes.NodeID("nodeId"),
+			directpvtypes.DriveName("sda"),
+			directpvtypes.AccessTierDefault,
+		)
+		if policy != "" {
+			drive.SetLabel(directpvtypes.GrowPolicyLabelKey, directpvtypes.ToLabelValue(string(policy)))
+		}
+		return drive
+	}
+
+	newDevice := func(size uint64) device {
+		device := newTestDevice("sda", 10*gib, "")
+		device.Size = size
+		return device
+	}
+
+	testCases := []struct {
+		drive         *types.Drive
+		device        device
+		growErr       error
+		expectGrow    bool
+		expectUpdated bool
+		totalCapacity int64
+		freeCapacity  int64
+	}{
+		{newDrive(directpvtypes.DriveStatusReady, directpvtypes.GrowPolicyAuto), newDevice(20 * gib), nil, true, true, 20 * gib, 16 * gib},
+		{newDrive(directpvtypes.DriveStatusReady, directpvtypes.GrowPolicyAuto), newDevice(10*gib + 1024), nil, false, false, 10 * gib, 6 * gib},
+		{newDrive(directpvtypes.DriveStatusReady, directpvtypes.GrowPolicyManual), newDevice(20 * gib), nil, false, false, 10 * gib, 6 * gib},
+		{newDrive(directpvtypes.DriveStatusReady, ""), newDevice(20 * gib), nil, false, false, 10 * gib, 6 * gib},
+		{newDrive(directpvtypes.DriveStatusError, directpvtypes.GrowPolicyAuto), newDevice(20 * gib), nil, false, false, 10 * gib, 6 * gib},
+		{newDrive(directpvtypes.DriveStatusReady, directpvtypes.GrowPolicyAuto), newDevice(20 * gib), errors.New("xfs_growfs failed"), true, false, 10 * gib, 6 * gib},
+	}
+
+	for i, testCase := range testCases {
+		var growCalled bool
+		grow := func(_ context.Context, _ *types.Drive) (int64, error) {
+			growCalled = true
+			if testCase.growErr != nil {
+				return 0, testCase.growErr
+			}
+			return int64(testCase.device.Size), nil
+		}
+
+		updated := growDrive(context.TODO(), testCase.drive, testCase.device, grow)
+		if growCalled != testCase.expectGrow {
+			t.Fatalf("case %v: grow called: expected: %v, got: %v", i, testCase.expectGrow, growCalled)
+		}
+		if updated != testCase.expectUpdated {
+			t.Fatalf("case %v: updated: expected: %v, got: %v", i, testCase.expectUpdated, updated)
+		}
+		if testCase.drive.Status.TotalCapacity != testCase.totalCapacity || testCase.drive.Status.FreeCapacity != testCase.freeCapacity {
+			t.Fatalf("case %v: unexpected capacity; total: %v, free: %v", i, testCase.drive.Status.TotalCapacity, testCase.drive.Status.FreeCapacity)
+		}
+	}
+}
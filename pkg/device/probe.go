@@ -26,8 +26,10 @@ import (
 	"strings"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/blkid"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/types"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +37,23 @@ import (
 
 const minSupportedDeviceSize = 512 * 1024 * 1024 // 512 MiB
 
+// memberReasons denies devices used by other storage stacks even if they are
+// inactive i.e. no holders on them.
+var memberReasons = map[string]string{
+	blkid.TypeLVM2:   "LVM2 member",
+	blkid.TypeLUKS:   "LUKS encrypted",
+	blkid.TypeMDRaid: "MD RAID member",
+	blkid.TypeZFS:    "ZFS member",
+}
+
+// contentReasons denies devices having content which is not created by DirectPV.
+var contentReasons = map[string]string{
+	blkid.TypeExt2:  "ext2 filesystem",
+	blkid.TypeExt3:  "ext3 filesystem",
+	blkid.TypeBtrfs: "Btrfs filesystem",
+	blkid.TypeSwap:  "Swap signature",
+}
+
 // Device is a block device information.
 type Device struct {
 	Name        string            `json:"name"`        // Read from /sys/dev/block/<Major:Minor>/uevent
@@ -49,7 +68,11 @@ type Device struct {
 	SwapOn      bool              `json:"swapOn"`      // Read from /proc/swaps
 	CDROM       bool              `json:"cdrom"`       // Read from /proc/sys/dev/cdrom/info
 	DMName      string            `json:"dmName"`      // Read from /sys/class/block/<NAME>/dm/name
+	DMUUID      string            `json:"dmUUID"`      // Read from /sys/class/block/<NAME>/dm/uuid
+	Slaves      []string          `json:"slaves"`      // Read from /sys/class/block/<NAME>/slaves
+	BackingFile string            `json:"backingFile"` // Read from /sys/class/block/<NAME>/loop/backing_file
 	udevData    map[string]string // Read from /run/udev/data/b<Major:Minor>
+	signatures  blkid.Signatures  // Read from /dev/<NAME> if udev data has no content information
 }
 
 // ID generates an unique ID by hashing the properties of the Device.
@@ -102,19 +125,83 @@ func (d Device) Make() string {
 	return strings.Join(tokens, " ")
 }
 
+// Type returns the device type e.g. disk, partition, multipath, lvm, crypt, dm or md.
+func (d Device) Type() string {
+	switch {
+	case d.BackingFile != "":
+		return "virtual"
+	case d.udevData["E:DEVTYPE"] == "partition", strings.HasPrefix(d.DMUUID, "part"):
+		return "partition"
+	case strings.HasPrefix(d.DMUUID, "mpath-"):
+		return "multipath"
+	case strings.HasPrefix(d.DMUUID, "LVM-"):
+		return "lvm"
+	case strings.HasPrefix(d.DMUUID, "CRYPT-"):
+		return "crypt"
+	case d.DMUUID != "", d.DMName != "":
+		return "dm"
+	case d.udevData["E:MD_UUID"] != "", strings.HasPrefix(d.Name, "md"):
+		return "md"
+	default:
+		return "disk"
+	}
+}
+
+// StableID returns the stable identifier of the device i.e. dm UUID of device-mapper devices,
+// MD UUID of mdraid arrays or WWN of disks and their partitions. Unlike device names, the
+// identifier does not change across reboots and paths; empty value is returned if not available.
+func (d Device) StableID() string {
+	switch {
+	case d.DMUUID != "":
+		return "dm-uuid-" + d.DMUUID
+	case d.udevData["E:MD_UUID"] != "":
+		return "md-uuid-" + d.udevData["E:MD_UUID"]
+	}
+
+	wwn := d.udevData["E:ID_WWN_WITH_EXTENSION"]
+	if wwn == "" {
+		wwn = d.udevData["E:ID_WWN"]
+	}
+	if wwn == "" {
+		return ""
+	}
+	if number := d.udevData["E:ID_PART_ENTRY_NUMBER"]; number != "" && d.udevData["E:DEVTYPE"] == "partition" {
+		return "wwn-" + wwn + "-part" + number
+	}
+	return "wwn-" + wwn
+}
+
 // PartTableType returns partition table type.
 func (d Device) PartTableType() string {
-	return d.udevData["E:ID_PART_TABLE_TYPE"]
+	if partTableType := d.udevData["E:ID_PART_TABLE_TYPE"]; partTableType != "" {
+		return partTableType
+	}
+	if signature := d.signatures.PartitionTable(); signature != nil {
+		return signature.Type
+	}
+	return ""
 }
 
 // FSType returns filesystem type.
 func (d Device) FSType() string {
-	return d.udevData["E:ID_FS_TYPE"]
+	if fsType := d.udevData["E:ID_FS_TYPE"]; fsType != "" {
+		return fsType
+	}
+	if signature := d.signatures.FS(); signature != nil {
+		return signature.Type
+	}
+	return ""
 }
 
 // FSUUID returns the filesystem UUID.
 func (d Device) FSUUID() string {
-	return d.udevData["E:ID_FS_UUID"]
+	if d.udevData["E:ID_FS_TYPE"] != "" {
+		return d.udevData["E:ID_FS_UUID"]
+	}
+	if signature := d.signatures.FS(); signature != nil {
+		return signature.UUID
+	}
+	return ""
 }
 
 // DeniedReason returns the reason if the device is denied for initialization.
@@ -134,11 +221,15 @@ func (d Device) DeniedReason() string {
 	}
 
 	if d.Partitioned {
-		reasons = append(reasons, "Partitioned")
+		if partTableType := d.PartTableType(); partTableType != "" {
+			reasons = append(reasons, "Partitioned ("+partTableType+")")
+		} else {
+			reasons = append(reasons, "Partitioned")
+		}
 	}
 
 	if len(d.Holders) != 0 {
-		reasons = append(reasons, "Held by other device")
+		reasons = append(reasons, "Held by "+strings.Join(d.Holders, ", "))
 	}
 
 	if len(d.MountPoints) != 0 {
@@ -153,16 +244,32 @@ func (d Device) DeniedReason() string {
 		reasons = append(reasons, "CDROM")
 	}
 
-	if d.FSType() == "xfs" && d.FSUUID() != "" {
+	if reason, found := memberReasons[d.FSType()]; found {
+		reasons = append(reasons, reason)
+	}
+
+	// Active swap is already denied as Swap.
+	if reason, found := contentReasons[d.FSType()]; found && !d.SwapOn {
+		reasons = append(reasons, reason)
+	}
+
+	usedByDirectPV := false
+	if fs.Supported(d.FSType()) && d.FSUUID() != "" {
 		if _, err := client.DriveClient().Get(context.Background(), d.FSUUID(), metav1.GetOptions{}); err != nil {
 			if !apierrors.IsNotFound(err) {
 				reasons = append(reasons, "internal error; "+err.Error())
 			}
 		} else {
+			usedByDirectPV = true
 			reasons = append(reasons, "Used by "+consts.AppPrettyName)
 		}
 	}
 
+	// Filesystems supported by DirectPV are reformatted by init; name them only for otherwise denied devices.
+	if len(reasons) != 0 && !usedByDirectPV && fs.Supported(d.FSType()) {
+		reasons = append(reasons, d.FSType()+" filesystem")
+	}
+
 	var reason string
 	if len(reasons) != 0 {
 		reason = strings.Join(reasons, "; ")
@@ -182,6 +289,9 @@ func (d Device) ToNodeDevice(nodeID directpvtypes.NodeID) types.Device {
 		FSType:       d.FSType(),
 		FSUUID:       d.FSUUID(),
 		DeniedReason: d.DeniedReason(),
+		Type:         d.Type(),
+		StableID:     d.StableID(),
+		Components:   d.Slaves,
 	}
 }
 
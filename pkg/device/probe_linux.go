@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/minio/directpv/pkg/blkid"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/utils"
+	"k8s.io/klog/v2"
 )
 
 func newDevice(
@@ -77,6 +79,25 @@ func newDevice(
 		return nil, fmt.Errorf("unable to get DM name; device=%v; err=%w", name, err)
 	}
 
+	if device.DMUUID, err = getDMUUID(name); err != nil {
+		return nil, fmt.Errorf("unable to get DM UUID; device=%v; err=%w", name, err)
+	}
+
+	if device.Slaves, err = getSlaves(name); err != nil {
+		return nil, fmt.Errorf("unable to get slaves; device=%v; err=%w", name, err)
+	}
+
+	if device.BackingFile, err = getBackingFile(name); err != nil {
+		return nil, fmt.Errorf("unable to get backing file; device=%v; err=%w", name, err)
+	}
+
+	// udev data is often incomplete in containers; read on-disk signatures instead.
+	if udevData["E:ID_FS_TYPE"] == "" && udevData["E:ID_PART_TABLE_TYPE"] == "" {
+		if device.signatures, err = blkid.ProbeDevice(utils.AddDevPrefix(name)); err != nil {
+			klog.V(5).ErrorS(err, "unable to probe signatures", "device", name)
+		}
+	}
+
 	return device, nil
 }
 
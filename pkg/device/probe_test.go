@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/blkid"
 )
 
 func TestID(t *testing.T) {
@@ -155,3 +156,146 @@ func TestID(t *testing.T) {
 		}
 	}
 }
+
+func TestDeniedReason(t *testing.T) {
+	testCases := []struct {
+		device                Device
+		expectedFSType        string
+		expectedPartTableType string
+		expectedReason        string
+	}{
+		{
+			device: Device{
+				Size:     minSupportedDeviceSize,
+				udevData: map[string]string{"E:ID_FS_TYPE": "LVM2_member"},
+			},
+			expectedFSType: blkid.TypeLVM2,
+			expectedReason: "LVM2 member",
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				signatures: blkid.Signatures{{Type: blkid.TypeLUKS, Usage: blkid.UsageCrypto}},
+			},
+			expectedFSType: blkid.TypeLUKS,
+			expectedReason: "LUKS encrypted",
+		},
+		{
+			device: Device{
+				Size: minSupportedDeviceSize - 1,
+				signatures: blkid.Signatures{
+					{Type: blkid.TypeMDRaid, Usage: blkid.UsageRaid},
+					{Type: blkid.TypeExt4, Usage: blkid.UsageFilesystem},
+				},
+			},
+			expectedFSType: blkid.TypeMDRaid,
+			expectedReason: "Too small; MD RAID member",
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				signatures: blkid.Signatures{{Type: blkid.TypeGPT, Usage: blkid.UsagePartitionTable}},
+			},
+			expectedPartTableType: blkid.TypeGPT,
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				udevData:   map[string]string{"E:ID_PART_TABLE_TYPE": "dos"},
+				signatures: blkid.Signatures{{Type: blkid.TypeGPT, Usage: blkid.UsagePartitionTable}},
+			},
+			expectedPartTableType: blkid.TypeDOS,
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				signatures: blkid.Signatures{{Type: blkid.TypeBtrfs, Usage: blkid.UsageFilesystem}},
+			},
+			expectedFSType: blkid.TypeBtrfs,
+			expectedReason: "Btrfs filesystem",
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				signatures: blkid.Signatures{{Type: blkid.TypeSwap, Usage: blkid.UsageOther}},
+			},
+			expectedFSType: blkid.TypeSwap,
+			expectedReason: "Swap signature",
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				SwapOn:     true,
+				signatures: blkid.Signatures{{Type: blkid.TypeSwap, Usage: blkid.UsageOther}},
+			},
+			expectedFSType: blkid.TypeSwap,
+			expectedReason: "Swap",
+		},
+		{
+			device: Device{
+				Size:       minSupportedDeviceSize,
+				signatures: blkid.Signatures{{Type: blkid.TypeExt4, Usage: blkid.UsageFilesystem}},
+			},
+			expectedFSType: blkid.TypeExt4,
+		},
+		{
+			device: Device{
+				Size:        minSupportedDeviceSize,
+				MountPoints: []string{"/mnt/data"},
+				signatures:  blkid.Signatures{{Type: blkid.TypeExt4, Usage: blkid.UsageFilesystem}},
+			},
+			expectedFSType: blkid.TypeExt4,
+			expectedReason: "Mounted; ext4 filesystem",
+		},
+		{
+			device: Device{
+				Size:        minSupportedDeviceSize,
+				Partitioned: true,
+				signatures:  blkid.Signatures{{Type: blkid.TypeDOS, Usage: blkid.UsagePartitionTable}},
+			},
+			expectedPartTableType: blkid.TypeDOS,
+			expectedReason:        "Partitioned (dos)",
+		},
+	}
+
+	for i, testCase := range testCases {
+		if fsType := testCase.device.FSType(); fsType != testCase.expectedFSType {
+			t.Fatalf("case %v: fsType: expected: %v; got: %v", i+1, testCase.expectedFSType, fsType)
+		}
+
+		if partTableType := testCase.device.PartTableType(); partTableType != testCase.expectedPartTableType {
+			t.Fatalf("case %v: partTableType: expected: %v; got: %v", i+1, testCase.expectedPartTableType, partTableType)
+		}
+
+		if reason := testCase.device.DeniedReason(); reason != testCase.expectedReason {
+			t.Fatalf("case %v: reason: expected: %v; got: %v", i+1, testCase.expectedReason, reason)
+		}
+	}
+}
+
+func TestTypeAndStableID(t *testing.T) {
+	testCases := []struct {
+		device           Device
+		expectedType     string
+		expectedStableID string
+	}{
+		{Device{Name: "sda", udevData: map[string]string{"E:DEVTYPE": "disk", "E:ID_WWN": "0x5000c500a1b2c3d4"}}, "disk", "wwn-0x5000c500a1b2c3d4"},
+		{Device{Name: "sda1", udevData: map[string]string{"E:DEVTYPE": "partition", "E:ID_WWN": "0x5000c500a1b2c3d4", "E:ID_PART_ENTRY_NUMBER": "1"}}, "partition", "wwn-0x5000c500a1b2c3d4-part1"},
+		{Device{Name: "nvme0n1", udevData: map[string]string{"E:ID_WWN": "eui.a03299af790f1001", "E:ID_WWN_WITH_EXTENSION": "eui.a03299af790f1001-1"}}, "disk", "wwn-eui.a03299af790f1001-1"},
+		{Device{Name: "dm-0", DMName: "mpatha", DMUUID: "mpath-3600a098038303053453f463045727a4f"}, "multipath", "dm-uuid-mpath-3600a098038303053453f463045727a4f"},
+		{Device{Name: "dm-1", DMName: "vg0-lv0", DMUUID: "LVM-q7XfHc9Qh2pG8BfmXxW3"}, "lvm", "dm-uuid-LVM-q7XfHc9Qh2pG8BfmXxW3"},
+		{Device{Name: "dm-2", DMName: "mpatha1", DMUUID: "part1-mpath-3600a098038303053453f463045727a4f"}, "partition", "dm-uuid-part1-mpath-3600a098038303053453f463045727a4f"},
+		{Device{Name: "md127", udevData: map[string]string{"E:MD_UUID": "3f4c6a1e:2b7d9c0f:8e1a5b3d:7c9f0e2a"}}, "md", "md-uuid-3f4c6a1e:2b7d9c0f:8e1a5b3d:7c9f0e2a"},
+		{Device{Name: "vdb"}, "disk", ""},
+		{Device{Name: "loop0", BackingFile: "/var/lib/directpv/virtual/9b2d.img"}, "virtual", ""},
+	}
+
+	for i, testCase := range testCases {
+		if deviceType := testCase.device.Type(); deviceType != testCase.expectedType {
+			t.Fatalf("case %v: type: expected: %v, got: %v", i, testCase.expectedType, deviceType)
+		}
+		if stableID := testCase.device.StableID(); stableID != testCase.expectedStableID {
+			t.Fatalf("case %v: stable ID: expected: %v, got: %v", i, testCase.expectedStableID, stableID)
+		}
+	}
+}
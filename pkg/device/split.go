@@ -0,0 +1,79 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/minio/directpv/pkg/consts"
+)
+
+// MaxPartitions is the maximum number of partitions created on a device.
+const MaxPartitions = 128
+
+const (
+	partitionAlignment  = 1024 * 1024 // 1 MiB
+	gptPartitionEntries = 128 * 128   // 128 partition entries of 128 bytes
+
+	// linuxFSPartitionType is the GPT partition type GUID of Linux filesystem data.
+	linuxFSPartitionType = "0FC63DAF-8483-4772-8E79-3D69D8477DE4"
+)
+
+// newPartitionScript returns sfdisk script to create a GPT with count equally sized partitions
+// on a device of given size in bytes. As sfdisk reads start and size in logical sectors, they
+// are computed by the logical sector size of the device.
+func newPartitionScript(size, sectorSize uint64, count int) (string, error) {
+	if count < 1 || count > MaxPartitions {
+		return "", fmt.Errorf("partition count must be between 1 and %v", MaxPartitions)
+	}
+
+	if sectorSize < 512 || sectorSize > partitionAlignment || sectorSize&(sectorSize-1) != 0 {
+		return "", fmt.Errorf("unsupported logical sector size %v", sectorSize)
+	}
+	alignment := partitionAlignment / sectorSize
+	// Backup GPT header and partition entries at the end of device.
+	gptBackupSectors := 1 + (gptPartitionEntries+sectorSize-1)/sectorSize
+
+	sectors := size / sectorSize
+	if sectors <= alignment+gptBackupSectors {
+		return "", fmt.Errorf("device size %v is too small to partition", size)
+	}
+
+	partitionSectors := (sectors - alignment - gptBackupSectors) / uint64(count)
+	partitionSectors -= partitionSectors % alignment
+	if partitionSectors*sectorSize < minSupportedDeviceSize {
+		return "", fmt.Errorf("partition size %v is less than minimum supported size %v", partitionSectors*sectorSize, minSupportedDeviceSize)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("label: gpt\n")
+	for i := range count {
+		fmt.Fprintf(
+			&builder,
+			"start=%v, size=%v, type=%v, name=%v-%v\n",
+			alignment+uint64(i)*partitionSectors, partitionSectors, linuxFSPartitionType, consts.AppName, i+1,
+		)
+	}
+	return builder.String(), nil
+}
+
+// Split creates a new GPT on the device with count equally sized partitions and returns the probed partitions.
+func Split(ctx context.Context, device Device, count int) ([]Device, error) {
+	return split(ctx, device, count)
+}
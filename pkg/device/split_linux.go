@@ -0,0 +1,96 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/directpv/pkg/utils"
+)
+
+const partitionProbeTimeout = 30 * time.Second
+
+func getPartitionMajorMinors(name string) ([]string, error) {
+	partitions, err := getPartitions(name)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(partitions)
+
+	var majorMinors []string
+	for _, partition := range partitions {
+		majorMinor, err := readFirstLine("/sys/class/block/" + partition + "/dev")
+		if err != nil {
+			return nil, err
+		}
+		if majorMinor != "" {
+			majorMinors = append(majorMinors, majorMinor)
+		}
+	}
+	return majorMinors, nil
+}
+
+func split(ctx context.Context, device Device, count int) ([]Device, error) {
+	sectorSize, err := getLogicalBlockSize(device.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := newPartitionScript(device.Size, sectorSize, count)
+	if err != nil {
+		return nil, err
+	}
+
+	devPath := utils.AddDevPrefix(device.Name)
+	cmd := exec.CommandContext(ctx, "sfdisk", "--wipe", "always", "--wipe-partitions", "always", devPath)
+	cmd.Stdin = strings.NewReader(script)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("unable to create partitions on device %v; output=%v; %w", devPath, string(output), err)
+	}
+
+	// Partitions are probed after the kernel and udev populate them.
+	ctx, cancelFunc := context.WithTimeout(ctx, partitionProbeTimeout)
+	defer cancelFunc()
+	for {
+		majorMinors, err := getPartitionMajorMinors(device.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(majorMinors) == count {
+			partitions, err := probeDevices(majorMinors...)
+			if err != nil {
+				return nil, err
+			}
+			if len(partitions) == count {
+				return partitions, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("unable to probe partitions of device %v; %w", devPath, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"testing"
+)
+
+func TestNewPartitionScript(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	scriptTestCases := []struct {
+		sectorSize uint64
+		expected   string
+	}{
+		{
+			512,
+			"label: gpt\n" +
+				"start=2048, size=4192256, type=0FC63DAF-8483-4772-8E79-3D69D8477DE4, name=directpv-1\n" +
+				"start=4194304, size=4192256, type=0FC63DAF-8483-4772-8E79-3D69D8477DE4, name=directpv-2\n",
+		},
+		{
+			4096,
+			"label: gpt\n" +
+				"start=256, size=524032, type=0FC63DAF-8483-4772-8E79-3D69D8477DE4, name=directpv-1\n" +
+				"start=524288, size=524032, type=0FC63DAF-8483-4772-8E79-3D69D8477DE4, name=directpv-2\n",
+		},
+	}
+	for i, testCase := range scriptTestCases {
+		script, err := newPartitionScript(4*gib, testCase.sectorSize, 2)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if script != testCase.expected {
+			t.Fatalf("case %v: script: expected: %q; got: %q", i+1, testCase.expected, script)
+		}
+	}
+
+	testCases := []struct {
+		size       uint64
+		sectorSize uint64
+		count      int
+	}{
+		{4 * gib, 512, 0},
+		{4 * gib, 512, MaxPartitions + 1},
+		{4 * gib, 512, 8},
+		{1024 * 1024, 512, 1},
+		{1024 * 1024, 4096, 1},
+		{4 * gib, 0, 2},
+		{4 * gib, 1000, 2},
+	}
+	for i, testCase := range testCases {
+		if _, err := newPartitionScript(testCase.size, testCase.sectorSize, testCase.count); err == nil {
+			t.Fatalf("case %v: expected error", i+1)
+		}
+	}
+}
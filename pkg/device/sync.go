@@ -24,12 +24,14 @@ import (
 	"path"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/blkid"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/luks"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
-	"github.com/minio/directpv/pkg/xfs"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -38,16 +40,20 @@ import (
 
 type device struct {
 	Device
+	Parent        *Device // Device holding this device e.g. LUKS device of dm-crypt mapping
+	FSType        string
 	FSUUID        string
 	Label         string
 	TotalCapacity int64
 	FreeCapacity  int64
 }
 
-func probeDeviceMap() (map[string][]device, error) {
-	devices, err := Probe()
-	if err != nil {
-		return nil, err
+func probeDeviceMap(devices []Device) map[string][]device {
+	parents := map[string]*Device{}
+	for i := range devices {
+		for _, holder := range devices[i].Holders {
+			parents[holder] = &devices[i]
+		}
 	}
 
 	deviceMap := map[string][]device{}
@@ -56,16 +62,18 @@ func probeDeviceMap() (map[string][]device, error) {
 			continue
 		}
 
-		fsuuid, label, totalCapacity, freeCapacity, err := xfs.Probe(utils.AddDevPrefix(dev.Name))
+		filesystem, fsuuid, label, totalCapacity, freeCapacity, err := fs.Probe(utils.AddDevPrefix(dev.Name))
 		if err != nil {
-			if !errors.Is(err, xfs.ErrFSNotFound) {
-				klog.ErrorS(err, "unable to probe XFS filesystem", "Device", dev.Name)
+			if !errors.Is(err, fs.ErrFSNotFound) {
+				klog.ErrorS(err, "unable to probe filesystem", "Device", dev.Name)
 			}
 			continue
 		}
 
 		deviceMap[fsuuid] = append(deviceMap[fsuuid], device{
 			Device:        dev,
+			Parent:        parents[dev.Name],
+			FSType:        filesystem.Name(),
 			FSUUID:        fsuuid,
 			Label:         label,
 			TotalCapacity: int64(totalCapacity),
@@ -73,15 +81,57 @@ func probeDeviceMap() (map[string][]device, error) {
 		})
 	}
 
-	return deviceMap, nil
+	return deviceMap
+}
+
+// openEncryptedDrives opens LUKS devices of encrypted drives those are not opened yet.
+func openEncryptedDrives(ctx context.Context, drives []types.Drive, devices []Device) (opened bool) {
+	luksDevices := map[string]Device{}
+	for _, dev := range devices {
+		if dev.FSType() == blkid.TypeLUKS && dev.FSUUID() != "" {
+			luksDevices[dev.FSUUID()] = dev
+		}
+	}
+
+	for i := range drives {
+		encryption := drives[i].Status.Encryption
+		if encryption == nil {
+			continue
+		}
+
+		dev, found := luksDevices[encryption.LUKSUUID]
+		if !found || len(dev.Holders) != 0 {
+			// Either the device is not found or already opened.
+			continue
+		}
+
+		key, err := luks.GetKey(ctx, encryption.KeyProvider, encryption.KeyRef, encryption.LUKSUUID)
+		if err == nil {
+			err = luks.Open(ctx, utils.AddDevPrefix(dev.Name), encryption.MapperName, key)
+		}
+		if err != nil {
+			client.Eventf(&drives[i], client.EventTypeWarning, client.EventReasonDriveMountError, "unable to open encrypted device; %v", err)
+			klog.ErrorS(err, "unable to open encrypted device", "device", dev.Name, "drive", drives[i].GetDriveID())
+			continue
+		}
+
+		opened = true
+	}
+
+	return opened
 }
 
 func syncDrive(drive *types.Drive, device device) (updated bool) {
+	if (drive.IsEncrypted() || drive.Status.LVM != nil) && device.Parent != nil {
+		// Drive name and make of encrypted drive are of the LUKS device and of LVM drive are of the physical volume.
+		device.Device = *device.Parent
+	}
 	if string(drive.GetDriveName()) != device.Name {
 		updated = true
 		drive.SetDriveName(directpvtypes.DriveName(device.Name))
 	}
-	if drive.Status.TotalCapacity != device.TotalCapacity {
+	// Capacity of LVM drive is of its thin pool, not of its metadata filesystem.
+	if drive.Status.LVM == nil && drive.Status.TotalCapacity != device.TotalCapacity {
 		updated = true
 		drive.Status.TotalCapacity = device.TotalCapacity
 		drive.Status.FreeCapacity = drive.Status.TotalCapacity - drive.Status.AllocatedCapacity
@@ -93,9 +143,32 @@ func syncDrive(drive *types.Drive, device device) (updated bool) {
 		updated = true
 		drive.Status.Make = device.Make()
 	}
+	if stableID := device.StableID(); stableID != "" && drive.Status.DeviceID != stableID {
+		updated = true
+		drive.Status.DeviceID = stableID
+	}
 	return
 }
 
+// filterByDeviceID returns the devices having the stable device ID of the drive. Devices are not
+// filtered if the drive has no device ID or none of them have it e.g. the device is replaced.
+func filterByDeviceID(drive *types.Drive, devices []device) []device {
+	if drive.Status.DeviceID == "" || drive.IsEncrypted() || drive.Status.LVM != nil {
+		return devices
+	}
+
+	var filtered []device
+	for _, device := range devices {
+		if device.StableID() == drive.Status.DeviceID {
+			filtered = append(filtered, device)
+		}
+	}
+	if len(filtered) == 0 {
+		return devices
+	}
+	return filtered
+}
+
 func verifyDrive(drive *types.Drive) (updated bool) {
 	switch drive.Status.Status {
 	case directpvtypes.DriveStatusReady, directpvtypes.DriveStatusLost, directpvtypes.DriveStatusError, directpvtypes.DriveStatusMoving:
@@ -103,9 +176,13 @@ func verifyDrive(drive *types.Drive) (updated bool) {
 		return false
 	}
 
-	source := utils.AddDevPrefix(string(drive.GetDriveName()))
+	source := drive.GetDevicePath()
 	target := types.GetDriveMountDir(drive.Status.FSUUID)
-	if err := xfs.Mount(source, target); err != nil {
+	filesystem, err := fs.Get(drive.GetFSType())
+	if err == nil {
+		err = filesystem.Mount(source, target)
+	}
+	if err != nil {
 		drive.Status.Status = directpvtypes.DriveStatusError
 		drive.SetMountErrorCondition(fmt.Sprintf("unable to mount; %v", err))
 		client.Eventf(drive, client.EventTypeWarning, client.EventReasonDriveMountError, "unable to mount the drive; %v", err)
@@ -138,7 +215,7 @@ func verifyDrive(drive *types.Drive) (updated bool) {
 		return updated
 	}
 
-	err := os.Symlink(".", types.GetVolumeRootDir(drive.Status.FSUUID))
+	err = os.Symlink(".", types.GetVolumeRootDir(drive.Status.FSUUID))
 	if err != nil {
 		switch {
 		case errors.Is(err, os.ErrExist):
@@ -172,7 +249,7 @@ func verifyDrive(drive *types.Drive) (updated bool) {
 	return true
 }
 
-func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]device, mountInfo *sys.MountInfo) error {
+func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]device, mountInfo *sys.MountInfo, grow growFunc) error {
 	drive, err := client.DriveClient().Get(ctx, driveName, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -181,7 +258,20 @@ func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]d
 		return err
 	}
 	var updated bool
-	devices := deviceMap[drive.Status.FSUUID]
+	var devices []device
+	for _, device := range deviceMap[drive.Status.FSUUID] {
+		if device.FSType != drive.GetFSType() {
+			continue
+		}
+		if drive.IsEncrypted() && device.DMName != drive.Status.Encryption.MapperName {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	if len(devices) > 1 {
+		// Devices having same filesystem e.g. cloned disks are matched by the stable device ID.
+		devices = filterByDeviceID(drive, devices)
+	}
 	switch len(devices) {
 	case 0:
 		// no match
@@ -205,8 +295,8 @@ func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]d
 
 			check := func() error {
 				switch {
-				case mountEntry.FilesystemType != "xfs":
-					return errors.New("device filesystem is not XFS")
+				case mountEntry.FilesystemType != drive.GetFSType():
+					return fmt.Errorf("device filesystem is not %v", drive.GetFSType())
 				case !mountEntry.MountOptions.Exist("prjquota"):
 					return errors.New("device mounted without 'prjquota' mount option")
 				case !mountEntry.MountOptions.Exist("noatime"):
@@ -231,6 +321,9 @@ func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]d
 		if verifyDrive(drive) {
 			updated = true
 		}
+		if grow != nil && growDrive(ctx, drive, devices[0], grow) {
+			updated = true
+		}
 	default:
 		// more than one matching devices
 		updated = true
@@ -254,22 +347,84 @@ func updateDrive(ctx context.Context, driveName string, deviceMap map[string][]d
 
 // Sync - matches and syncs the drive with locally probed device
 func Sync(ctx context.Context, nodeID directpvtypes.NodeID) error {
-	deviceMap, err := probeDeviceMap()
+	drives, err := client.NewDriveLister().NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(nodeID))}).Get(ctx)
 	if err != nil {
 		return err
 	}
 
+	// Virtual drives must be attached to loop devices to find their filesystems.
+	attachVirtualDrives(drives)
+
+	devices, err := Probe()
+	if err != nil {
+		return err
+	}
+
+	// Encrypted drives must be opened to find their filesystems.
+	if openEncryptedDrives(ctx, drives, devices) {
+		if devices, err = Probe(); err != nil {
+			return err
+		}
+	}
+
+	deviceMap := probeDeviceMap(devices)
+
+	// Drives moved from other nodes or lost from the cluster are adopted by their journals.
+	if adoptDrives(ctx, nodeID, drives, deviceMap) {
+		if drives, err = client.NewDriveLister().NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(nodeID))}).Get(ctx); err != nil {
+			return err
+		}
+	}
+
 	mountInfo, err := sys.NewMountInfo()
 	if err != nil {
 		return err
 	}
 
+	for i := range drives {
+		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error { return updateDrive(ctx, drives[i].Name, deviceMap, mountInfo, nil) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncDevices matches and syncs the drives of the node with the devices of given major:minor numbers
+// e.g. devices added or changed; all devices are synced if no major:minor number is given. Drives
+// on enlarged devices are grown as per their grow policy.
+func SyncDevices(ctx context.Context, nodeID directpvtypes.NodeID, majorMinors ...string) error {
+	var devices []Device
+	var err error
+	if len(majorMinors) == 0 {
+		devices, err = Probe()
+	} else {
+		devices, err = ProbeDevices(majorMinors...)
+	}
+	if err != nil {
+		return err
+	}
+
+	deviceMap := probeDeviceMap(devices)
+	if len(deviceMap) == 0 {
+		return nil
+	}
+
 	drives, err := client.NewDriveLister().NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(nodeID))}).Get(ctx)
 	if err != nil {
 		return err
 	}
+
+	mountInfo, err := sys.NewMountInfo()
+	if err != nil {
+		return err
+	}
+
 	for i := range drives {
-		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error { return updateDrive(ctx, drives[i].Name, deviceMap, mountInfo) }); err != nil {
+		if _, found := deviceMap[drives[i].Status.FSUUID]; !found {
+			continue
+		}
+		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error { return updateDrive(ctx, drives[i].Name, deviceMap, mountInfo, growFS) }); err != nil {
 			return err
 		}
 	}
@@ -17,6 +17,7 @@
 package device
 
 import (
+	"reflect"
 	"testing"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
@@ -115,3 +116,71 @@ func TestSyncDrive(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncEncryptedDrive(t *testing.T) {
+	drive := types.NewDrive(
+		directpvtypes.DriveID("sda-id"),
+		types.DriveStatus{
+			TotalCapacity: 100,
+			Encryption:    &types.DriveEncryption{KeyRef: "luks-key", LUKSUUID: "luks-uuid", MapperName: "directpv-sda-id"},
+		},
+		directpvtypes.NodeID("nodeId"),
+		directpvtypes.DriveName("sda"),
+		directpvtypes.AccessTierDefault,
+	)
+
+	dev := newTestDevice("dm-0", 100, "directpv-sda-id")
+	dev.Parent = &Device{Name: "sdb"}
+
+	if updated := syncDrive(drive, dev); !updated {
+		t.Fatalf("expected drive to be updated")
+	}
+	if drive.GetDriveName() != "sdb" {
+		t.Fatalf("expected drive name: sdb; got: %v", drive.GetDriveName())
+	}
+	if drive.GetDevicePath() != "/dev/mapper/directpv-sda-id" {
+		t.Fatalf("expected device path: /dev/mapper/directpv-sda-id; got: %v", drive.GetDevicePath())
+	}
+}
+
+func TestFilterByDeviceID(t *testing.T) {
+	newDevice := func(name, dmuuid string) device {
+		dev := newTestDevice(name, 100, "")
+		dev.DMUUID = dmuuid
+		return dev
+	}
+	newDrive := func(deviceID string) *types.Drive {
+		return types.NewDrive(
+			directpvtypes.DriveID("sda-id"),
+			types.DriveStatus{TotalCapacity: 100, DeviceID: deviceID},
+			directpvtypes.NodeID("nodeId"),
+			directpvtypes.DriveName("dm-0"),
+			directpvtypes.AccessTierDefault,
+		)
+	}
+	devices := []device{newDevice("dm-0", "mpath-360001"), newDevice("dm-1", "mpath-360002")}
+
+	testCases := []struct {
+		drive         *types.Drive
+		expectedNames []string
+	}{
+		{newDrive("dm-uuid-mpath-360002"), []string{"dm-1"}},
+		{newDrive(""), []string{"dm-0", "dm-1"}},
+		{newDrive("dm-uuid-mpath-360003"), []string{"dm-0", "dm-1"}},
+	}
+
+	for i, testCase := range testCases {
+		var names []string
+		for _, device := range filterByDeviceID(testCase.drive, devices) {
+			names = append(names, device.Name)
+		}
+		if !reflect.DeepEqual(names, testCase.expectedNames) {
+			t.Fatalf("case %v: expected: %v; got: %v", i, testCase.expectedNames, names)
+		}
+	}
+
+	drive := newDrive("")
+	if updated := syncDrive(drive, devices[0]); !updated || drive.Status.DeviceID != "dm-uuid-mpath-360001" {
+		t.Fatalf("expected device ID to be synced; got: %v", drive.Status.DeviceID)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -83,6 +84,14 @@ func getSize(name string) (uint64, error) {
 	return ui64 * defaultBlockSize, nil
 }
 
+func getLogicalBlockSize(name string) (uint64, error) {
+	s, err := readFirstLine("/sys/class/block/" + name + "/queue/logical_block_size")
+	if err != nil || s == "" {
+		return defaultBlockSize, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
 func getPartitions(name string) ([]string, error) {
 	names, err := readdirnames("/sys/block/" + name)
 	if err != nil {
@@ -107,6 +116,20 @@ func getDMName(name string) (string, error) {
 	return readFirstLine("/sys/class/block/" + name + "/dm/name")
 }
 
+func getDMUUID(name string) (string, error) {
+	return readFirstLine("/sys/class/block/" + name + "/dm/uuid")
+}
+
+func getSlaves(name string) ([]string, error) {
+	slaves, err := readdirnames("/sys/class/block/" + name + "/slaves")
+	sort.Strings(slaves)
+	return slaves, err
+}
+
+func getBackingFile(name string) (string, error) {
+	return readFirstLine("/sys/class/block/" + name + "/loop/backing_file")
+}
+
 // GetStat returns statistics for a given device name.
 func GetStat(name string) (stats []uint64, err error) {
 	line, err := readFirstLine("/sys/class/block/" + name + "/stat")
@@ -100,7 +100,8 @@ func probeFromUdev() (deviceMap map[string]string, udevDataMap map[string]map[st
 			return nil, nil, err
 		}
 
-		if loopDeviceRegexp.MatchString(deviceName) {
+		// Loop devices other than of virtual drives are skipped.
+		if loopDeviceRegexp.MatchString(deviceName) && !isVirtualDevice(deviceName) {
 			continue
 		}
 
@@ -0,0 +1,69 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// MinVirtualDeviceSize is the minimum size of virtual device.
+const MinVirtualDeviceSize = minSupportedDeviceSize
+
+func isVirtualBackingFile(file string) bool {
+	return strings.HasPrefix(file, consts.VirtualDriveDir+"/")
+}
+
+// CreateVirtualDevice creates a sparse file of given size under DirectPV root and attaches
+// it to a loop device. Virtual devices are meant for development and testing only.
+func CreateVirtualDevice(size int64) (*Device, error) {
+	if size < MinVirtualDeviceSize {
+		return nil, errors.New("virtual device size must be at least 512 MiB")
+	}
+	return createVirtualDevice(size)
+}
+
+// RemoveVirtualDevice detaches the loop device of the backing file and removes the file.
+func RemoveVirtualDevice(backingFile string) error {
+	if err := detachLoopDevice(backingFile); err != nil {
+		return err
+	}
+	if err := os.Remove(backingFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// attachVirtualDrives attaches backing files of virtual drives to loop devices e.g. after reboot.
+func attachVirtualDrives(drives []types.Drive) {
+	for i := range drives {
+		if !drives[i].IsVirtual() {
+			continue
+		}
+		name, err := attachLoopDevice(drives[i].Status.Virtual.BackingFile)
+		if err != nil {
+			klog.ErrorS(err, "unable to attach virtual drive", "drive", drives[i].Name, "backingFile", drives[i].Status.Virtual.BackingFile)
+			continue
+		}
+		klog.V(3).InfoS("virtual drive is attached", "drive", drives[i].Name, "device", name)
+	}
+}
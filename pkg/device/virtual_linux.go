@@ -0,0 +1,145 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package device
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	losetup "github.com/freddierice/go-losetup/v2"
+	"github.com/google/uuid"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/utils"
+)
+
+func isVirtualDevice(name string) bool {
+	file, err := getBackingFile(name)
+	return err == nil && isVirtualBackingFile(file)
+}
+
+// getLoopDevice returns the name of the loop device attached to the backing file.
+func getLoopDevice(backingFile string) (string, error) {
+	names, err := readdirnames("/sys/block")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range names {
+		if !loopDeviceRegexp.MatchString(name) {
+			continue
+		}
+		file, err := getBackingFile(name)
+		if err != nil {
+			return "", err
+		}
+		if file == backingFile {
+			return name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func attachLoopDevice(backingFile string) (string, error) {
+	name, err := getLoopDevice(backingFile)
+	if err != nil || name != "" {
+		return name, err
+	}
+
+	device, err := losetup.Attach(backingFile, 0, false)
+	if err != nil {
+		return "", fmt.Errorf("unable to attach %v to loop device; %w", backingFile, err)
+	}
+	return path.Base(device.Path()), nil
+}
+
+func detachLoopDevice(backingFile string) error {
+	name, err := getLoopDevice(backingFile)
+	if err != nil || name == "" {
+		return err
+	}
+
+	number, err := strconv.ParseUint(strings.TrimPrefix(name, "loop"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid loop device %v; %w", name, err)
+	}
+	if err := losetup.New(number, os.O_RDWR).Detach(); err != nil {
+		return fmt.Errorf("unable to detach loop device %v; %w", name, err)
+	}
+	return nil
+}
+
+func createVirtualDevice(size int64) (device *Device, err error) {
+	if err = sys.Mkdir(consts.VirtualDriveDir, 0o750); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, err
+	}
+
+	backingFile := path.Join(consts.VirtualDriveDir, uuid.New().String()+".img")
+	file, err := os.OpenFile(backingFile, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if rerr := RemoveVirtualDevice(backingFile); rerr != nil {
+				err = errors.Join(err, rerr)
+			}
+		}
+	}()
+
+	// Backing file is sparse; space is allocated on the host filesystem as data is written.
+	err = file.Truncate(size)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := attachLoopDevice(backingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	majorMinor, err := readFirstLine("/sys/class/block/" + name + "/dev")
+	if err != nil {
+		return nil, err
+	}
+
+	// udev data of the new loop device may not be available yet.
+	udevData, err := readUdevData(majorMinor)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		udevData = map[string]string{}
+	}
+
+	mountInfo, err := sys.NewMountInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDevice(mountInfo, make(utils.StringSet), make(utils.StringSet), name, majorMinor, udevData)
+}
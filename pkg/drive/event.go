@@ -30,6 +30,10 @@ import (
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/device"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/journal"
+	"github.com/minio/directpv/pkg/luks"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
@@ -64,10 +68,20 @@ func SetIOError(ctx context.Context, driveID directpvtypes.DriveID) error {
 	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
 }
 
+// GetFSType returns filesystem type of specified drive.
+func GetFSType(ctx context.Context, driveID directpvtypes.DriveID) (string, error) {
+	drive, err := client.DriveClient().Get(ctx, string(driveID), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return "", err
+	}
+	return drive.GetFSType(), nil
+}
+
 func stageVolumeMount(
 	volumeName, volumeDir, stagingTargetPath string,
 	getMounts func() (*sys.MountInfo, error),
-	bindMount func(volumeDir, stagingTargetPath string, readOnly bool) error,
+	bindMount func(volumeDir, stagingTargetPath string, readOnly bool, flags []string) error,
+	flags []string,
 ) error {
 	mountInfo, err := getMounts()
 	if err != nil {
@@ -79,7 +93,7 @@ func stageVolumeMount(
 		return nil
 	}
 
-	return bindMount(volumeDir, stagingTargetPath, false)
+	return bindMount(volumeDir, stagingTargetPath, false, flags)
 }
 
 // StageVolume creates and mounts staging target path of the volume to the drive.
@@ -89,10 +103,14 @@ func StageVolume(
 	stagingTargetPath string,
 	getDeviceByFSUUID func(fsuuid string) (string, error),
 	mkdir func(volumeDir string) error,
-	setQuota func(ctx context.Context, device, stagingTargetPath, volumeName string, quota xfs.Quota, update bool) error,
-	bindMount func(volumeDir, stagingTargetPath string, readOnly bool) error,
+	setQuota func(ctx context.Context, fsType, device, stagingTargetPath, volumeName string, quota fs.Quota, update bool) error,
+	bindMount func(volumeDir, stagingTargetPath string, readOnly bool, flags []string) error,
 	getMounts func() (*sys.MountInfo, error),
 ) (codes.Code, error) {
+	if volume.GetBackend() == directpvtypes.BackendLVMThin {
+		return stageThinVolume(ctx, volume, stagingTargetPath, getMounts)
+	}
+
 	device, err := getDeviceByFSUUID(volume.Status.FSUUID)
 	if err != nil {
 		klog.ErrorS(
@@ -123,18 +141,37 @@ func StageVolume(
 		return codes.Internal, err
 	}
 
-	quota := xfs.Quota{
+	fsType, err := GetFSType(ctx, volume.GetDriveID())
+	if err != nil {
+		return codes.Internal, fmt.Errorf("unable to get drive %v; %w", volume.GetDriveID(), err)
+	}
+
+	quota := fs.Quota{
 		HardLimit: uint64(volume.Status.TotalCapacity),
 		SoftLimit: uint64(volume.Status.TotalCapacity),
 	}
 
-	if err := setQuota(ctx, device, volumeDir, volume.Name, quota, false); err != nil {
+	if err := setQuota(ctx, fsType, device, volumeDir, volume.Name, quota, false); err != nil {
 		klog.ErrorS(err, "unable to set quota on volume data path", "DataPath", volumeDir)
 		return codes.Internal, fmt.Errorf("unable to set quota on volume data path; %w", err)
 	}
 
+	flags, fsOptions, err := xfs.ParseMountOptions(volume.Status.MountOptions)
+	if err != nil {
+		return codes.InvalidArgument, fmt.Errorf("invalid mount options of volume %v; %w", volume.Name, err)
+	}
+	if len(fsOptions) != 0 {
+		// Volumes share the drive filesystem; such volumes are refused by CreateVolume now.
+		klog.InfoS("filesystem mount options of volume are not applied", "volume", volume.Name, "options", fsOptions)
+		client.Eventf(
+			volume, client.EventTypeWarning, client.EventReasonStageVolume,
+			"filesystem mount options %v are not applied; they are supported by %v backend only",
+			fsOptions, directpvtypes.BackendLVMThin,
+		)
+	}
+
 	if stagingTargetPath != "" {
-		if err := stageVolumeMount(volume.Name, volumeDir, stagingTargetPath, getMounts, bindMount); err != nil {
+		if err := stageVolumeMount(volume.Name, volumeDir, stagingTargetPath, getMounts, bindMount, flags); err != nil {
 			return codes.Internal, fmt.Errorf("unable to bind mount volume directory to staging target path; %w", err)
 		}
 	}
@@ -156,11 +193,16 @@ type driveEventHandler struct {
 	getMounts         func() (mountInfo *sys.MountInfo, err error)
 	unmount           func(target string) error
 	mkdir             func(path string) error
-	bindMount         func(source, target string, readOnly bool) error
+	bindMount         func(source, target string, readOnly bool, flags []string) error
 	getDeviceByFSUUID func(fsuuid string) (string, error)
-	setQuota          func(ctx context.Context, device, path, volumeName string, quota xfs.Quota, update bool) (err error)
+	setQuota          func(ctx context.Context, fsType, device, path, volumeName string, quota fs.Quota, update bool) (err error)
 	rmdir             func(fsuuid string) error
 	exists            func(name string) error
+	closeLUKS         func(name string) error
+	removeVirtual     func(backingFile string) error
+	updateJournal     func(ctx context.Context, driveID directpvtypes.DriveID) error
+	syncJournal       func(ctx context.Context, drive *types.Drive) error
+	removeJournal     func(fsuuid string) error
 }
 
 func newDriveEventHandler(nodeID directpvtypes.NodeID) *driveEventHandler {
@@ -178,7 +220,7 @@ func newDriveEventHandler(nodeID directpvtypes.NodeID) *driveEventHandler {
 		},
 		bindMount:         xfs.BindMount,
 		getDeviceByFSUUID: sys.GetDeviceByFSUUID,
-		setQuota:          xfs.SetQuota,
+		setQuota:          fs.SetQuota,
 		rmdir: func(fsuuid string) (err error) {
 			driveMountPoint := types.GetDriveMountDir(fsuuid)
 			if err = os.Remove(driveMountPoint); err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -194,6 +236,13 @@ func newDriveEventHandler(nodeID directpvtypes.NodeID) *driveEventHandler {
 			_, err = os.Lstat(name)
 			return err
 		},
+		removeVirtual: device.RemoveVirtualDevice,
+		closeLUKS: func(name string) error {
+			return luks.Close(context.Background(), name)
+		},
+		updateJournal: journal.Update,
+		syncJournal:   journal.Sync,
+		removeJournal: journal.Remove,
 	}
 }
 
@@ -256,9 +305,23 @@ func (handler *driveEventHandler) remove(ctx context.Context, drive *types.Drive
 	if volumeCount > 0 {
 		return fmt.Errorf("drive %v still contains %v volumes", drive.GetDriveID(), volumeCount)
 	}
+	// Journal is removed to avoid adopting the removed drive.
+	if err := handler.removeJournal(drive.Status.FSUUID); err != nil {
+		return err
+	}
 	if err := handler.unmountDrive(drive, false); err != nil {
 		return err
 	}
+	if drive.IsEncrypted() {
+		if err := handler.closeLUKS(drive.Status.Encryption.MapperName); err != nil {
+			return err
+		}
+	}
+	if drive.IsVirtual() {
+		if err := handler.removeVirtual(drive.Status.Virtual.BackingFile); err != nil {
+			return err
+		}
+	}
 	drive.RemoveFinalizers()
 	if _, err := client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()}); err != nil {
 		return err
@@ -339,10 +402,16 @@ func (handler *driveEventHandler) move(ctx context.Context, drive *types.Drive)
 	}
 
 	drive.Status.Status = directpvtypes.DriveStatusReady
-	_, err := client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{
+	if _, err := client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{
 		TypeMeta: types.NewDriveTypeMeta(),
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+
+	if err := handler.updateJournal(ctx, drive.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to update drive journal", "drive", drive.GetDriveID())
+	}
+	return nil
 }
 
 func (handler *driveEventHandler) handleUpdate(ctx context.Context, drive *types.Drive) error {
@@ -351,6 +420,12 @@ func (handler *driveEventHandler) handleUpdate(ctx context.Context, drive *types
 		return handler.remove(ctx, drive)
 	case directpvtypes.DriveStatusMoving:
 		return handler.move(ctx, drive)
+	case directpvtypes.DriveStatusReady:
+		// Journal is kept in sync with labels and access-tier of the drive;
+		// failure is retried on next resync.
+		if err := handler.syncJournal(ctx, drive); err != nil {
+			klog.ErrorS(err, "unable to sync drive journal", "drive", drive.GetDriveID())
+		}
 	}
 
 	return nil
@@ -405,7 +480,11 @@ func (handler *driveEventHandler) checkDrive(ctx context.Context, drive *types.D
 
 		source := utils.AddDevPrefix(device)
 		target := types.GetDriveMountDir(drive.Status.FSUUID)
-		if err = xfs.Mount(source, target); err != nil {
+		filesystem, err := fs.Get(drive.GetFSType())
+		if err == nil {
+			err = filesystem.Mount(source, target)
+		}
+		if err != nil {
 			drive.Status.Status = directpvtypes.DriveStatusError
 			drive.SetMountErrorCondition(fmt.Sprintf("unable to mount; %v", err))
 			client.Eventf(drive, client.EventTypeWarning, client.EventReasonDriveMountError, "unable to mount the drive; %v", err)
@@ -419,7 +498,10 @@ func (handler *driveEventHandler) checkDrive(ctx context.Context, drive *types.D
 				"Drive mounted successfully to %s", target,
 			)
 		}
-		drive.SetDriveName(directpvtypes.DriveName(utils.TrimDevPrefix(device)))
+		if !drive.IsEncrypted() {
+			// Encrypted drive keeps the name of LUKS device, not of dm-crypt mapping.
+			drive.SetDriveName(directpvtypes.DriveName(utils.TrimDevPrefix(device)))
+		}
 		_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{
 			TypeMeta: types.NewDriveTypeMeta(),
 		})
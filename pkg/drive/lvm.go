@@ -0,0 +1,133 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/lvm"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/xfs"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// stageThinVolume creates thin logical volume of the volume in the drive's thin pool,
+// formats it with XFS on first use and mounts it on staging target path.
+func stageThinVolume(
+	ctx context.Context,
+	volume *types.Volume,
+	stagingTargetPath string,
+	getMounts func() (*sys.MountInfo, error),
+) (codes.Code, error) {
+	drive, err := client.DriveClient().Get(ctx, string(volume.GetDriveID()), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return codes.Internal, fmt.Errorf("unable to get drive %v; %w", volume.GetDriveID(), err)
+	}
+	if drive.Status.LVM == nil {
+		return codes.FailedPrecondition, fmt.Errorf("drive %v of volume %v has no thin pool", drive.Name, volume.Name)
+	}
+
+	vgName := drive.Status.LVM.VGName
+	snapshot := volume.GetSnapshot()
+	if snapshot != "" {
+		if err := lvm.CreateVolumeFromSnapshot(ctx, vgName, snapshot, volume.Name); err != nil {
+			klog.ErrorS(err, "unable to restore thin volume from snapshot", "volume", volume.Name, "snapshot", snapshot, "vg", vgName)
+			return codes.Internal, fmt.Errorf("unable to restore thin volume from snapshot %v; %w", snapshot, err)
+		}
+		if err := lvm.ExtendVolume(ctx, vgName, volume.Name, volume.Status.TotalCapacity); err != nil {
+			return codes.Internal, fmt.Errorf("unable to extend restored thin volume; %w", err)
+		}
+	} else if err := lvm.CreateThinVolume(ctx, vgName, drive.Status.LVM.ThinPool, volume.Name, volume.Status.TotalCapacity); err != nil {
+		klog.ErrorS(err, "unable to create thin volume", "volume", volume.Name, "vg", vgName)
+		return codes.Internal, fmt.Errorf("unable to create thin volume; %w", err)
+	}
+
+	device := lvm.Path(vgName, volume.Name)
+	if _, _, _, _, err := xfs.Probe(device); err != nil {
+		if !errors.Is(err, xfs.ErrFSNotFound) {
+			return codes.Internal, fmt.Errorf("unable to probe thin volume %v; %w", device, err)
+		}
+		if _, _, _, _, err := xfs.MakeFS(ctx, device, uuid.NewString(), false, false, xfs.Profiles[xfs.DefaultProfileName]); err != nil {
+			return codes.Internal, fmt.Errorf("unable to format thin volume %v; %w", device, err)
+		}
+	}
+
+	flags, fsOptions, err := xfs.ParseMountOptions(volume.Status.MountOptions)
+	if err != nil {
+		return codes.InvalidArgument, fmt.Errorf("invalid mount options of volume %v; %w", volume.Name, err)
+	}
+
+	if stagingTargetPath != "" {
+		mountInfo, err := getMounts()
+		if err != nil {
+			return codes.Internal, err
+		}
+		// Filesystem mount options are applied per volume as each thin volume has its own filesystem.
+		if mountInfo.FilterByMountPoint(stagingTargetPath).IsEmpty() {
+			if snapshot != "" {
+				// Restored filesystem has the same UUID of the snapshot origin.
+				fsOptions = append(fsOptions, "nouuid")
+			}
+			if err := sys.Mount(device, stagingTargetPath, "xfs", flags, strings.Join(fsOptions, ",")); err != nil {
+				return codes.Internal, fmt.Errorf("unable to mount thin volume on staging target path; %w", err)
+			}
+		}
+		if snapshot != "" {
+			// Restored filesystem is grown to the requested size of the volume.
+			if err := xfs.Grow(ctx, stagingTargetPath); err != nil {
+				return codes.Internal, fmt.Errorf("unable to grow restored thin volume; %w", err)
+			}
+		}
+	}
+
+	volume.Status.DataPath = device
+	volume.Status.StagingTargetPath = stagingTargetPath
+	volume.Status.Status = directpvtypes.VolumeStatusReady
+	if _, err := client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{
+		TypeMeta: types.NewVolumeTypeMeta(),
+	}); err != nil {
+		return codes.Internal, err
+	}
+
+	return codes.OK, nil
+}
+
+// ExpandThinVolume extends thin logical volume of the volume to given size and grows its filesystem.
+func ExpandThinVolume(ctx context.Context, volume *types.Volume, size int64) error {
+	drive, err := client.DriveClient().Get(ctx, string(volume.GetDriveID()), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return err
+	}
+	if drive.Status.LVM == nil {
+		return fmt.Errorf("drive %v of volume %v has no thin pool", drive.Name, volume.Name)
+	}
+
+	if err := lvm.ExtendVolume(ctx, drive.Status.LVM.VGName, volume.Name, size); err != nil {
+		return err
+	}
+
+	return xfs.Grow(ctx, volume.Status.StagingTargetPath)
+}
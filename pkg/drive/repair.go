@@ -28,10 +28,10 @@ import (
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
-	"github.com/minio/directpv/pkg/xfs"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -119,7 +119,7 @@ func repair(ctx context.Context, drive *types.Drive, force, disablePrefetch, dry
 	}
 
 	if len(mountPoints) != 0 {
-		return fmt.Errorf("unable to run xfs repair; device %v still mounted in [%v]", device, strings.Join(mountPoints.ToSlice(), ","))
+		return fmt.Errorf("unable to run filesystem repair; device %v still mounted in [%v]", device, strings.Join(mountPoints.ToSlice(), ","))
 	}
 
 	if err = unmount(target); err != nil {
@@ -174,8 +174,12 @@ func repair(ctx context.Context, drive *types.Drive, force, disablePrefetch, dry
 	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
 }
 
-// Repair runs `xfs_repair` command on specified drive
+// Repair runs filesystem repair command i.e. `xfs_repair` or `e2fsck` on specified drive
 func Repair(ctx context.Context, drive *types.Drive, force, disablePrefetch, dryRun bool) error {
+	filesystem, err := fs.Get(drive.GetFSType())
+	if err != nil {
+		return err
+	}
 	return repair(ctx, drive, force, disablePrefetch, dryRun,
 		sys.GetDeviceByFSUUID,
 		func() (mountInfo *sys.MountInfo, err error) {
@@ -185,7 +189,7 @@ func Repair(ctx context.Context, drive *types.Drive, force, disablePrefetch, dry
 		func(mountPoint string) error {
 			return sys.Unmount(mountPoint, true, true, false)
 		},
-		xfs.Repair,
-		xfs.Mount,
+		filesystem.Repair,
+		filesystem.Mount,
 	)
 }
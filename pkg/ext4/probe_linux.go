@@ -0,0 +1,156 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	superBlockOffset     = 1024
+	magicNumber          = 0xEF53
+	featureIncompat64Bit = 0x80
+)
+
+func bytesToUUIDString(uuid [16]byte) string {
+	return fmt.Sprintf(
+		"%08x-%04x-%04x-%x-%x",
+		binary.BigEndian.Uint32(uuid[0:4]),
+		binary.BigEndian.Uint16(uuid[4:6]),
+		binary.BigEndian.Uint16(uuid[6:8]),
+		uuid[8:10],
+		uuid[10:],
+	)
+}
+
+// Refer below link for more information about this structure.
+// - https://www.kernel.org/doc/html/latest/filesystems/ext4/super.html
+type superBlock struct {
+	InodesCount        uint32
+	BlocksCountLo      uint32
+	RBlocksCountLo     uint32
+	FreeBlocksCountLo  uint32
+	FreeInodesCount    uint32
+	FirstDataBlock     uint32
+	LogBlockSize       uint32
+	LogClusterSize     uint32
+	BlocksPerGroup     uint32
+	ClustersPerGroup   uint32
+	InodesPerGroup     uint32
+	MountTime          uint32
+	WriteTime          uint32
+	MountCount         uint16
+	MaxMountCount      uint16
+	MagicNumber        uint16
+	State              uint16
+	Errors             uint16
+	MinorRevisionLevel uint16
+	LastCheck          uint32
+	CheckInterval      uint32
+	CreatorOS          uint32
+	RevisionLevel      uint32
+	DefaultResUID      uint16
+	DefaultResGID      uint16
+	FirstInode         uint32
+	InodeSize          uint16
+	BlockGroupNumber   uint16
+	FeatureCompat      uint32
+	FeatureIncompat    uint32
+	FeatureROCompat    uint32
+	UUID               [16]byte
+	VolumeName         [16]byte
+	_                  [64]byte  // LastMounted
+	_                  [136]byte // AlgorithmUsageBitmap to JournalBlocks
+	BlocksCountHi      uint32
+	RBlocksCountHi     uint32
+	FreeBlocksCountHi  uint32
+	// Ignoring the rest
+}
+
+func readSuperBlock(reader io.Reader) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	if _, err = io.CopyN(io.Discard, reader, superBlockOffset); err != nil {
+		return
+	}
+
+	var sb superBlock
+	if err = binary.Read(reader, binary.LittleEndian, &sb); err != nil {
+		return
+	}
+
+	if sb.MagicNumber != magicNumber {
+		err = ErrFSNotFound
+		return
+	}
+
+	blockSize := uint64(1024) << sb.LogBlockSize
+	totalBlocks := uint64(sb.BlocksCountLo)
+	freeBlocks := uint64(sb.FreeBlocksCountLo)
+	if sb.FeatureIncompat&featureIncompat64Bit != 0 {
+		totalBlocks |= uint64(sb.BlocksCountHi) << 32
+		freeBlocks |= uint64(sb.FreeBlocksCountHi) << 32
+	}
+
+	fsuuid = bytesToUUIDString(sb.UUID)
+	label = string(bytes.TrimRightFunc(sb.VolumeName[:], func(r rune) bool { return r == 0 }))
+	totalCapacity = totalBlocks * blockSize
+	freeCapacity = freeBlocks * blockSize
+	return
+}
+
+// probe probes FSUUID, total and free capacity.
+func probe(path string) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancelFunc()
+
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		var devFile *os.File
+		devFile, err = os.OpenFile(path, os.O_RDONLY, os.ModeDevice)
+		if err != nil {
+			return
+		}
+		defer devFile.Close()
+		fsuuid, label, totalCapacity, freeCapacity, err = readSuperBlock(devFile)
+	}()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			klog.InfoS("ext4 probe is taking too long; still waiting", "device", path)
+		case <-ctx.Done():
+			err = errors.Join(ErrCanceled, ctx.Err())
+			return
+		case <-doneCh:
+			return fsuuid, label, totalCapacity, freeCapacity, err
+		}
+	}
+}
@@ -0,0 +1,142 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"math"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/minio/directpv/pkg/sys"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Refer below links for more information about these constants and their calculations.
+	// - https://man7.org/linux/man-pages/man2/quotactl.2.html
+	// - https://github.com/torvalds/linux/blob/master/include/uapi/linux/quota.h
+	prjQuotaType = 2
+	subCmdShift  = 8
+	subCmdMask   = 0x00ff
+
+	getQuotaCmd = 0x800007
+	prjGetQuota = uintptr(getQuotaCmd<<subCmdShift | prjQuotaType&subCmdMask)
+
+	setQuotaCmd = 0x800008
+	prjSetQuota = uintptr(setQuotaCmd<<subCmdShift | prjQuotaType&subCmdMask)
+
+	validBlockLimits = 1    // QIF_BLIMITS
+	blockSize        = 1024 // QIF_DQBLKSIZE
+)
+
+// Refer below link for more information about this structure.
+// - https://man7.org/linux/man-pages/man2/quotactl.2.html
+type diskQuota struct {
+	hardLimitBlocks uint64 // Absolute limit on disk quota blocks alloc
+	softLimitBlocks uint64 // Preferred limit on disk quota blocks
+	currentSpace    uint64 // Current occupied space (in bytes)
+	_               uint64 // hardLimitInodes: Maximum number of allocated inodes
+	_               uint64 // softLimitInodes: Preferred inode limit
+	_               uint64 // currentInodes: Current number of allocated inodes
+	_               uint64 // blockTime: Time limit for excessive disk use
+	_               uint64 // inodeTime: Time limit for excessive files
+	valid           uint32 // Bit mask of QIF_* constants
+}
+
+func quotactl(cmd uintptr, device string, projectID uint32, quota *diskQuota) error {
+	deviceNamePtr, err := syscall.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_QUOTACTL,
+		cmd,
+		uintptr(unsafe.Pointer(deviceNamePtr)),
+		uintptr(projectID),
+		uintptr(unsafe.Pointer(quota)),
+		0,
+		0,
+	)
+	if errno != 0 {
+		return os.NewSyscallError("quotactl", errno)
+	}
+
+	return nil
+}
+
+func getQuota(device, volumeID string) (*Quota, error) {
+	result := &diskQuota{}
+	if err := quotactl(prjGetQuota, device, sys.GetProjectIDHash(volumeID), result); err != nil {
+		return nil, err
+	}
+
+	return &Quota{
+		HardLimit:    result.hardLimitBlocks * blockSize,
+		SoftLimit:    result.softLimitBlocks * blockSize,
+		CurrentSpace: result.currentSpace,
+	}, nil
+}
+
+func setProjectQuota(device string, projectID uint32, quota Quota) error {
+	return quotactl(prjSetQuota, device, projectID, &diskQuota{
+		hardLimitBlocks: uint64(math.Ceil(float64(quota.HardLimit) / blockSize)),
+		softLimitBlocks: uint64(math.Ceil(float64(quota.SoftLimit) / blockSize)),
+		valid:           validBlockLimits,
+	})
+}
+
+func setQuota(device, path, volumeID string, quota Quota, update bool) error {
+	projectID := sys.GetProjectIDHash(volumeID)
+
+	if !update {
+		if info, err := getQuota(device, volumeID); err == nil && info.HardLimit != 0 {
+			if info.HardLimit == quota.HardLimit {
+				klog.V(3).InfoS(
+					"Quota is already set",
+					"Device", device,
+					"Path", path,
+					"VolumeID", volumeID,
+					"ProjectID", projectID,
+					"HardLimit", info.HardLimit,
+				)
+				return nil
+			}
+		} else if err := sys.SetProjectID(path, projectID); err != nil {
+			klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
+			return err
+		}
+	}
+
+	if err := setProjectQuota(device, projectID, quota); err != nil {
+		klog.ErrorS(err, "unable to set quota", "Device", device, "Path", path, "Limit", quota.HardLimit)
+		return err
+	}
+
+	klog.V(3).InfoS(
+		"SetQuota succeeded",
+		"Device", device,
+		"Path", path,
+		"VolumeID", volumeID,
+		"ProjectID", projectID,
+		"HardLimit", quota.HardLimit,
+	)
+	return nil
+}
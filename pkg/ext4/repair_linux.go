@@ -0,0 +1,54 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+func repair(ctx context.Context, device string, force, dryRun bool, output io.Writer) error {
+	args := []string{"-f", "-v"}
+	switch {
+	case dryRun:
+		args = append(args, "-n")
+	case force:
+		args = append(args, "-y")
+	default:
+		args = append(args, "-p")
+	}
+	args = append(args, device)
+
+	cmd := exec.CommandContext(ctx, "e2fsck", args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Run(); err != nil {
+		// Exit status 1 denotes filesystem errors are corrected.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil
+		}
+		return fmt.Errorf("unable to run e2fsck on device %v; %w", device, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/directpv/pkg/ext4"
+	"github.com/minio/directpv/pkg/xfs"
+)
+
+type ext4FS struct{}
+
+func (ext4FS) Name() string {
+	return Ext4
+}
+
+func (ext4FS) Probe(device string) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	fsuuid, label, totalCapacity, freeCapacity, err = ext4.Probe(device)
+	if errors.Is(err, ext4.ErrFSNotFound) {
+		err = errors.Join(ErrFSNotFound, err)
+	}
+	return
+}
+
+// MakeFS formats the device; format profiles are not supported on ext4.
+func (ext4FS) MakeFS(ctx context.Context, device, uuid string, options MakeFSOptions) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	if options.Profile != (xfs.Profile{}) {
+		err = errors.New("format profiles are not supported on ext4")
+		return
+	}
+	return ext4.MakeFS(ctx, device, uuid, options.Force)
+}
+
+func (ext4FS) Mount(device, target string) error {
+	return ext4.Mount(device, target)
+}
+
+func (ext4FS) GetQuota(ctx context.Context, device, volumeID string) (*Quota, error) {
+	quota, err := ext4.GetQuota(ctx, device, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return &Quota{HardLimit: quota.HardLimit, SoftLimit: quota.SoftLimit, CurrentSpace: quota.CurrentSpace}, nil
+}
+
+func (ext4FS) SetQuota(ctx context.Context, device, path, volumeID string, quota Quota, update bool) error {
+	return ext4.SetQuota(ctx, device, path, volumeID, ext4.Quota{HardLimit: quota.HardLimit, SoftLimit: quota.SoftLimit}, update)
+}
+
+// Repair repairs ext4 on device; disablePrefetch is not applicable to ext4.
+func (ext4FS) Repair(ctx context.Context, device string, force, _, dryRun bool, output io.Writer) error {
+	return ext4.Repair(ctx, device, force, dryRun, output)
+}
+
+func (ext4FS) Grow(ctx context.Context, device, _ string) error {
+	return ext4.Grow(ctx, device)
+}
@@ -0,0 +1,135 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/directpv/pkg/xfs"
+)
+
+const (
+	// XFS denotes XFS filesystem.
+	XFS = "xfs"
+
+	// Ext4 denotes ext4 filesystem.
+	Ext4 = "ext4"
+)
+
+// ErrFSNotFound denotes filesystem not found error.
+var ErrFSNotFound = errors.New("filesystem not found")
+
+// Quota denotes project quota information.
+type Quota struct {
+	HardLimit    uint64
+	SoftLimit    uint64
+	CurrentSpace uint64
+}
+
+// MakeFSOptions denotes options to format a device.
+type MakeFSOptions struct {
+	Force   bool
+	Reflink bool
+	Profile xfs.Profile
+}
+
+// FS denotes a filesystem used on drives.
+type FS interface {
+	// Name returns the filesystem type name.
+	Name() string
+
+	// Probe probes the filesystem on device.
+	Probe(device string) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error)
+
+	// MakeFS formats the device.
+	MakeFS(ctx context.Context, device, uuid string, options MakeFSOptions) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error)
+
+	// Mount mounts the device to target with project quota enabled.
+	Mount(device, target string) error
+
+	// GetQuota returns project quota information of given volume ID.
+	GetQuota(ctx context.Context, device, volumeID string) (*Quota, error)
+
+	// SetQuota sets project quota on given path and volume ID.
+	SetQuota(ctx context.Context, device, path, volumeID string, quota Quota, update bool) error
+
+	// Repair repairs the filesystem on device.
+	Repair(ctx context.Context, device string, force, disablePrefetch, dryRun bool, output io.Writer) error
+
+	// Grow grows the filesystem on device mounted on mountPoint to the size of the device.
+	Grow(ctx context.Context, device, mountPoint string) error
+}
+
+var filesystems = map[string]FS{
+	XFS:  xfsFS{},
+	Ext4: ext4FS{},
+}
+
+// Names returns supported filesystem names.
+func Names() []string {
+	return []string{XFS, Ext4}
+}
+
+// Supported returns whether given filesystem is supported.
+func Supported(name string) bool {
+	_, found := filesystems[name]
+	return found
+}
+
+// Get returns filesystem of given name; empty name denotes XFS.
+func Get(name string) (FS, error) {
+	if name == "" {
+		name = XFS
+	}
+	if fs, found := filesystems[name]; found {
+		return fs, nil
+	}
+	return nil, fmt.Errorf("unsupported filesystem %v", name)
+}
+
+// Probe probes supported filesystems on device.
+func Probe(device string) (fs FS, fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	for _, name := range Names() {
+		fs = filesystems[name]
+		fsuuid, label, totalCapacity, freeCapacity, err = fs.Probe(device)
+		if !errors.Is(err, ErrFSNotFound) {
+			return fs, fsuuid, label, totalCapacity, freeCapacity, err
+		}
+	}
+	return nil, "", "", 0, 0, ErrFSNotFound
+}
+
+// GetQuota returns project quota information of given volume ID from the filesystem of fsType on device.
+func GetQuota(ctx context.Context, fsType, device, volumeID string) (*Quota, error) {
+	fs, err := Get(fsType)
+	if err != nil {
+		return nil, err
+	}
+	return fs.GetQuota(ctx, device, volumeID)
+}
+
+// SetQuota sets project quota on given path and volume ID of the filesystem of fsType on device.
+func SetQuota(ctx context.Context, fsType, device, path, volumeID string, quota Quota, update bool) error {
+	fs, err := Get(fsType)
+	if err != nil {
+		return err
+	}
+	return fs.SetQuota(ctx, device, path, volumeID, quota, update)
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fs
+
+import "testing"
+
+func TestProbe(t *testing.T) {
+	testCases := []struct {
+		device       string
+		expectedName string
+		expectedErr  error
+	}{
+		{"../xfs/xfs.testdata", XFS, nil},
+		{"../ext4/ext4.testdata", Ext4, nil},
+		{"../ext4/zero.testdata", "", ErrFSNotFound},
+	}
+
+	for i, testCase := range testCases {
+		fs, _, _, _, _, err := Probe(testCase.device)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && fs.Name() != testCase.expectedName {
+			t.Fatalf("case %v: expected: %v; got: %v", i+1, testCase.expectedName, fs.Name())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/directpv/pkg/xfs"
+)
+
+type xfsFS struct{}
+
+func (xfsFS) Name() string {
+	return XFS
+}
+
+func (xfsFS) Probe(device string) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	fsuuid, label, totalCapacity, freeCapacity, err = xfs.Probe(device)
+	if errors.Is(err, xfs.ErrFSNotFound) {
+		err = errors.Join(ErrFSNotFound, err)
+	}
+	return
+}
+
+func (xfsFS) MakeFS(ctx context.Context, device, uuid string, options MakeFSOptions) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	return xfs.MakeFS(ctx, device, uuid, options.Force, options.Reflink, options.Profile)
+}
+
+func (xfsFS) Mount(device, target string) error {
+	return xfs.Mount(device, target)
+}
+
+func (xfsFS) GetQuota(ctx context.Context, device, volumeID string) (*Quota, error) {
+	quota, err := xfs.GetQuota(ctx, device, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return &Quota{HardLimit: quota.HardLimit, SoftLimit: quota.SoftLimit, CurrentSpace: quota.CurrentSpace}, nil
+}
+
+func (xfsFS) SetQuota(ctx context.Context, device, path, volumeID string, quota Quota, update bool) error {
+	return xfs.SetQuota(ctx, device, path, volumeID, xfs.Quota{HardLimit: quota.HardLimit, SoftLimit: quota.SoftLimit}, update)
+}
+
+func (xfsFS) Repair(ctx context.Context, device string, force, disablePrefetch, dryRun bool, output io.Writer) error {
+	return xfs.Repair(ctx, device, force, disablePrefetch, dryRun, output)
+}
+
+func (xfsFS) Grow(ctx context.Context, _, mountPoint string) error {
+	return xfs.Grow(ctx, mountPoint)
+}
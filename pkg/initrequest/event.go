@@ -31,7 +31,12 @@ import (
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/controller"
 	pkgdevice "github.com/minio/directpv/pkg/device"
+	"github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/journal"
+	"github.com/minio/directpv/pkg/luks"
+	"github.com/minio/directpv/pkg/lvm"
 	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/topology"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
 	"github.com/minio/directpv/pkg/xfs"
@@ -48,24 +53,34 @@ const (
 )
 
 type initRequestEventHandler struct {
-	nodeID   directpvtypes.NodeID
-	reflink  bool
-	topology map[string]string
+	nodeID      directpvtypes.NodeID
+	reflink     bool
+	getTopology func() map[string]string
 
 	probeDevices func() ([]pkgdevice.Device, error)
 	getDevices   func(majorMinor ...string) ([]pkgdevice.Device, error)
 	getMounts    func() (*sys.MountInfo, error)
-	makeFS       func(device, fsuuid string, force, reflink bool) (string, string, uint64, uint64, error)
-	mount        func(device, fsuuid string) error
+	split        func(device pkgdevice.Device, count int) ([]pkgdevice.Device, error)
+	makeFS       func(fsType, device, fsuuid string, force, reflink bool, profile xfs.Profile) (string, string, uint64, uint64, error)
+	mount        func(fsType, device, fsuuid string) error
 	unmount      func(fsuuid string) error
 	symlink      func(fsuuid string) error
 	makeMetaDir  func(fsuuid string) error
 	writeFile    func(fsuuid, data string) error
+	writeJournal func(drive *types.Drive) error
+	getKey       func(provider, keyRef, luksUUID string) ([]byte, error)
+	luksFormat   func(device, luksUUID string, key []byte) error
+	luksOpen     func(device, name string, key []byte) error
+	luksClose    func(name string) error
+	createPool   func(device, vgName string) (int64, error)
+
+	createVirtualDevice func(size int64) (*pkgdevice.Device, error)
+	removeVirtualDevice func(backingFile string) error
 
 	mu sync.Mutex
 }
 
-func newInitRequestEventHandler(ctx context.Context, nodeID directpvtypes.NodeID, topology map[string]string) (*initRequestEventHandler, error) {
+func newInitRequestEventHandler(ctx context.Context, nodeID directpvtypes.NodeID, getTopology func() map[string]string) (*initRequestEventHandler, error) {
 	reflink, err := reflinkSupported(ctx)
 	if err != nil {
 		return nil, err
@@ -78,9 +93,9 @@ func newInitRequestEventHandler(ctx context.Context, nodeID directpvtypes.NodeID
 	}
 
 	return &initRequestEventHandler{
-		reflink:  reflink,
-		nodeID:   nodeID,
-		topology: topology,
+		reflink:     reflink,
+		nodeID:      nodeID,
+		getTopology: getTopology,
 
 		probeDevices: pkgdevice.Probe,
 		getDevices:   pkgdevice.ProbeDevices,
@@ -90,15 +105,28 @@ func newInitRequestEventHandler(ctx context.Context, nodeID directpvtypes.NodeID
 			}
 			return
 		},
-		makeFS: func(device, fsuuid string, force, reflink bool) (string, string, uint64, uint64, error) {
-			fsuuid, label, totalCapacity, freeCapacity, err := xfs.MakeFS(context.Background(), device, fsuuid, force, reflink)
+		split: func(device pkgdevice.Device, count int) ([]pkgdevice.Device, error) {
+			return pkgdevice.Split(context.Background(), device, count)
+		},
+		makeFS: func(fsType, device, fsuuid string, force, reflink bool, profile xfs.Profile) (string, string, uint64, uint64, error) {
+			filesystem, err := fs.Get(fsType)
+			if err != nil {
+				return "", "", 0, 0, err
+			}
+			fsuuid, label, totalCapacity, freeCapacity, err := filesystem.MakeFS(
+				context.Background(), device, fsuuid, fs.MakeFSOptions{Force: force, Reflink: reflink, Profile: profile},
+			)
 			if err != nil {
 				err = fmt.Errorf("unable to format device %v; %w", device, err)
 			}
 			return fsuuid, label, totalCapacity, freeCapacity, err
 		},
-		mount: func(device, fsuuid string) (err error) {
-			if err = xfs.Mount(device, types.GetDriveMountDir(fsuuid)); err != nil {
+		mount: func(fsType, device, fsuuid string) (err error) {
+			filesystem, err := fs.Get(fsType)
+			if err != nil {
+				return err
+			}
+			if err = filesystem.Mount(device, types.GetDriveMountDir(fsuuid)); err != nil {
 				err = fmt.Errorf("unable to mount %v to %v; %w", device, types.GetDriveMountDir(fsuuid), err)
 			}
 			return
@@ -127,6 +155,38 @@ func newInitRequestEventHandler(ctx context.Context, nodeID directpvtypes.NodeID
 			}
 			return
 		},
+		writeJournal: func(drive *types.Drive) error {
+			return journal.New(drive, nil).Write()
+		},
+		getKey: func(provider, keyRef, luksUUID string) ([]byte, error) {
+			return luks.GetKey(context.Background(), provider, keyRef, luksUUID)
+		},
+		luksFormat: func(device, luksUUID string, key []byte) (err error) {
+			if err = luks.Format(context.Background(), device, luksUUID, key); err != nil {
+				err = fmt.Errorf("unable to encrypt device %v; %w", device, err)
+			}
+			return
+		},
+		luksOpen: func(device, name string, key []byte) (err error) {
+			if err = luks.Open(context.Background(), device, name, key); err != nil {
+				err = fmt.Errorf("unable to open encrypted device %v; %w", device, err)
+			}
+			return
+		},
+		createPool: func(device, vgName string) (size int64, err error) {
+			if size, err = lvm.CreateThinPool(context.Background(), device, vgName); err != nil {
+				err = fmt.Errorf("unable to create thin pool on device %v; %w", device, err)
+			}
+			return
+		},
+		createVirtualDevice: pkgdevice.CreateVirtualDevice,
+		removeVirtualDevice: pkgdevice.RemoveVirtualDevice,
+		luksClose: func(name string) (err error) {
+			if err = luks.Close(context.Background(), name); err != nil {
+				err = fmt.Errorf("unable to close encrypted device %v; %w", luks.MapperPath(name), err)
+			}
+			return
+		},
 	}, nil
 }
 
@@ -164,6 +224,9 @@ func (handler *initRequestEventHandler) initDevices(ctx context.Context, req *ty
 
 	var majorMinorList []string
 	for i := range req.Spec.Devices {
+		if req.Spec.Devices[i].VirtualSize > 0 {
+			continue
+		}
 		tokens := strings.SplitN(req.Spec.Devices[i].ID, "$", 2)
 		if len(tokens) != 2 {
 			client.Eventf(req, client.EventTypeWarning, client.EventReasonInitError, "invalid device ID %v", req.Spec.Devices[i])
@@ -183,32 +246,78 @@ func (handler *initRequestEventHandler) initDevices(ctx context.Context, req *ty
 	}
 
 	results := make([]types.InitDeviceResult, len(req.Spec.Devices))
+	backingFiles := map[int]string{}
 	var wg sync.WaitGroup
 	for i := range req.Spec.Devices {
 		results[i].Name = req.Spec.Devices[i].Name
 		majorMinor := strings.SplitN(req.Spec.Devices[i].ID, "$", 2)[0]
 		device, found := probedDevices[majorMinor]
+		virtual := req.Spec.Devices[i].VirtualSize > 0
+		if virtual {
+			virtualDevice, err := handler.createVirtualDevice(req.Spec.Devices[i].VirtualSize)
+			if err != nil {
+				results[i].Error = "unable to create virtual device; " + err.Error()
+				continue
+			}
+			device, found = *virtualDevice, true
+			results[i].Name = device.Name
+			backingFiles[i] = device.BackingFile
+		}
 		switch {
 		case !found:
 			results[i].Error = "device not found"
-		case device.ID(handler.nodeID) != req.Spec.Devices[i].ID:
+		case !virtual && device.ID(handler.nodeID) != req.Spec.Devices[i].ID:
 			results[i].Error = "device state changed"
 		default:
-			if deniedReason := device.DeniedReason(); deniedReason == "" {
+			fsType := req.Spec.Devices[i].FSType
+			if fsType == "" {
+				fsType = fs.XFS
+			}
+			profile := toFormatProfile(req.Spec.Devices[i].FormatProfile)
+			backend, backendErr := toBackend(req.Spec.Devices[i].Backend)
+			if deniedReason := device.DeniedReason(); deniedReason != "" {
+				results[i].Error = "device init not permitted; " + deniedReason
+			} else if !fs.Supported(fsType) {
+				results[i].Error = "unsupported filesystem " + fsType
+			} else if err := toXFSProfile(profile).Validate(); err != nil {
+				results[i].Error = fmt.Sprintf("invalid format profile %v; %v", profile.Name, err)
+			} else if partitions := req.Spec.Devices[i].Partitions; partitions < 0 || partitions > pkgdevice.MaxPartitions {
+				results[i].Error = fmt.Sprintf("invalid partition count %v", partitions)
+			} else if virtual && partitions > 0 {
+				results[i].Error = "partitions are not supported on virtual device"
+			} else if backendErr != nil {
+				results[i].Error = backendErr.Error()
+			} else if backend == directpvtypes.BackendLVMThin && (fsType != fs.XFS || req.Spec.Devices[i].Encryption != nil) {
+				results[i].Error = "lvm-thin backend supports unencrypted XFS drives only"
+			} else {
 				wg.Add(1)
-				go func(i int, device pkgdevice.Device, force bool) {
+				go func(i int, device pkgdevice.Device, force bool, partitions int) {
 					defer wg.Done()
-					if err := handler.initDevice(device, force); err != nil {
+					var err error
+					if partitions > 0 {
+						err = handler.initPartitions(device, partitions, fsType, profile, req.Spec.Devices[i].Encryption, backend)
+					} else {
+						err = handler.initDevice(device, force, fsType, profile, req.Spec.Devices[i].Encryption, backend)
+					}
+					if err != nil {
 						results[i].Error = err.Error()
 					}
-				}(i, device, req.Spec.Devices[i].Force || device.PartTableType() != "")
-			} else {
-				results[i].Error = "device init not permitted; " + deniedReason
+				}(i, device, req.Spec.Devices[i].Force || device.PartTableType() != "", partitions)
 			}
 		}
 	}
 	wg.Wait()
 
+	// Backing files of virtual devices failed to initialize are removed.
+	for i, backingFile := range backingFiles {
+		if results[i].Error == "" {
+			continue
+		}
+		if err := handler.removeVirtualDevice(backingFile); err != nil {
+			klog.ErrorS(err, "unable to remove virtual device", "device", results[i].Name, "backingFile", backingFile)
+		}
+	}
+
 	return updateInitRequest(ctx, req.Name, results, directpvtypes.InitStatusProcessed)
 }
 
@@ -228,7 +337,60 @@ func updateInitRequest(ctx context.Context, name string, results []types.InitDev
 	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
 }
 
-func (handler *initRequestEventHandler) initDevice(device pkgdevice.Device, force bool) error {
+func toBackend(value string) (directpvtypes.Backend, error) {
+	if value == "" {
+		return directpvtypes.BackendDirectory, nil
+	}
+	return directpvtypes.ToBackend(value)
+}
+
+func toFormatProfile(profile *types.FormatProfile) *types.FormatProfile {
+	if profile == nil {
+		return &types.FormatProfile{Name: xfs.DefaultProfileName}
+	}
+	return profile
+}
+
+func toXFSProfile(profile *types.FormatProfile) xfs.Profile {
+	return xfs.Profile{
+		BlockSize:   profile.BlockSize,
+		InodeSize:   profile.InodeSize,
+		LogSize:     profile.LogSize,
+		StripeUnit:  profile.StripeUnit,
+		StripeWidth: profile.StripeWidth,
+		CRC:         profile.CRC,
+		BigTime:     profile.BigTime,
+		InobtCount:  profile.InobtCount,
+		RmapBT:      profile.RmapBT,
+	}
+}
+
+// initPartitions creates a new GPT with count equally sized partitions on the device and
+// initializes each partition as a drive.
+func (handler *initRequestEventHandler) initPartitions(device pkgdevice.Device, count int, fsType string, profile *types.FormatProfile, encryption *types.Encryption, backend directpvtypes.Backend) error {
+	mountInfo, err := handler.getMounts()
+	if err != nil {
+		return err
+	}
+	if mountPoints := mountInfo.FilterByMajorMinor(device.MajorMinor).List(); len(mountPoints) != 0 {
+		return fmt.Errorf("device %v is mounted", utils.AddDevPrefix(device.Name))
+	}
+
+	partitions, err := handler.split(device, count)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, partition := range partitions {
+		if err := handler.initDevice(partition, true, fsType, profile, encryption, backend); err != nil {
+			errs = append(errs, fmt.Errorf("partition %v; %w", partition.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (handler *initRequestEventHandler) initDevice(device pkgdevice.Device, force bool, fsType string, profile *types.FormatProfile, encryption *types.Encryption, backend directpvtypes.Backend) (err error) {
 	devPath := utils.AddDevPrefix(device.Name)
 
 	mountInfo, err := handler.getMounts()
@@ -247,12 +409,66 @@ func (handler *initRequestEventHandler) initDevice(device pkgdevice.Device, forc
 
 	fsuuid := uuid.New().String()
 
-	_, _, totalCapacity, freeCapacity, err := handler.makeFS(devPath, fsuuid, force, handler.reflink)
+	var driveEncryption *types.DriveEncryption
+	if encryption != nil {
+		driveEncryption = &types.DriveEncryption{
+			KeyProvider: encryption.KeyProvider,
+			KeyRef:      encryption.KeyRef,
+			LUKSUUID:    uuid.New().String(),
+			MapperName:  luks.MapperName(fsuuid),
+		}
+
+		var key []byte
+		if key, err = handler.getKey(driveEncryption.KeyProvider, driveEncryption.KeyRef, driveEncryption.LUKSUUID); err != nil {
+			return err
+		}
+
+		if err = handler.luksFormat(devPath, driveEncryption.LUKSUUID, key); err != nil {
+			return err
+		}
+
+		if err = handler.luksOpen(devPath, driveEncryption.MapperName, key); err != nil {
+			return err
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if cerr := handler.luksClose(driveEncryption.MapperName); cerr != nil {
+				err = errors.Join(err, cerr)
+			}
+		}()
+
+		// Filesystem is created on the dm-crypt mapped device.
+		devPath = luks.MapperPath(driveEncryption.MapperName)
+	}
+
+	var driveLVM *types.DriveLVM
+	var poolSize int64
+	if backend == directpvtypes.BackendLVMThin {
+		driveLVM = &types.DriveLVM{
+			VGName:   lvm.VGName(fsuuid),
+			MetaLV:   lvm.MetaLVName,
+			ThinPool: lvm.ThinPoolName,
+		}
+		if poolSize, err = handler.createPool(devPath, driveLVM.VGName); err != nil {
+			return err
+		}
+
+		// Filesystem is created on the metadata logical volume; volumes are in the thin pool.
+		devPath = lvm.Path(driveLVM.VGName, driveLVM.MetaLV)
+		force = true
+	}
+
+	_, _, totalCapacity, freeCapacity, err := handler.makeFS(fsType, devPath, fsuuid, force, handler.reflink, toXFSProfile(profile))
 	if err != nil {
 		return err
 	}
+	if driveLVM != nil {
+		totalCapacity, freeCapacity = uint64(poolSize), uint64(poolSize)
+	}
 
-	if err = handler.mount(devPath, fsuuid); err != nil {
+	if err = handler.mount(fsType, devPath, fsuuid); err != nil {
 		return err
 	}
 	defer func() {
@@ -277,6 +493,11 @@ func (handler *initRequestEventHandler) initDevice(device pkgdevice.Device, forc
 		return err
 	}
 
+	var driveVirtual *types.DriveVirtual
+	if device.BackingFile != "" {
+		driveVirtual = &types.DriveVirtual{BackingFile: device.BackingFile}
+	}
+
 	drive := types.NewDrive(
 		directpvtypes.DriveID(fsuuid),
 		types.DriveStatus{
@@ -285,31 +506,35 @@ func (handler *initRequestEventHandler) initDevice(device pkgdevice.Device, forc
 			FSUUID:        fsuuid,
 			Status:        directpvtypes.DriveStatusReady,
 			Make:          device.Make(),
-			Topology:      handler.topology,
+			DeviceID:      device.StableID(),
+			Topology:      handler.getTopology(),
+			FSType:        fsType,
+			FormatProfile: profile,
+			Encryption:    driveEncryption,
+			LVM:           driveLVM,
+			Virtual:       driveVirtual,
 		},
 		handler.nodeID,
 		directpvtypes.DriveName(device.Name),
 		directpvtypes.AccessTierDefault,
 	)
+	if driveLVM != nil {
+		drive.SetLabel(directpvtypes.BackendLabelKey, directpvtypes.LabelValue(backend))
+	}
 	if _, err = client.DriveClient().Create(context.Background(), drive, metav1.CreateOptions{}); err != nil {
 		return fmt.Errorf("unable to create Drive CRD; %w", err)
 	}
+
+	// Journal is written right away so that the drive is self-describing even if no volume is staged on it.
+	if jerr := handler.writeJournal(drive); jerr != nil {
+		klog.ErrorS(jerr, "unable to write drive journal", "drive", drive.GetDriveID())
+	}
 	return nil
 }
 
 // StartController starts initrequest controller.
-func StartController(ctx context.Context, nodeID directpvtypes.NodeID, identity, rack, zone, region string) {
-	initRequestHandler, err := newInitRequestEventHandler(
-		ctx,
-		nodeID,
-		map[string]string{
-			string(directpvtypes.TopologyDriverIdentity): identity,
-			string(directpvtypes.TopologyDriverRack):     rack,
-			string(directpvtypes.TopologyDriverZone):     zone,
-			string(directpvtypes.TopologyDriverRegion):   region,
-			string(directpvtypes.TopologyDriverNode):     string(nodeID),
-		},
-	)
+func StartController(ctx context.Context, nodeID directpvtypes.NodeID, resolver *topology.Resolver) {
+	initRequestHandler, err := newInitRequestEventHandler(ctx, nodeID, resolver.Get)
 	if err != nil {
 		klog.ErrorS(err, "unable to create initrequest event handler")
 		return
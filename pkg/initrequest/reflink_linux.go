@@ -51,7 +51,7 @@ func reflinkSupported(ctx context.Context) (bool, error) {
 			return err
 		}
 
-		if _, _, _, _, err = xfs.MakeFS(ctx, file.Name(), uuid.New().String(), false, reflink); err != nil {
+		if _, _, _, _, err = xfs.MakeFS(ctx, file.Name(), uuid.New().String(), false, reflink, xfs.Profile{}); err != nil {
 			return err
 		}
 
@@ -0,0 +1,205 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package journal manages the metadata journal stored on each drive. The journal describes the drive
+// and its volumes so that the drive is self-describing when it is moved to another node or the
+// cluster state is lost.
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Version is the version of the journal format.
+const Version = "v1"
+
+var errUnsupportedVersion = errors.New("unsupported journal version")
+
+// Volume denotes a volume entry of the journal.
+type Volume struct {
+	Name         string `json:"name"`
+	Size         int64  `json:"size"`
+	ProjectID    uint32 `json:"projectID"`
+	PVCName      string `json:"pvcName,omitempty"`
+	PVCNamespace string `json:"pvcNamespace,omitempty"`
+}
+
+// Journal denotes the metadata of a drive and its volumes.
+type Journal struct {
+	Version    string                   `json:"version"`
+	DriveID    directpvtypes.DriveID    `json:"driveID"`
+	NodeID     directpvtypes.NodeID     `json:"nodeID"`
+	FSUUID     string                   `json:"fsuuid"`
+	FSType     string                   `json:"fsType"`
+	AccessTier directpvtypes.AccessTier `json:"accessTier"`
+	Labels     map[string]string        `json:"labels,omitempty"`
+	LVM        *types.DriveLVM          `json:"lvm,omitempty"`
+	Volumes    []Volume                 `json:"volumes,omitempty"`
+	UpdatedAt  time.Time                `json:"updatedAt"`
+}
+
+// New creates a journal of the drive with the volumes on it; volumes not held by the drive are ignored.
+func New(drive *types.Drive, volumes []types.Volume) *Journal {
+	journal := &Journal{
+		Version:    Version,
+		DriveID:    drive.GetDriveID(),
+		NodeID:     drive.GetNodeID(),
+		FSUUID:     drive.Status.FSUUID,
+		FSType:     drive.GetFSType(),
+		AccessTier: drive.GetAccessTier(),
+		LVM:        drive.Status.LVM,
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	for key, value := range drive.GetLabels() {
+		if !directpvtypes.LabelKey(key).IsReserved() {
+			if journal.Labels == nil {
+				journal.Labels = map[string]string{}
+			}
+			journal.Labels[key] = value
+		}
+	}
+
+	for _, volume := range volumes {
+		if !drive.VolumeExist(volume.Name) {
+			continue
+		}
+		journal.Volumes = append(journal.Volumes, Volume{
+			Name:         volume.Name,
+			Size:         volume.Status.TotalCapacity,
+			ProjectID:    sys.GetProjectIDHash(volume.Name),
+			PVCName:      volume.GetPVCName(),
+			PVCNamespace: volume.GetPVCNamespace(),
+		})
+	}
+	slices.SortFunc(journal.Volumes, func(a, b Volume) int { return strings.Compare(a.Name, b.Name) })
+
+	return journal
+}
+
+func read(filename string) (*Journal, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var journal Journal
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return nil, err
+	}
+	if journal.Version != Version {
+		return nil, fmt.Errorf("%w %v", errUnsupportedVersion, journal.Version)
+	}
+	return &journal, nil
+}
+
+// Read reads the journal of the mounted drive by its FSUUID; nil is returned if no journal exists.
+func Read(fsuuid string) (*Journal, error) {
+	return read(types.GetDriveJournalFile(fsuuid))
+}
+
+func (journal *Journal) write(filename string) error {
+	data, err := utils.ToJSON(journal)
+	if err != nil {
+		return err
+	}
+
+	file, err := utils.NewSafeFile(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	return file.Close()
+}
+
+// Write writes the journal atomically to the mounted drive.
+func (journal *Journal) Write() error {
+	// The volume root symlink ensures the drive is mounted before creating the meta directory.
+	if _, err := os.Lstat(types.GetVolumeRootDir(journal.FSUUID)); err != nil {
+		return fmt.Errorf("drive %v is not mounted; %w", journal.DriveID, err)
+	}
+	if err := sys.Mkdir(types.GetDriveMetaDir(journal.FSUUID), 0o750); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return journal.write(types.GetDriveJournalFile(journal.FSUUID))
+}
+
+// Remove removes the journal from the mounted drive.
+func Remove(fsuuid string) error {
+	if err := os.Remove(types.GetDriveJournalFile(fsuuid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Update writes the journal of the drive with its current volumes.
+func Update(ctx context.Context, driveID directpvtypes.DriveID) error {
+	drive, err := client.DriveClient().Get(ctx, string(driveID), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return err
+	}
+
+	volumes, err := client.NewVolumeLister().
+		DriveIDSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(driveID))}).
+		Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return New(drive, volumes).Write()
+}
+
+// driveChanged returns whether the drive metadata in the journal differs from the drive.
+func (journal *Journal) driveChanged(drive *types.Drive) bool {
+	expected := New(drive, nil)
+	return journal.NodeID != expected.NodeID ||
+		journal.FSType != expected.FSType ||
+		journal.AccessTier != expected.AccessTier ||
+		!maps.Equal(journal.Labels, expected.Labels)
+}
+
+// Sync updates the journal of the drive if it does not exist or its drive metadata e.g. labels
+// and access-tier differs from the drive.
+func Sync(ctx context.Context, drive *types.Drive) error {
+	journal, err := Read(drive.Status.FSUUID)
+	if err != nil {
+		return err
+	}
+	if journal != nil && !journal.driveChanged(drive) {
+		return nil
+	}
+	return Update(ctx, drive.GetDriveID())
+}
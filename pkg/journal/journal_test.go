@@ -0,0 +1,105 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/types"
+)
+
+func TestNew(t *testing.T) {
+	drive := types.NewDrive("drive-1", types.DriveStatus{FSUUID: "fsuuid-1"}, "node-1", "sda", directpvtypes.AccessTierHot)
+	drive.SetLabel("example.org/rack", "rack-1")
+	drive.AddVolumeFinalizer("volume-2")
+	drive.AddVolumeFinalizer("volume-1")
+
+	volume1 := types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10)
+	volume1.SetPVCName("data-0")
+	volume1.SetPVCNamespace("tenant-1")
+	volume2 := types.NewVolume("volume-2", "fsuuid-1", "node-1", "drive-1", "sda", 20)
+	// Released volume is not held by the drive.
+	volume3 := types.NewVolume("volume-3", "fsuuid-1", "node-1", "drive-1", "sda", 30)
+
+	journal := New(drive, []types.Volume{*volume2, *volume3, *volume1})
+	if journal.DriveID != "drive-1" || journal.NodeID != "node-1" || journal.FSUUID != "fsuuid-1" || journal.FSType != "xfs" || journal.AccessTier != directpvtypes.AccessTierHot {
+		t.Fatalf("unexpected journal %+v", journal)
+	}
+	if !reflect.DeepEqual(journal.Labels, map[string]string{"example.org/rack": "rack-1"}) {
+		t.Fatalf("unexpected labels %v", journal.Labels)
+	}
+	if len(journal.Volumes) != 2 || journal.Volumes[0].Name != "volume-1" || journal.Volumes[1].Name != "volume-2" {
+		t.Fatalf("unexpected volumes %+v", journal.Volumes)
+	}
+	if journal.Volumes[0].Size != 10 || journal.Volumes[0].PVCName != "data-0" || journal.Volumes[0].PVCNamespace != "tenant-1" || journal.Volumes[0].ProjectID == 0 {
+		t.Fatalf("unexpected volume %+v", journal.Volumes[0])
+	}
+}
+
+func TestDriveChanged(t *testing.T) {
+	drive := types.NewDrive("drive-1", types.DriveStatus{FSUUID: "fsuuid-1"}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive.SetLabel("example.org/rack", "rack-1")
+	journal := New(drive, nil)
+	if journal.driveChanged(drive) {
+		t.Fatalf("journal must match the drive")
+	}
+
+	drive.SetLabel(directpvtypes.AccessTierLabelKey, directpvtypes.LabelValue(directpvtypes.AccessTierHot))
+	if !journal.driveChanged(drive) {
+		t.Fatalf("access-tier change must be detected")
+	}
+
+	journal = New(drive, nil)
+	drive.SetLabel("example.org/rack", "rack-2")
+	if !journal.driveChanged(drive) {
+		t.Fatalf("label change must be detected")
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "journal.json")
+
+	journal, err := read(filename)
+	if journal != nil || err != nil {
+		t.Fatalf("expected: <nil>, <nil>; got: %v, %v", journal, err)
+	}
+
+	drive := types.NewDrive("drive-1", types.DriveStatus{FSUUID: "fsuuid-1"}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive.AddVolumeFinalizer("volume-1")
+	expected := New(drive, []types.Volume{*types.NewVolume("volume-1", "fsuuid-1", "node-1", "drive-1", "sda", 10)})
+	if err := expected.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	if journal, err = read(filename); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(journal, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, journal)
+	}
+
+	if err := os.WriteFile(filename, []byte(`{"version":"v0"}`), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := read(filename); !errors.Is(err, errUnsupportedVersion) {
+		t.Fatalf("expected: %v, got: %v", errUnsupportedVersion, err)
+	}
+}
@@ -0,0 +1,142 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package luks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretKeyProvider is the name of the default key provider using Kubernetes Secrets.
+const SecretKeyProvider = "secret"
+
+// SecretKey is the data key of the Secret having the master key.
+const SecretKey = "key"
+
+// KeyProvider provides encryption keys of LUKS2 devices. A KMS is
+// plugged in by implementing this interface and registering it by
+// RegisterKeyProvider().
+type KeyProvider interface {
+	// GetKey returns the key of the device of LUKS UUID by key reference.
+	GetKey(ctx context.Context, keyRef, luksUUID string) ([]byte, error)
+}
+
+var (
+	keyProviders   = map[string]KeyProvider{SecretKeyProvider: secretKeyProvider{}}
+	keyProvidersMu sync.RWMutex
+)
+
+// RegisterKeyProvider registers a key provider by name.
+func RegisterKeyProvider(name string, provider KeyProvider) error {
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+
+	if _, found := keyProviders[name]; found {
+		return fmt.Errorf("key provider %v already registered", name)
+	}
+	keyProviders[name] = provider
+	return nil
+}
+
+// KeyProviderNames returns names of registered key providers.
+func KeyProviderNames() (names []string) {
+	keyProvidersMu.RLock()
+	defer keyProvidersMu.RUnlock()
+
+	for name := range keyProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetKey returns the key from named key provider; empty name denotes SecretKeyProvider.
+func GetKey(ctx context.Context, providerName, keyRef, luksUUID string) ([]byte, error) {
+	if providerName == "" {
+		providerName = SecretKeyProvider
+	}
+
+	keyProvidersMu.RLock()
+	provider, found := keyProviders[providerName]
+	keyProvidersMu.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("unknown key provider %v", providerName)
+	}
+
+	key, err := provider.GetKey(ctx, keyRef, luksUUID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get key from key provider %v; %w", providerName, err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("empty key from key provider %v", providerName)
+	}
+	return key, nil
+}
+
+// ParseSecretRef parses key reference of SecretKeyProvider in
+// [NAMESPACE/]NAME format; default namespace is DirectPV namespace.
+func ParseSecretRef(keyRef string) (namespace, name string, err error) {
+	tokens := strings.Split(keyRef, "/")
+	switch {
+	case len(tokens) == 1 && tokens[0] != "":
+		return consts.AppName, tokens[0], nil
+	case len(tokens) == 2 && tokens[0] != "" && tokens[1] != "":
+		return tokens[0], tokens[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid secret reference %v", keyRef)
+	}
+}
+
+// secretKeyProvider derives per-device key from the master key in a Secret
+// so that compromising one device key does not expose other devices.
+type secretKeyProvider struct{}
+
+func (secretKeyProvider) GetKey(ctx context.Context, keyRef, luksUUID string) ([]byte, error) {
+	namespace, name, err := ParseSecretRef(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := k8s.KubeClient().CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey := secret.Data[SecretKey]
+	if len(masterKey) == 0 {
+		return nil, errors.New("no " + SecretKey + " found in secret " + namespace + "/" + name)
+	}
+
+	return deriveKey(masterKey, luksUUID), nil
+}
+
+func deriveKey(masterKey []byte, luksUUID string) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(luksUUID))
+	return []byte(hex.EncodeToString(mac.Sum(nil)))
+}
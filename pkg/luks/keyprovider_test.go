@@ -0,0 +1,88 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package luks
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	testCases := []struct {
+		keyRef            string
+		expectedNamespace string
+		expectedName      string
+		expectErr         bool
+	}{
+		{"luks-key", "directpv", "luks-key", false},
+		{"tenant-1/luks-key", "tenant-1", "luks-key", false},
+		{"", "", "", true},
+		{"tenant-1/", "", "", true},
+		{"/luks-key", "", "", true},
+		{"a/b/c", "", "", true},
+	}
+
+	for i, testCase := range testCases {
+		namespace, name, err := ParseSecretRef(testCase.keyRef)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectErr, err)
+		}
+		if namespace != testCase.expectedNamespace || name != testCase.expectedName {
+			t.Fatalf("case %v: expected: %v/%v; got: %v/%v", i+1, testCase.expectedNamespace, testCase.expectedName, namespace, name)
+		}
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	key1 := deriveKey([]byte("master-key"), "6a1b3d2e-47c8-4a2f-9f0e-1c2d3e4f5a6b")
+	key2 := deriveKey([]byte("master-key"), "6a1b3d2e-47c8-4a2f-9f0e-1c2d3e4f5a6b")
+	key3 := deriveKey([]byte("master-key"), "2dc39938-8a84-4078-abec-159bfae4aa0f")
+	if !bytes.Equal(key1, key2) {
+		t.Fatalf("expected same key for same LUKS UUID")
+	}
+	if bytes.Equal(key1, key3) {
+		t.Fatalf("expected different keys for different LUKS UUIDs")
+	}
+}
+
+type testKeyProvider struct{}
+
+func (testKeyProvider) GetKey(ctx context.Context, keyRef, luksUUID string) ([]byte, error) {
+	return []byte(keyRef + ":" + luksUUID), nil
+}
+
+func TestGetKey(t *testing.T) {
+	if err := RegisterKeyProvider("test", testKeyProvider{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterKeyProvider(SecretKeyProvider, testKeyProvider{}); err == nil {
+		t.Fatalf("expected error on registering existing key provider")
+	}
+
+	key, err := GetKey(context.Background(), "test", "ref", "uuid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(key) != "ref:uuid" {
+		t.Fatalf("expected: ref:uuid; got: %s", key)
+	}
+
+	if _, err = GetKey(context.Background(), "unknown", "ref", "uuid"); err == nil {
+		t.Fatalf("expected error for unknown key provider")
+	}
+}
@@ -0,0 +1,53 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package luks formats and opens LUKS2 encrypted devices using cryptsetup.
+package luks
+
+import (
+	"context"
+	"path"
+
+	"github.com/minio/directpv/pkg/consts"
+)
+
+// MapperDir is the directory of dm-crypt mapped devices.
+const MapperDir = "/dev/mapper"
+
+// MapperName returns dm-crypt mapping name of given drive ID.
+func MapperName(driveID string) string {
+	return consts.AppName + "-" + driveID
+}
+
+// MapperPath returns path of the dm-crypt mapped device.
+func MapperPath(name string) string {
+	return path.Join(MapperDir, name)
+}
+
+// Format formats the device as LUKS2 with given UUID and key.
+func Format(ctx context.Context, device, uuid string, key []byte) error {
+	return format(ctx, device, uuid, key)
+}
+
+// Open opens the LUKS2 device with given key as dm-crypt mapping name; opened mapping is left as is.
+func Open(ctx context.Context, device, name string, key []byte) error {
+	return open(ctx, device, name, key)
+}
+
+// Close closes the dm-crypt mapping name; closed mapping is ignored.
+func Close(ctx context.Context, name string) error {
+	return closeMapping(ctx, name)
+}
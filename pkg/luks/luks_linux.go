@@ -0,0 +1,72 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package luks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func cryptsetup(ctx context.Context, key []byte, args ...string) error {
+	cmd := exec.CommandContext(ctx, "cryptsetup", args...)
+	if key != nil {
+		cmd.Stdin = bytes.NewReader(key)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf(
+			"unable to execute command %v; output=%v; error=%w",
+			append([]string{"cryptsetup"}, args...), string(output), err,
+		)
+	}
+	return nil
+}
+
+func exists(name string) (bool, error) {
+	if _, err := os.Stat(MapperPath(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func format(ctx context.Context, device, uuid string, key []byte) error {
+	return cryptsetup(ctx, key, "luksFormat", "--type", "luks2", "--batch-mode", "--uuid", uuid, "--key-file", "-", device)
+}
+
+func open(ctx context.Context, device, name string, key []byte) error {
+	found, err := exists(name)
+	if err != nil || found {
+		return err
+	}
+	return cryptsetup(ctx, key, "open", "--type", "luks2", "--key-file", "-", device, name)
+}
+
+func closeMapping(ctx context.Context, name string) error {
+	found, err := exists(name)
+	if err != nil || !found {
+		return err
+	}
+	return cryptsetup(ctx, nil, "close", name)
+}
@@ -0,0 +1,94 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package lvm manages LVM thin pools of drives and thin logical volumes of volumes using LVM2 tools.
+package lvm
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/minio/directpv/pkg/consts"
+)
+
+const (
+	// MetaLVName is the name of the logical volume having the drive filesystem.
+	MetaLVName = "meta"
+
+	// MetaLVSize is the size of the logical volume having the drive filesystem.
+	MetaLVSize = 1024 * 1024 * 1024 // 1 GiB
+
+	// ThinPoolName is the name of the thin pool of volumes.
+	ThinPoolName = "pool"
+)
+
+// VGName returns volume group name of given drive ID.
+func VGName(driveID string) string {
+	return consts.AppName + "-" + driveID
+}
+
+// Path returns device path of the logical volume.
+func Path(vgName, lvName string) string {
+	return path.Join("/dev", vgName, lvName)
+}
+
+func toSizeArg(size int64) string {
+	return strconv.FormatInt(size, 10) + "b"
+}
+
+func parseSize(output string) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+}
+
+// CreateThinPool creates a volume group on the device with the metadata logical volume and a thin
+// pool of the remaining space. The size of the thin pool is returned.
+func CreateThinPool(ctx context.Context, device, vgName string) (int64, error) {
+	return createThinPool(ctx, device, vgName)
+}
+
+// CreateThinVolume creates a thin logical volume of given size in the thin pool; existing logical volume is left as is.
+func CreateThinVolume(ctx context.Context, vgName, thinPool, name string, size int64) error {
+	return createThinVolume(ctx, vgName, thinPool, name, size)
+}
+
+// CreateSnapshot creates a thin snapshot of the thin logical volume; existing logical volume is left as is.
+// The snapshot is not activated as its filesystem has the same UUID of the origin.
+func CreateSnapshot(ctx context.Context, vgName, origin, name string) error {
+	return createSnapshot(ctx, vgName, origin, name)
+}
+
+// CreateVolumeFromSnapshot creates an active thin logical volume from the snapshot; existing logical
+// volume is left as is.
+func CreateVolumeFromSnapshot(ctx context.Context, vgName, snapshot, name string) error {
+	return createVolumeFromSnapshot(ctx, vgName, snapshot, name)
+}
+
+// ExtendVolume extends the logical volume to given size; logical volume of larger size is left as is.
+func ExtendVolume(ctx context.Context, vgName, name string, size int64) error {
+	return extendVolume(ctx, vgName, name, size)
+}
+
+// RemoveVolume removes the logical volume; removed logical volume is ignored.
+func RemoveVolume(ctx context.Context, vgName, name string) error {
+	return removeVolume(ctx, vgName, name)
+}
+
+// Exists returns whether the logical volume exists.
+func Exists(ctx context.Context, vgName, name string) (bool, error) {
+	return exists(ctx, vgName, name)
+}
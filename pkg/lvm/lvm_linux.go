@@ -0,0 +1,123 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lvm
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf(
+			"unable to execute command %v; output=%v; error=%w",
+			append([]string{name}, args...), string(output), err,
+		)
+	}
+	return string(output), nil
+}
+
+func getSize(ctx context.Context, vgName, name string) (int64, error) {
+	output, err := run(ctx, "lvs", "--noheadings", "--nosuffix", "--units", "b", "-o", "lv_size", vgName+"/"+name)
+	if err != nil {
+		return 0, err
+	}
+	size, err := parseSize(output)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse size of logical volume %v/%v; %w", vgName, name, err)
+	}
+	return size, nil
+}
+
+func exists(ctx context.Context, vgName, name string) (bool, error) {
+	output, err := run(ctx, "lvs", "--noheadings", "-o", "lv_name", vgName)
+	if err != nil {
+		return false, err
+	}
+	for _, lvName := range strings.Fields(output) {
+		if lvName == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func createThinPool(ctx context.Context, device, vgName string) (int64, error) {
+	if _, err := run(ctx, "pvcreate", "--force", "--force", "--yes", device); err != nil {
+		return 0, err
+	}
+	if _, err := run(ctx, "vgcreate", "--yes", vgName, device); err != nil {
+		return 0, err
+	}
+	if _, err := run(ctx, "lvcreate", "--yes", "--size", toSizeArg(MetaLVSize), "--name", MetaLVName, vgName); err != nil {
+		return 0, err
+	}
+	if _, err := run(ctx, "lvcreate", "--yes", "--extents", "100%FREE", "--thinpool", ThinPoolName, vgName); err != nil {
+		return 0, err
+	}
+	return getSize(ctx, vgName, ThinPoolName)
+}
+
+func createThinVolume(ctx context.Context, vgName, thinPool, name string, size int64) error {
+	found, err := exists(ctx, vgName, name)
+	if err != nil || found {
+		return err
+	}
+	_, err = run(ctx, "lvcreate", "--yes", "--virtualsize", toSizeArg(size), "--thin", vgName+"/"+thinPool, "--name", name)
+	return err
+}
+
+func createSnapshot(ctx context.Context, vgName, origin, name string) error {
+	found, err := exists(ctx, vgName, name)
+	if err != nil || found {
+		return err
+	}
+	_, err = run(ctx, "lvcreate", "--yes", "--snapshot", "--setactivationskip", "y", "--name", name, vgName+"/"+origin)
+	return err
+}
+
+func createVolumeFromSnapshot(ctx context.Context, vgName, snapshot, name string) error {
+	found, err := exists(ctx, vgName, name)
+	if err != nil || found {
+		return err
+	}
+	_, err = run(ctx, "lvcreate", "--yes", "--snapshot", "--setactivationskip", "n", "--activate", "y", "--name", name, vgName+"/"+snapshot)
+	return err
+}
+
+func extendVolume(ctx context.Context, vgName, name string, size int64) error {
+	currentSize, err := getSize(ctx, vgName, name)
+	if err != nil || currentSize >= size {
+		return err
+	}
+	_, err = run(ctx, "lvextend", "--size", toSizeArg(size), vgName+"/"+name)
+	return err
+}
+
+func removeVolume(ctx context.Context, vgName, name string) error {
+	found, err := exists(ctx, vgName, name)
+	if err != nil || !found {
+		return err
+	}
+	_, err = run(ctx, "lvremove", "--yes", vgName+"/"+name)
+	return err
+}
@@ -0,0 +1,53 @@
+//go:build !linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lvm
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+func createThinPool(_ context.Context, _, _ string) (int64, error) {
+	return 0, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func createThinVolume(_ context.Context, _, _, _ string, _ int64) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func createSnapshot(_ context.Context, _, _, _ string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func createVolumeFromSnapshot(_ context.Context, _, _, _ string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func extendVolume(_ context.Context, _, _ string, _ int64) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func removeVolume(_ context.Context, _, _ string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func exists(_ context.Context, _, _ string) (bool, error) {
+	return false, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
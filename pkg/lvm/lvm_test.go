@@ -0,0 +1,43 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lvm
+
+import "testing"
+
+func TestNames(t *testing.T) {
+	vgName := VGName("0f9d1b58-6c5e-4a3b-9d0e-3f2c1b7a8e64")
+	if vgName != "directpv-0f9d1b58-6c5e-4a3b-9d0e-3f2c1b7a8e64" {
+		t.Fatalf("unexpected volume group name %v", vgName)
+	}
+	if path := Path(vgName, "pvc-1"); path != "/dev/directpv-0f9d1b58-6c5e-4a3b-9d0e-3f2c1b7a8e64/pvc-1" {
+		t.Fatalf("unexpected logical volume path %v", path)
+	}
+}
+
+func TestSize(t *testing.T) {
+	if arg := toSizeArg(MetaLVSize); arg != "1073741824b" {
+		t.Fatalf("unexpected size argument %v", arg)
+	}
+
+	size, err := parseSize("  10733223936\n")
+	if err != nil || size != 10733223936 {
+		t.Fatalf("unexpected size %v; %v", size, err)
+	}
+	if _, err := parseSize("10.00g"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -24,10 +24,10 @@ import (
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/device"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/utils"
-	"github.com/minio/directpv/pkg/xfs"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog/v2"
 )
@@ -67,7 +67,8 @@ type metricsCollector struct {
 	nodeID            directpvtypes.NodeID
 	desc              *prometheus.Desc
 	getDeviceByFSUUID func(fsuuid string) (string, error)
-	getQuota          func(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error)
+	getQuota          func(ctx context.Context, fsType, device, volumeID string) (quota *fs.Quota, err error)
+	getFSUsage        func(mountPoint string) (total, used, available uint64, err error)
 }
 
 func newMetricsCollector(nodeID directpvtypes.NodeID) *metricsCollector {
@@ -75,7 +76,8 @@ func newMetricsCollector(nodeID directpvtypes.NodeID) *metricsCollector {
 		nodeID:            nodeID,
 		desc:              prometheus.NewDesc(consts.AppName+"_stats", "Statistics exposed by "+consts.AppPrettyName, nil, nil),
 		getDeviceByFSUUID: sys.GetDeviceByFSUUID,
-		getQuota:          xfs.GetQuota,
+		getQuota:          fs.GetQuota,
+		getFSUsage:        sys.GetFSUsage,
 	}
 }
 
@@ -84,7 +86,21 @@ func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.desc
 }
 
-func (c *metricsCollector) publishVolumeStats(ctx context.Context, volume *types.Volume, ch chan<- prometheus.Metric) {
+func (c *metricsCollector) publishVolumeStats(ctx context.Context, volume *types.Volume, fsTypes map[directpvtypes.DriveID]string, ch chan<- prometheus.Metric) {
+	if volume.GetBackend() == directpvtypes.BackendLVMThin {
+		// Thin volume has its own filesystem without project quota.
+		if volume.Status.StagingTargetPath == "" {
+			return
+		}
+		_, used, _, err := c.getFSUsage(volume.Status.StagingTargetPath)
+		if err != nil {
+			klog.ErrorS(err, "unable to get filesystem usage of thin volume", "volume", volume.Name)
+			return
+		}
+		c.sendVolumeStats(volume, used, ch)
+		return
+	}
+
 	device, err := c.getDeviceByFSUUID(volume.Status.FSUUID)
 	if err != nil {
 		klog.ErrorS(
@@ -102,12 +118,21 @@ func (c *metricsCollector) publishVolumeStats(ctx context.Context, volume *types
 				" on the host to reload", volume.Status.FSUUID)
 		return
 	}
-	quota, err := c.getQuota(ctx, device, volume.Name)
+	fsType, found := fsTypes[volume.GetDriveID()]
+	if !found {
+		klog.V(5).InfoS("drive of volume not found", "volume", volume.Name, "drive", volume.GetDriveID())
+		return
+	}
+	quota, err := c.getQuota(ctx, fsType, device, volume.Name)
 	if err != nil {
 		klog.ErrorS(err, "unable to get quota information", "volume", volume.Name)
 		return
 	}
 
+	c.sendVolumeStats(volume, quota.CurrentSpace, ch)
+}
+
+func (c *metricsCollector) sendVolumeStats(volume *types.Volume, usedBytes uint64, ch chan<- prometheus.Metric) {
 	tenantName := volume.GetTenantName()
 
 	ch <- prometheus.MustNewConstMetric(
@@ -116,7 +141,7 @@ func (c *metricsCollector) publishVolumeStats(ctx context.Context, volume *types
 			"Total number of bytes used by the volume",
 			[]string{"tenant", "volumeID", "node"}, nil),
 		prometheus.GaugeValue,
-		float64(quota.CurrentSpace), tenantName, volume.Name, string(volume.GetNodeID()),
+		float64(usedBytes), tenantName, volume.Name, string(volume.GetNodeID()),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -224,29 +249,31 @@ func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
 
-	// Collecting volume statistics
-	volumeResultCh := client.NewVolumeLister().
+	// Collecting drive statistics
+	fsTypes := map[directpvtypes.DriveID]string{}
+	driveResultCh := client.NewDriveLister().
 		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(c.nodeID))}).
 		List(ctx)
-	for result := range volumeResultCh {
+	for result := range driveResultCh {
 		if result.Err != nil {
 			break
 		}
 
-		if result.Volume.Status.TargetPath != "" {
-			c.publishVolumeStats(ctx, &result.Volume, ch)
-		}
+		fsTypes[result.Drive.GetDriveID()] = result.Drive.GetFSType()
+		c.publishDriveStats(&result.Drive, ch)
 	}
 
-	// Collecting drive statistics
-	driveResultCh := client.NewDriveLister().
+	// Collecting volume statistics
+	volumeResultCh := client.NewVolumeLister().
 		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(c.nodeID))}).
 		List(ctx)
-	for result := range driveResultCh {
+	for result := range volumeResultCh {
 		if result.Err != nil {
 			break
 		}
 
-		c.publishDriveStats(&result.Drive, ch)
+		if result.Volume.Status.TargetPath != "" {
+			c.publishVolumeStats(ctx, &result.Volume, fsTypes, ch)
+		}
 	}
 }
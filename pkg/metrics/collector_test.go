@@ -23,11 +23,12 @@ import (
 	"testing"
 	"time"
 
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
 	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/types"
-	"github.com/minio/directpv/pkg/xfs"
 	"github.com/prometheus/client_golang/prometheus"
 	clientmodelgo "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,17 +64,20 @@ func createFakeMetricsCollector() *metricsCollector {
 		desc:              prometheus.NewDesc(consts.AppName+"_stats", "Statistics exposed by "+consts.AppPrettyName, nil, nil),
 		nodeID:            "test-node-1",
 		getDeviceByFSUUID: func(_ string) (string, error) { return "", nil },
-		getQuota: func(_ context.Context, _, volumeID string) (quota *xfs.Quota, err error) {
+		getQuota: func(_ context.Context, _, _, volumeID string) (quota *fs.Quota, err error) {
 			for _, volume := range volumes {
 				if volume.Name == volumeID {
-					return &xfs.Quota{
+					return &fs.Quota{
 						HardLimit:    uint64(volume.Status.TotalCapacity),
 						SoftLimit:    uint64(volume.Status.TotalCapacity),
 						CurrentSpace: uint64(volume.Status.UsedCapacity),
 					}, nil
 				}
 			}
-			return &xfs.Quota{}, nil
+			return &fs.Quota{}, nil
+		},
+		getFSUsage: func(_ string) (total, used, available uint64, err error) {
+			return 0, 0, 0, nil
 		},
 	}
 }
@@ -169,11 +173,12 @@ func TestVolumeStatsEmitter(t *testing.T) {
 		}
 	}()
 
-	fmc.publishVolumeStats(ctx, &volumes[0], metricChan)
+	fsTypes := map[directpvtypes.DriveID]string{"test-drive-1": fs.XFS}
+	fmc.publishVolumeStats(ctx, &volumes[0], fsTypes, metricChan)
 	if failed {
 		t.Fatalf("publish volume stats failed for %v", volumes[0].Name)
 	}
-	fmc.publishVolumeStats(ctx, &volumes[1], metricChan)
+	fmc.publishVolumeStats(ctx, &volumes[1], fsTypes, metricChan)
 	if failed {
 		t.Fatalf("publish volume stats failed for %v", volumes[1].Name)
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/device"
 	"github.com/minio/directpv/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,7 +68,10 @@ func (handler *nodeEventHandler) Handle(ctx context.Context, eventType controlle
 	case controller.UpdateEvent, controller.AddEvent:
 		node := object.(*types.Node)
 		if node.Spec.Refresh {
-			return Sync(ctx, directpvtypes.NodeID(node.Name))
+			if err := Sync(ctx, directpvtypes.NodeID(node.Name)); err != nil {
+				return err
+			}
+			return device.SyncDevices(ctx, directpvtypes.NodeID(node.Name))
 		}
 	default:
 	}
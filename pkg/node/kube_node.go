@@ -0,0 +1,209 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// KubeNodePolicy denotes how changes of Kubernetes nodes are reflected to DirectPV objects.
+type KubeNodePolicy struct {
+	// MarkDrivesLost marks the drives of deleted nodes as lost.
+	MarkDrivesLost bool
+	// MirrorUnschedulable cordons/uncordons the drives as their nodes are cordoned/uncordoned.
+	MirrorUnschedulable bool
+	// RemoveOrphanNodes removes DirectPVNode objects of deleted nodes.
+	RemoveOrphanNodes bool
+}
+
+type kubeNodeEventHandler struct {
+	policy KubeNodePolicy
+}
+
+func (handler *kubeNodeEventHandler) ListerWatcher() cache.ListerWatcher {
+	return cache.NewListWatchFromClient(
+		k8s.KubeClient().CoreV1().RESTClient(),
+		"nodes",
+		"",
+		fields.Everything(),
+	)
+}
+
+func (handler *kubeNodeEventHandler) ObjectType() runtime.Object {
+	return &corev1.Node{}
+}
+
+func (handler *kubeNodeEventHandler) Handle(ctx context.Context, eventType controller.EventType, object runtime.Object) error {
+	node := object.(*corev1.Node)
+	switch eventType {
+	case controller.AddEvent, controller.UpdateEvent:
+		if handler.policy.MirrorUnschedulable {
+			return handler.syncUnschedulable(ctx, node.Name, node.Spec.Unschedulable)
+		}
+	case controller.DeleteEvent:
+		return handler.handleDeletedNode(ctx, node.Name)
+	}
+	return nil
+}
+
+// syncUnschedulable cordons schedulable drives of unschedulable node and uncordons the drives cordoned by this
+// when the node becomes schedulable. Drives cordoned otherwise are left as is.
+func (handler *kubeNodeEventHandler) syncUnschedulable(ctx context.Context, nodeName string, unschedulable bool) error {
+	lister := client.NewDriveLister().NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(nodeName)})
+	if !unschedulable {
+		lister = lister.LabelSelector(map[directpvtypes.LabelKey]directpvtypes.LabelValue{directpvtypes.NodeUnschedulableLabelKey: "true"})
+	}
+	drives, err := lister.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range drives {
+		if unschedulable && drives[i].IsUnschedulable() {
+			continue
+		}
+
+		updateFunc := func() error {
+			drive, err := client.DriveClient().Get(ctx, drives[i].Name, metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			if unschedulable {
+				drive.Unschedulable()
+				drive.SetLabel(directpvtypes.NodeUnschedulableLabelKey, "true")
+			} else {
+				drive.Schedulable()
+				drive.RemoveLabel(directpvtypes.NodeUnschedulableLabelKey)
+			}
+			_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+			return err
+		}
+		if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			return err
+		}
+
+		if unschedulable {
+			client.Eventf(&drives[i], client.EventTypeNormal, client.EventReasonDriveCordoned, "node %v is unschedulable", nodeName)
+		} else {
+			client.Eventf(&drives[i], client.EventTypeNormal, client.EventReasonDriveUncordoned, "node %v is schedulable", nodeName)
+		}
+	}
+
+	return nil
+}
+
+// handleDeletedNode marks the drives of the deleted node as lost and removes its DirectPVNode object as per policy.
+func (handler *kubeNodeEventHandler) handleDeletedNode(ctx context.Context, nodeName string) error {
+	if handler.policy.MarkDrivesLost {
+		if err := markNodeDrivesLost(ctx, nodeName); err != nil {
+			return err
+		}
+	}
+
+	if handler.policy.RemoveOrphanNodes {
+		err := client.NodeClient().Delete(ctx, nodeName, metav1.DeleteOptions{})
+		switch {
+		case err == nil:
+			klog.InfoS("Removed orphan node", "node", nodeName)
+		case !apierrors.IsNotFound(err):
+			return err
+		}
+	}
+
+	return nil
+}
+
+func markNodeDrivesLost(ctx context.Context, nodeName string) error {
+	drives, err := client.NewDriveLister().
+		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(nodeName)}).
+		StatusSelector([]directpvtypes.DriveStatus{directpvtypes.DriveStatusReady}).
+		Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range drives {
+		updateFunc := func() error {
+			drive, err := client.DriveClient().Get(ctx, drives[i].Name, metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			drive.Status.Status = directpvtypes.DriveStatusLost
+			_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+			return err
+		}
+		if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			return err
+		}
+		client.Eventf(&drives[i], client.EventTypeWarning, client.EventReasonDriveLost, "node %v is deleted", nodeName)
+	}
+
+	return nil
+}
+
+// handleOrphanNodes handles DirectPVNode objects of the nodes deleted while the controller was not running.
+func (handler *kubeNodeEventHandler) handleOrphanNodes(ctx context.Context) error {
+	nodes, err := client.NewNodeLister().Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		_, err := k8s.KubeClient().CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err):
+			if err := handler.handleDeletedNode(ctx, node.Name); err != nil {
+				return fmt.Errorf("unable to handle deleted node %v; %w", node.Name, err)
+			}
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StartKubeNodeController starts Kubernetes node controller.
+func StartKubeNodeController(ctx context.Context, policy KubeNodePolicy) {
+	handler := &kubeNodeEventHandler{policy: policy}
+	if policy.MarkDrivesLost || policy.RemoveOrphanNodes {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := handler.handleOrphanNodes(ctx); err != nil {
+				klog.ErrorS(err, "unable to handle orphan nodes")
+			}
+		}, resyncPeriod)
+	}
+	ctrl := controller.New("kube-node", handler, workerThreads, resyncPeriod)
+	ctrl.Run(ctx)
+}
@@ -0,0 +1,109 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubeNodeEventHandler(t *testing.T) {
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{Status: directpvtypes.DriveStatusReady}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{Status: directpvtypes.DriveStatusReady}, "node-1", "sdb", directpvtypes.AccessTierDefault)
+	drive2.Unschedulable()
+	drive3 := types.NewDrive("drive-3", types.DriveStatus{Status: directpvtypes.DriveStatusReady}, "node-2", "sda", directpvtypes.AccessTierDefault)
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(
+		drive1, drive2, drive3, types.NewNode("node-1", nil), types.NewNode("node-2", nil), types.NewNode("node-3", nil),
+	))
+	client.SetNodeInterface(clientset.DirectpvLatest().DirectPVNodes())
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	k8s.SetKubeInterface(fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}},
+	))
+
+	ctx := t.Context()
+	isUnschedulable := func(name string) bool {
+		drive, err := client.DriveClient().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return drive.IsUnschedulable()
+	}
+
+	handler := &kubeNodeEventHandler{policy: KubeNodePolicy{MarkDrivesLost: true, MirrorUnschedulable: true, RemoveOrphanNodes: true}}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{Unschedulable: true}}
+	if err := handler.Handle(ctx, controller.UpdateEvent, node); err != nil {
+		t.Fatal(err)
+	}
+	if !isUnschedulable("drive-1") || !isUnschedulable("drive-2") {
+		t.Fatalf("drives of unschedulable node must be cordoned")
+	}
+
+	node.Spec.Unschedulable = false
+	if err := handler.Handle(ctx, controller.UpdateEvent, node); err != nil {
+		t.Fatal(err)
+	}
+	if isUnschedulable("drive-1") {
+		t.Fatalf("drive must be uncordoned")
+	}
+	// drive-2 was cordoned before its node became unschedulable, hence it is left cordoned.
+	if !isUnschedulable("drive-2") {
+		t.Fatalf("drive cordoned before must not be uncordoned")
+	}
+
+	// node-2 is deleted while the controller was not running.
+	if err := handler.handleOrphanNodes(ctx); err != nil {
+		t.Fatal(err)
+	}
+	drive, err := client.DriveClient().Get(ctx, "drive-3", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.Status != directpvtypes.DriveStatusLost {
+		t.Fatalf("expected: %v, got: %v", directpvtypes.DriveStatusLost, drive.Status.Status)
+	}
+	if _, err = client.NodeClient().Get(ctx, "node-2", metav1.GetOptions{}); err == nil {
+		t.Fatalf("orphan node must be removed")
+	}
+	if _, err = client.NodeClient().Get(ctx, "node-3", metav1.GetOptions{}); err != nil {
+		t.Fatalf("node must not be removed; %v", err)
+	}
+
+	if err = handler.Handle(ctx, controller.DeleteEvent, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if drive, err = client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.Status != directpvtypes.DriveStatusLost {
+		t.Fatalf("expected: %v, got: %v", directpvtypes.DriveStatusLost, drive.Status.Status)
+	}
+	if _, err = client.NodeClient().Get(ctx, "node-1", metav1.GetOptions{}); err == nil {
+		t.Fatalf("node of deleted Kubernetes node must be removed")
+	}
+}
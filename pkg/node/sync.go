@@ -18,14 +18,19 @@ package node
 
 import (
 	"context"
+	"errors"
+	"os"
+	"strings"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/device"
+	"github.com/minio/directpv/pkg/fs"
 	"github.com/minio/directpv/pkg/types"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
 )
 
 func probeDevices(nodeID directpvtypes.NodeID) ([]types.Device, error) {
@@ -40,12 +45,99 @@ func probeDevices(nodeID directpvtypes.NodeID) ([]types.Device, error) {
 	return nodeDevices, nil
 }
 
+// scanVolumeDirs returns volume directories, having no volume objects, found
+// on the drives of this node. Only the drives having DirectPVDrive objects are
+// scanned; volume directories on a drive without its drive object are not
+// reported.
+func scanVolumeDirs(ctx context.Context, nodeID directpvtypes.NodeID) ([]types.VolumeDir, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	type volumeDirEntry struct {
+		drive *types.Drive
+		name  string
+	}
+
+	var entries []volumeDirEntry
+	resultCh := client.NewDriveLister().
+		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(nodeID))}).
+		List(ctx)
+	for result := range resultCh {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		drive := result.Drive
+		if drive.Status.Status != directpvtypes.DriveStatusReady {
+			continue
+		}
+
+		dirEntries, err := os.ReadDir(types.GetVolumeRootDir(drive.Status.FSUUID))
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				klog.ErrorS(err, "unable to read volume root directory", "drive", drive.Name, "FSUUID", drive.Status.FSUUID)
+			}
+			continue
+		}
+
+		for _, dirEntry := range dirEntries {
+			if dirEntry.IsDir() && !strings.HasSuffix(dirEntry.Name(), ".deleted") {
+				entries = append(entries, volumeDirEntry{drive: &drive, name: dirEntry.Name()})
+			}
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// Volumes are listed after reading the directories; as a volume object is
+	// created prior to its directory, a managed volume directory is never
+	// reported.
+	volumes, err := client.NewVolumeLister().
+		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(nodeID))}).
+		Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	managed := make(map[string]struct{}, len(volumes))
+	for _, volume := range volumes {
+		managed[volume.Name] = struct{}{}
+	}
+
+	var volumeDirs []types.VolumeDir
+	for _, entry := range entries {
+		if _, found := managed[entry.name]; found {
+			continue
+		}
+
+		volumeDir := types.VolumeDir{
+			Name:    entry.name,
+			DriveID: entry.drive.GetDriveID(),
+			FSUUID:  entry.drive.Status.FSUUID,
+		}
+		quota, err := fs.GetQuota(ctx, entry.drive.GetFSType(), entry.drive.GetDevicePath(), entry.name)
+		if err != nil {
+			klog.ErrorS(err, "unable to get quota of volume directory", "drive", entry.drive.Name, "volume", entry.name)
+		} else {
+			volumeDir.Size = int64(quota.HardLimit)
+		}
+		volumeDirs = append(volumeDirs, volumeDir)
+	}
+
+	return volumeDirs, nil
+}
+
 // Sync probes the local devices and syncs the DirectPVNode CRD objects with the probed information.
 func Sync(ctx context.Context, nodeID directpvtypes.NodeID) error {
 	devices, err := probeDevices(nodeID)
 	if err != nil {
 		return err
 	}
+	volumeDirs, err := scanVolumeDirs(ctx, nodeID)
+	if err != nil {
+		return err
+	}
 	updateFunc := func() error {
 		nodeClient := client.NodeClient()
 		node, err := nodeClient.Get(ctx, string(nodeID), metav1.GetOptions{})
@@ -53,10 +145,13 @@ func Sync(ctx context.Context, nodeID directpvtypes.NodeID) error {
 			if !apierrors.IsNotFound(err) {
 				return err
 			}
-			_, err = nodeClient.Create(ctx, types.NewNode(nodeID, devices), metav1.CreateOptions{})
+			node := types.NewNode(nodeID, devices)
+			node.Status.VolumeDirs = volumeDirs
+			_, err = nodeClient.Create(ctx, node, metav1.CreateOptions{})
 			return err
 		}
 		node.Status.Devices = devices
+		node.Status.VolumeDirs = volumeDirs
 		node.Spec.Refresh = false
 		if _, err := nodeClient.Update(ctx, node, metav1.UpdateOptions{TypeMeta: types.NewNodeTypeMeta()}); err != nil {
 			return err
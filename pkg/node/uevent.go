@@ -0,0 +1,209 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/device"
+	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/uevent"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// mergeDevices replaces the devices of given major:minor numbers by the probed devices; it returns
+// the added and removed devices along with the merged devices.
+func mergeDevices(devices []types.Device, majorMinors []string, probed []types.Device) (added, removed, merged []types.Device) {
+	changed := map[string]struct{}{}
+	for _, majorMinor := range majorMinors {
+		changed[majorMinor] = struct{}{}
+	}
+	probedMap := map[string]types.Device{}
+	for _, device := range probed {
+		probedMap[device.MajorMinor] = device
+	}
+
+	existing := map[string]struct{}{}
+	for _, device := range devices {
+		if _, found := changed[device.MajorMinor]; !found {
+			merged = append(merged, device)
+			continue
+		}
+		existing[device.MajorMinor] = struct{}{}
+		if _, found := probedMap[device.MajorMinor]; !found {
+			removed = append(removed, device)
+		}
+	}
+
+	for _, device := range probed {
+		if _, found := existing[device.MajorMinor]; !found {
+			added = append(added, device)
+		}
+		merged = append(merged, device)
+	}
+
+	return added, removed, merged
+}
+
+type deviceEventHandler struct {
+	nodeID       directpvtypes.NodeID
+	probeDevices func(majorMinors ...string) ([]types.Device, error)
+	deviceExists func(majorMinor string) bool
+	syncDrives   func(ctx context.Context, nodeID directpvtypes.NodeID, majorMinors ...string) error
+}
+
+func newDeviceEventHandler(nodeID directpvtypes.NodeID) *deviceEventHandler {
+	return &deviceEventHandler{
+		nodeID: nodeID,
+		probeDevices: func(majorMinors ...string) ([]types.Device, error) {
+			devices, err := device.ProbeDevices(majorMinors...)
+			if err != nil {
+				return nil, err
+			}
+			var nodeDevices []types.Device
+			for i := range devices {
+				nodeDevices = append(nodeDevices, devices[i].ToNodeDevice(nodeID))
+			}
+			return nodeDevices, nil
+		},
+		deviceExists: func(majorMinor string) bool {
+			_, err := os.Stat(path.Join("/sys/dev/block", majorMinor))
+			return !errors.Is(err, os.ErrNotExist)
+		},
+		syncDrives: device.SyncDevices,
+	}
+}
+
+// markDrivesLost marks the ready drives of the removed devices as lost.
+func (handler *deviceEventHandler) markDrivesLost(ctx context.Context, removed []types.Device) error {
+	fsuuids := map[string]string{}
+	for _, device := range removed {
+		if device.FSUUID != "" {
+			fsuuids[device.FSUUID] = device.Name
+		}
+	}
+	if len(fsuuids) == 0 {
+		return nil
+	}
+
+	drives, err := client.NewDriveLister().
+		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(handler.nodeID))}).
+		StatusSelector([]directpvtypes.DriveStatus{directpvtypes.DriveStatusReady}).
+		Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range drives {
+		deviceName, found := fsuuids[drives[i].Status.FSUUID]
+		if !found {
+			continue
+		}
+
+		updateFunc := func() error {
+			drive, err := client.DriveClient().Get(ctx, drives[i].Name, metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			drive.Status.Status = directpvtypes.DriveStatusLost
+			_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+			return err
+		}
+		if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			return err
+		}
+		client.Eventf(&drives[i], client.EventTypeWarning, client.EventReasonDriveLost, "device %v is removed", deviceName)
+	}
+
+	return nil
+}
+
+// handle re-probes the devices of given major:minor numbers, updates the node and its drives and emits events.
+func (handler *deviceEventHandler) handle(ctx context.Context, majorMinors []string) error {
+	probed, err := handler.probeDevices(majorMinors...)
+	if err != nil {
+		return err
+	}
+
+	// udev data may be missing for an existing device while udev processes the event.
+	var changed []string
+	probedMap := map[string]struct{}{}
+	for _, device := range probed {
+		probedMap[device.MajorMinor] = struct{}{}
+	}
+	for _, majorMinor := range majorMinors {
+		if _, found := probedMap[majorMinor]; found || !handler.deviceExists(majorMinor) {
+			changed = append(changed, majorMinor)
+		}
+	}
+
+	var node *types.Node
+	var added, removed []types.Device
+	updateFunc := func() (err error) {
+		if node, err = client.NodeClient().Get(ctx, string(handler.nodeID), metav1.GetOptions{}); err != nil {
+			return err
+		}
+		added, removed, node.Status.Devices = mergeDevices(node.Status.Devices, changed, probed)
+		node, err = client.NodeClient().Update(ctx, node, metav1.UpdateOptions{TypeMeta: types.NewNodeTypeMeta()})
+		return err
+	}
+	if err = retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+		return err
+	}
+
+	for _, device := range removed {
+		client.Eventf(node, client.EventTypeWarning, client.EventReasonDeviceRemoved, "Device %v (%v) removed", device.Name, device.MajorMinor)
+	}
+	for _, device := range added {
+		client.Eventf(node, client.EventTypeNormal, client.EventReasonDeviceAdded, "Device %v (%v) added", device.Name, device.MajorMinor)
+	}
+
+	if err = handler.markDrivesLost(ctx, removed); err != nil {
+		return err
+	}
+
+	if len(probed) != 0 {
+		return handler.syncDrives(ctx, handler.nodeID, majorMinors...)
+	}
+	return nil
+}
+
+// StartDeviceWatcher listens block device uevents and re-probes the affected devices after no event is
+// received for the debounce period.
+func StartDeviceWatcher(ctx context.Context, nodeID directpvtypes.NodeID, debounce time.Duration) error {
+	eventCh, err := uevent.Listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	handler := newDeviceEventHandler(nodeID)
+	uevent.Debounce(ctx, eventCh, debounce, func(majorMinors []string) {
+		klog.V(3).InfoS("Devices changed", "majorMinors", majorMinors)
+		if err := handler.handle(ctx, majorMinors); err != nil {
+			klog.ErrorS(err, "unable to sync changed devices", "majorMinors", majorMinors)
+		}
+	})
+	return nil
+}
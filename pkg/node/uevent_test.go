@@ -0,0 +1,108 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+func TestMergeDevices(t *testing.T) {
+	devices := []types.Device{
+		{Name: "sda", MajorMinor: "8:0"},
+		{Name: "sdb", MajorMinor: "8:16"},
+		{Name: "sdc", MajorMinor: "8:32", Size: 100},
+	}
+	probed := []types.Device{
+		{Name: "sdc", MajorMinor: "8:32", Size: 200},
+		{Name: "sdd", MajorMinor: "8:48"},
+	}
+
+	added, removed, merged := mergeDevices(devices, []string{"8:16", "8:32", "8:48"}, probed)
+	if len(added) != 1 || added[0].Name != "sdd" {
+		t.Fatalf("unexpected added devices %+v", added)
+	}
+	if len(removed) != 1 || removed[0].Name != "sdb" {
+		t.Fatalf("unexpected removed devices %+v", removed)
+	}
+	if len(merged) != 3 || merged[0].Name != "sda" || merged[1].Size != 200 || merged[2].Name != "sdd" {
+		t.Fatalf("unexpected merged devices %+v", merged)
+	}
+}
+
+func TestDeviceEventHandler(t *testing.T) {
+	node := types.NewNode("node-1", []types.Device{
+		{Name: "sdb", MajorMinor: "8:16", FSUUID: "fsuuid-1"},
+		{Name: "sdc", MajorMinor: "8:32", FSUUID: "fsuuid-2"},
+	})
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{FSUUID: "fsuuid-1", Status: directpvtypes.DriveStatusReady}, "node-1", "sdb", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{FSUUID: "fsuuid-2", Status: directpvtypes.DriveStatusReady}, "node-1", "sdc", directpvtypes.AccessTierDefault)
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(node, drive1, drive2))
+	client.SetNodeInterface(clientset.DirectpvLatest().DirectPVNodes())
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+
+	var synced []string
+	handler := &deviceEventHandler{
+		nodeID: "node-1",
+		probeDevices: func(_ ...string) ([]types.Device, error) {
+			return []types.Device{{Name: "sdd", MajorMinor: "8:48"}}, nil
+		},
+		// sdc is still present but its udev data is not yet available.
+		deviceExists: func(majorMinor string) bool { return majorMinor == "8:32" },
+		syncDrives: func(_ context.Context, _ directpvtypes.NodeID, majorMinors ...string) error {
+			synced = majorMinors
+			return nil
+		},
+	}
+
+	ctx := t.Context()
+	if err := handler.handle(ctx, []string{"8:16", "8:32", "8:48"}); err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := client.NodeClient().Get(ctx, "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(node.Status.Devices) != 2 || node.Status.Devices[0].Name != "sdc" || node.Status.Devices[1].Name != "sdd" {
+		t.Fatalf("unexpected devices %+v", node.Status.Devices)
+	}
+
+	for driveID, status := range map[string]directpvtypes.DriveStatus{"drive-1": directpvtypes.DriveStatusLost, "drive-2": directpvtypes.DriveStatusReady} {
+		drive, err := client.DriveClient().Get(ctx, driveID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if drive.Status.Status != status {
+			t.Fatalf("drive %v: expected: %v, got: %v", driveID, status, drive.Status.Status)
+		}
+	}
+
+	if len(synced) != 3 {
+		t.Fatalf("unexpected synced devices %v", synced)
+	}
+}
@@ -0,0 +1,71 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	workerThreads = 5
+	resyncPeriod  = time.Minute
+)
+
+type quotaEventHandler struct{}
+
+func (handler *quotaEventHandler) ListerWatcher() cache.ListerWatcher {
+	return cache.NewListWatchFromClient(
+		client.RESTClient(),
+		consts.QuotaResource,
+		metav1.NamespaceAll,
+		fields.Everything(),
+	)
+}
+
+func (handler *quotaEventHandler) ObjectType() runtime.Object {
+	return &types.Quota{}
+}
+
+func (handler *quotaEventHandler) Handle(ctx context.Context, eventType controller.EventType, object runtime.Object) error {
+	switch eventType {
+	case controller.AddEvent, controller.UpdateEvent:
+		quota := object.(*types.Quota)
+		usage, err := GetUsage(ctx, quota.Namespace)
+		if err != nil {
+			return err
+		}
+		return updateStatus(ctx, quota.Namespace, quota.Name, usage.toStatus())
+	default:
+	}
+	return nil
+}
+
+// StartController starts quota controller refreshing the usage in quota status.
+func StartController(ctx context.Context) {
+	ctrl := controller.New("quota", &quotaEventHandler{}, workerThreads, resyncPeriod)
+	ctrl.Run(ctx)
+}
@@ -0,0 +1,261 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package quota implements DirectPVQuota enforcement and usage accounting.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/types"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// ErrQuotaExceeded denotes the request exceeds a quota of the namespace.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Usage denotes the volume usage of a namespace.
+type Usage struct {
+	Capacity    int64
+	Volumes     int64
+	AccessTiers map[string]int64
+	Nodes       map[string]int64
+}
+
+func newUsage() *Usage {
+	return &Usage{
+		AccessTiers: map[string]int64{},
+		Nodes:       map[string]int64{},
+	}
+}
+
+func (usage *Usage) add(capacity int64, accessTier directpvtypes.AccessTier, nodeID directpvtypes.NodeID) {
+	usage.Capacity += capacity
+	usage.Volumes++
+	usage.AccessTiers[string(accessTier)] += capacity
+	usage.Nodes[string(nodeID)] += capacity
+}
+
+func toQuantities(values map[string]int64) map[string]resource.Quantity {
+	if len(values) == 0 {
+		return nil
+	}
+	quantities := map[string]resource.Quantity{}
+	for key, value := range values {
+		quantities[key] = *resource.NewQuantity(value, resource.BinarySI)
+	}
+	return quantities
+}
+
+// toStatus converts the usage to quota status.
+func (usage *Usage) toStatus() types.QuotaStatus {
+	return types.QuotaStatus{
+		Capacity:    *resource.NewQuantity(usage.Capacity, resource.BinarySI),
+		Volumes:     usage.Volumes,
+		AccessTiers: toQuantities(usage.AccessTiers),
+		Nodes:       toQuantities(usage.Nodes),
+	}
+}
+
+// GetUsage returns the volume usage of the namespace excluding the named volumes.
+func GetUsage(ctx context.Context, namespace string, excludeVolumes ...string) (*Usage, error) {
+	var volumes []types.Volume
+	driveIDs := map[directpvtypes.DriveID]struct{}{}
+	for result := range client.NewVolumeLister().
+		PVCNamespaceSelector(directpvtypes.ToLabelValues([]string{namespace})).
+		IgnoreNotFound(true).
+		List(ctx) {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if isExcluded(result.Volume.Name, excludeVolumes) {
+			continue
+		}
+		volumes = append(volumes, result.Volume)
+		driveIDs[result.Volume.GetDriveID()] = struct{}{}
+	}
+
+	accessTiers := map[directpvtypes.DriveID]directpvtypes.AccessTier{}
+	if len(driveIDs) != 0 {
+		var selectors []directpvtypes.DriveID
+		for driveID := range driveIDs {
+			selectors = append(selectors, driveID)
+		}
+		for result := range client.NewDriveLister().DriveIDSelector(selectors).IgnoreNotFound(true).List(ctx) {
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			accessTiers[result.Drive.GetDriveID()] = result.Drive.GetAccessTier()
+		}
+	}
+
+	usage := newUsage()
+	for _, volume := range volumes {
+		usage.add(volume.Status.TotalCapacity, accessTiers[volume.GetDriveID()], volume.GetNodeID())
+	}
+	return usage, nil
+}
+
+func isExcluded(name string, excludeVolumes []string) bool {
+	for _, excluded := range excludeVolumes {
+		if name == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+func getLimit(limits map[string]resource.Quantity, key string) (resource.Quantity, bool) {
+	for name, limit := range limits {
+		if strings.EqualFold(name, key) {
+			return limit, true
+		}
+	}
+	return resource.Quantity{}, false
+}
+
+// Request denotes the capacity of a volume to be provisioned or expanded in a namespace.
+type Request struct {
+	Namespace  string
+	VolumeName string
+	Capacity   int64
+	AccessTier directpvtypes.AccessTier
+	NodeID     directpvtypes.NodeID
+}
+
+// checkQuota returns ErrQuotaExceeded if usage after adding the request exceeds the quota.
+func checkQuota(quota *types.Quota, usage *Usage, request Request) error {
+	exceeded := func(kind string, used int64, limit resource.Quantity) error {
+		return fmt.Errorf(
+			"%w; %v limit %v of quota %v/%v is exceeded by volume %v; used=%v, requested=%v",
+			ErrQuotaExceeded,
+			kind,
+			humanize.IBytes(uint64(limit.Value())),
+			quota.Namespace,
+			quota.Name,
+			request.VolumeName,
+			humanize.IBytes(uint64(used)),
+			humanize.IBytes(uint64(request.Capacity)),
+		)
+	}
+
+	if quota.Spec.Volumes != nil && usage.Volumes+1 > *quota.Spec.Volumes {
+		return fmt.Errorf(
+			"%w; volumes limit %v of quota %v/%v is exceeded by volume %v",
+			ErrQuotaExceeded, *quota.Spec.Volumes, quota.Namespace, quota.Name, request.VolumeName,
+		)
+	}
+
+	if quota.Spec.Capacity != nil && usage.Capacity+request.Capacity > quota.Spec.Capacity.Value() {
+		return exceeded("capacity", usage.Capacity, *quota.Spec.Capacity)
+	}
+
+	if limit, found := getLimit(quota.Spec.AccessTiers, string(request.AccessTier)); found {
+		used := usage.AccessTiers[string(request.AccessTier)]
+		if used+request.Capacity > limit.Value() {
+			return exceeded(fmt.Sprintf("access-tier %v", request.AccessTier), used, limit)
+		}
+	}
+
+	if limit, found := getLimit(quota.Spec.Nodes, string(request.NodeID)); found {
+		used := usage.Nodes[string(request.NodeID)]
+		if used+request.Capacity > limit.Value() {
+			return exceeded(fmt.Sprintf("node %v", request.NodeID), used, limit)
+		}
+	}
+
+	return nil
+}
+
+// Check returns ErrQuotaExceeded if the request exceeds any quota in the namespace.
+func Check(ctx context.Context, request Request) error {
+	if request.Namespace == "" {
+		return nil
+	}
+
+	quotaList, err := client.QuotaClient(request.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list quotas in namespace %v; %w", request.Namespace, err)
+	}
+	if len(quotaList.Items) == 0 {
+		return nil
+	}
+
+	usage, err := GetUsage(ctx, request.Namespace, request.VolumeName)
+	if err != nil {
+		return fmt.Errorf("unable to get usage of namespace %v; %w", request.Namespace, err)
+	}
+
+	for i := range quotaList.Items {
+		if err := checkQuota(&quotaList.Items[i], usage, request); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateStatus(ctx context.Context, namespace, name string, status types.QuotaStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		quota, err := client.QuotaClient(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(quota.Status, status) {
+			return nil
+		}
+		quota.Status = status
+		_, err = client.QuotaClient(namespace).Update(ctx, quota, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// UpdateStatus updates the usage in status of all quotas in the namespace.
+func UpdateStatus(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+
+	quotaList, err := client.QuotaClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(quotaList.Items) == 0 {
+		return nil
+	}
+
+	usage, err := GetUsage(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	status := usage.toStatus()
+	for _, quota := range quotaList.Items {
+		if err := updateStatus(ctx, namespace, quota.Name, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package quota
+
+import (
+	"errors"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestCheckQuota(t *testing.T) {
+	volumes := int64(3)
+	capacity := resource.MustParse("100Mi")
+	quota := types.NewQuota("quota", "tenant", types.QuotaSpec{
+		Capacity:    &capacity,
+		Volumes:     &volumes,
+		AccessTiers: map[string]resource.Quantity{"hot": resource.MustParse("20Mi")},
+		Nodes:       map[string]resource.Quantity{"node-2": resource.MustParse("30Mi")},
+	})
+
+	usage := newUsage()
+	usage.add(10*1024*1024, directpvtypes.AccessTierHot, "node-1")
+	usage.add(20*1024*1024, directpvtypes.AccessTierDefault, "node-2")
+
+	testCases := []struct {
+		request     Request
+		expectedErr bool
+	}{
+		{Request{Capacity: 10 * 1024 * 1024, AccessTier: directpvtypes.AccessTierHot, NodeID: "node-1"}, false},
+		{Request{Capacity: 11 * 1024 * 1024, AccessTier: directpvtypes.AccessTierHot, NodeID: "node-1"}, true},
+		{Request{Capacity: 10 * 1024 * 1024, AccessTier: directpvtypes.AccessTierDefault, NodeID: "node-2"}, false},
+		{Request{Capacity: 11 * 1024 * 1024, AccessTier: directpvtypes.AccessTierDefault, NodeID: "node-2"}, true},
+		{Request{Capacity: 70 * 1024 * 1024, AccessTier: directpvtypes.AccessTierCold, NodeID: "node-3"}, false},
+		{Request{Capacity: 71 * 1024 * 1024, AccessTier: directpvtypes.AccessTierCold, NodeID: "node-3"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := checkQuota(quota, usage, testCase.request)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrQuotaExceeded, err)
+		}
+	}
+
+	usage.add(1, directpvtypes.AccessTierDefault, "node-3")
+	if err := checkQuota(quota, usage, Request{Capacity: 1, NodeID: "node-3"}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected volumes limit error; got: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package snapshot manages thin logical volumes of snapshots of thin volumes.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/lvm"
+	"github.com/minio/directpv/pkg/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	workerThreads = 10
+	resyncPeriod  = 10 * time.Minute
+)
+
+type snapshotEventHandler struct {
+	nodeID           directpvtypes.NodeID
+	createThinVolume func(ctx context.Context, vgName, thinPool, name string, size int64) error
+	createSnapshot   func(ctx context.Context, vgName, origin, name string) error
+	removeVolume     func(ctx context.Context, vgName, name string) error
+}
+
+func newSnapshotEventHandler(nodeID directpvtypes.NodeID) *snapshotEventHandler {
+	return &snapshotEventHandler{
+		nodeID:           nodeID,
+		createThinVolume: lvm.CreateThinVolume,
+		createSnapshot:   lvm.CreateSnapshot,
+		removeVolume:     lvm.RemoveVolume,
+	}
+}
+
+func (handler *snapshotEventHandler) ListerWatcher() cache.ListerWatcher {
+	labelSelector := fmt.Sprintf("%s=%s", directpvtypes.NodeLabelKey, handler.nodeID)
+	return cache.NewFilteredListWatchFromClient(
+		client.RESTClient(),
+		consts.SnapshotResource,
+		"",
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = labelSelector
+		},
+	)
+}
+
+func (handler *snapshotEventHandler) ObjectType() runtime.Object {
+	return &types.Snapshot{}
+}
+
+func (handler *snapshotEventHandler) Handle(ctx context.Context, _ controller.EventType, object runtime.Object) error {
+	snapshot := object.(*types.Snapshot)
+	if !snapshot.GetDeletionTimestamp().IsZero() {
+		return handler.delete(ctx, snapshot)
+	}
+	if snapshot.Status.ReadyToUse {
+		return nil
+	}
+	return handler.create(ctx, snapshot)
+}
+
+func (handler *snapshotEventHandler) getVGName(ctx context.Context, snapshot *types.Snapshot) (string, string, error) {
+	drive, err := client.DriveClient().Get(ctx, string(snapshot.GetDriveID()), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return "", "", err
+	}
+	if drive.Status.LVM == nil {
+		return "", "", fmt.Errorf("drive %v of snapshot %v has no thin pool", drive.Name, snapshot.Name)
+	}
+	return drive.Status.LVM.VGName, drive.Status.LVM.ThinPool, nil
+}
+
+// create takes the snapshot of the thin logical volume of the source volume.
+func (handler *snapshotEventHandler) create(ctx context.Context, snapshot *types.Snapshot) error {
+	vgName, thinPool, err := handler.getVGName(ctx, snapshot)
+	if err != nil {
+		client.Eventf(snapshot, client.EventTypeWarning, client.EventReasonSnapshotError, "unable to get thin pool; %v", err)
+		return err
+	}
+
+	// Thin logical volume of the volume is created on staging; unstaged volume gets an empty one.
+	volumeName := snapshot.GetVolumeName()
+	if err := handler.createThinVolume(ctx, vgName, thinPool, volumeName, snapshot.Status.Size); err != nil {
+		klog.ErrorS(err, "unable to create thin volume", "volume", volumeName, "vg", vgName)
+		return err
+	}
+
+	if err := handler.createSnapshot(ctx, vgName, volumeName, snapshot.Name); err != nil {
+		klog.ErrorS(err, "unable to create snapshot", "snapshot", snapshot.Name, "volume", volumeName, "vg", vgName)
+		client.Eventf(snapshot, client.EventTypeWarning, client.EventReasonSnapshotError, "unable to create snapshot; %v", err)
+		return err
+	}
+
+	updateFunc := func() error {
+		snapshot, err := client.SnapshotClient().Get(ctx, snapshot.Name, metav1.GetOptions{TypeMeta: types.NewSnapshotTypeMeta()})
+		if err != nil {
+			return err
+		}
+		now := metav1.Now()
+		snapshot.Status.ReadyToUse = true
+		snapshot.Status.CreationTime = &now
+		_, err = client.SnapshotClient().Update(ctx, snapshot, metav1.UpdateOptions{TypeMeta: types.NewSnapshotTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+		return err
+	}
+
+	client.Eventf(snapshot, client.EventTypeNormal, client.EventReasonSnapshotCreated, "snapshot of volume %v is created", volumeName)
+	return nil
+}
+
+// delete removes the thin logical volume of the snapshot once no volume waits to be restored from it.
+func (handler *snapshotEventHandler) delete(ctx context.Context, snapshot *types.Snapshot) error {
+	volumes, err := client.NewVolumeLister().
+		LabelSelector(map[directpvtypes.LabelKey]directpvtypes.LabelValue{directpvtypes.SnapshotLabelKey: directpvtypes.ToLabelValue(snapshot.Name)}).
+		Get(ctx)
+	if err != nil {
+		return err
+	}
+	for _, volume := range volumes {
+		if volume.Status.DataPath == "" && volume.GetDeletionTimestamp().IsZero() {
+			return fmt.Errorf("volume %v is not yet restored from snapshot %v", volume.Name, snapshot.Name)
+		}
+	}
+
+	vgName, _, err := handler.getVGName(ctx, snapshot)
+	switch {
+	case err == nil:
+		if err := handler.removeVolume(ctx, vgName, snapshot.Name); err != nil {
+			klog.ErrorS(err, "unable to remove snapshot", "snapshot", snapshot.Name, "vg", vgName)
+			return err
+		}
+	case apierrors.IsNotFound(err):
+		// Thin pool is removed along with the drive.
+	default:
+		return err
+	}
+
+	updateFunc := func() error {
+		snapshot, err := client.SnapshotClient().Get(ctx, snapshot.Name, metav1.GetOptions{TypeMeta: types.NewSnapshotTypeMeta()})
+		if err != nil {
+			return err
+		}
+		snapshot.RemoveDataProtection()
+		_, err = client.SnapshotClient().Update(ctx, snapshot, metav1.UpdateOptions{TypeMeta: types.NewSnapshotTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// StartController starts snapshot controller of the node.
+func StartController(ctx context.Context, nodeID directpvtypes.NodeID) {
+	ctrl := controller.New("snapshot", newSnapshotEventHandler(nodeID), workerThreads, resyncPeriod)
+	ctrl.Run(ctx)
+}
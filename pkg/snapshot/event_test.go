@@ -0,0 +1,140 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+const MiB = 1024 * 1024
+
+func TestSnapshotEventHandler(t *testing.T) {
+	drive := types.NewDrive(
+		"drive-1",
+		types.DriveStatus{
+			TotalCapacity: 100 * MiB,
+			FreeCapacity:  90 * MiB,
+			Status:        directpvtypes.DriveStatusReady,
+			LVM:           &types.DriveLVM{VGName: "directpv-drive-1", MetaLV: "meta", ThinPool: "pool"},
+		},
+		"node-1",
+		"sda",
+		directpvtypes.AccessTierDefault,
+	)
+	volume := types.NewVolume("volume-1", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	volume.SetBackend(directpvtypes.BackendLVMThin)
+	snapshot := types.NewSnapshot("snapshot-1", volume)
+	restoredVolume := types.NewVolume("volume-2", "drive-1", "node-1", "drive-1", "sda", 10*MiB)
+	restoredVolume.SetBackend(directpvtypes.BackendLVMThin)
+	restoredVolume.SetSnapshot(snapshot.Name)
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive, volume, restoredVolume, snapshot))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+	client.SetClientsetInterface(clientset)
+
+	var thinVolumes, snapshots, removedVolumes []string
+	handler := &snapshotEventHandler{
+		nodeID: "node-1",
+		createThinVolume: func(_ context.Context, vgName, thinPool, name string, size int64) error {
+			if vgName != "directpv-drive-1" || thinPool != "pool" || size != 10*MiB {
+				t.Fatalf("unexpected vg %v, thin pool %v or size %v", vgName, thinPool, size)
+			}
+			thinVolumes = append(thinVolumes, name)
+			return nil
+		},
+		createSnapshot: func(_ context.Context, vgName, origin, name string) error {
+			if vgName != "directpv-drive-1" || origin != "volume-1" {
+				t.Fatalf("unexpected vg %v or origin %v", vgName, origin)
+			}
+			snapshots = append(snapshots, name)
+			return nil
+		},
+		removeVolume: func(_ context.Context, _, name string) error {
+			removedVolumes = append(removedVolumes, name)
+			return nil
+		},
+	}
+
+	ctx := t.Context()
+	getSnapshot := func() *types.Snapshot {
+		snapshot, err := client.SnapshotClient().Get(ctx, "snapshot-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return snapshot
+	}
+
+	if err := handler.Handle(ctx, controller.AddEvent, snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	snapshot = getSnapshot()
+	if !snapshot.Status.ReadyToUse || snapshot.Status.CreationTime == nil {
+		t.Fatalf("snapshot is not ready; %+v", snapshot.Status)
+	}
+	if len(thinVolumes) != 1 || thinVolumes[0] != "volume-1" || len(snapshots) != 1 || snapshots[0] != "snapshot-1" {
+		t.Fatalf("unexpected thin volumes %v or snapshots %v", thinVolumes, snapshots)
+	}
+
+	// Ready snapshot is not taken again.
+	if err := handler.Handle(ctx, controller.UpdateEvent, snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("unexpected snapshots %v", snapshots)
+	}
+
+	now := metav1.Now()
+	snapshot.DeletionTimestamp = &now
+	if _, err := client.SnapshotClient().Update(ctx, snapshot, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Snapshot is kept until the volume is restored from it.
+	if err := handler.Handle(ctx, controller.UpdateEvent, snapshot); err == nil {
+		t.Fatalf("expected error; but succeeded")
+	}
+	if len(removedVolumes) != 0 || len(getSnapshot().Finalizers) == 0 {
+		t.Fatalf("snapshot is removed before restore")
+	}
+
+	restoredVolume.Status.DataPath = "/var/lib/directpv/mnt/drive-1/volume-2"
+	if _, err := client.VolumeClient().Update(ctx, restoredVolume, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := handler.Handle(ctx, controller.UpdateEvent, snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(removedVolumes) != 1 || removedVolumes[0] != "snapshot-1" {
+		t.Fatalf("unexpected removed volumes %v", removedVolumes)
+	}
+	if finalizers := getSnapshot().Finalizers; len(finalizers) != 0 {
+		t.Fatalf("unexpected finalizers %v", finalizers)
+	}
+}
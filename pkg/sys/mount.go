@@ -140,6 +140,11 @@ func BindMount(source, target, fsType string, recursive, readOnly bool, superBlo
 	return bindMount(source, target, fsType, recursive, readOnly, superBlockFlags)
 }
 
+// RemountBind remounts bind-mounted target with flags.
+func RemountBind(target string, flags []string) error {
+	return remountBind(target, flags)
+}
+
 // Unmount unmounts target with force, detach and expire options.
 func Unmount(target string, force, detach, expire bool) error {
 	return unmount(target, force, detach, expire)
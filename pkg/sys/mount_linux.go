@@ -217,6 +217,19 @@ func bindMount(source, target, fsType string, recursive, readOnly bool, superBlo
 	return syscall.Mount(source, target, fsType, flags, superBlockFlags)
 }
 
+func remountBind(target string, flags []string) error {
+	mountFlags := mountFlagMap["remount"] | mountFlagMap["bind"]
+	for _, flag := range flags {
+		value, found := mountFlagMap[flag]
+		if !found {
+			return fmt.Errorf("unknown flag %v", flag)
+		}
+		mountFlags |= value
+	}
+	klog.V(5).InfoS("remounting bind mount", "target", target, "flags", flags)
+	return syscall.Mount("", target, "", mountFlags, "")
+}
+
 func unmount(target string, force, detach, expire bool) error {
 	mountInfo, err := newMountInfo()
 	if err != nil {
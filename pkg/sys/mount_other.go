@@ -38,3 +38,7 @@ func unmount(target string, force, detach, expire bool) error {
 func bindMount(source, target, fsType string, recursive, readOnly bool, superBlockFlags string) error {
 	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
+
+func remountBind(target string, flags []string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
@@ -0,0 +1,34 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"encoding/binary"
+
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// GetProjectIDHash returns project quota ID of given ID.
+func GetProjectIDHash(id string) uint32 {
+	hash := sha256.Sum256([]byte(id))
+	return binary.LittleEndian.Uint32(hash[:8])
+}
+
+// SetProjectID sets project quota ID to path with project inherit flag.
+func SetProjectID(path string, projectID uint32) error {
+	return setProjectID(path, projectID)
+}
@@ -0,0 +1,73 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	fsGetAttr          = 0x801c581f // FS_IOC_FSGETXATTR
+	fsSetAttr          = 0x401c5820 // FS_IOC_FSSETXATTR
+	flagProjectInherit = 0x00000200
+)
+
+type fsXAttr struct {
+	fsXXFlags uint32
+	_         uint32 // fsXExtSize
+	_         uint32 // fsXNextents
+	fsXProjID uint32
+	_         uint32  // fsXCowextSize
+	_         [8]byte // fsXPad
+}
+
+func setProjectID(path string, projectID uint32) error {
+	targetDir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer targetDir.Close()
+
+	var fsx fsXAttr
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		targetDir.Fd(),
+		fsGetAttr,
+		uintptr(unsafe.Pointer(&fsx)),
+	)
+	if errno != 0 {
+		return os.NewSyscallError("FS_IOC_FSGETXATTR", errno)
+	}
+
+	fsx.fsXProjID = projectID
+	fsx.fsXXFlags |= uint32(flagProjectInherit)
+	_, _, errno = syscall.Syscall(
+		syscall.SYS_IOCTL,
+		targetDir.Fd(),
+		fsSetAttr,
+		uintptr(unsafe.Pointer(&fsx)),
+	)
+	if errno != 0 {
+		return os.NewSyscallError("FS_IOC_FSSETXATTR", errno)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import "syscall"
+
+func getFSUsage(mountPoint string) (total, used, available uint64, err error) {
+	var stat syscall.Statfs_t
+	if err = syscall.Statfs(mountPoint, &stat); err != nil {
+		return 0, 0, 0, err
+	}
+	total = stat.Blocks * uint64(stat.Bsize)
+	used = (stat.Blocks - stat.Bfree) * uint64(stat.Bsize)
+	available = stat.Bavail * uint64(stat.Bsize)
+	return total, used, available, nil
+}
@@ -0,0 +1,56 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"math"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// fiTrim is FITRIM ioctl request; refer https://github.com/torvalds/linux/blob/master/include/uapi/linux/fs.h
+const fiTrim = 0xc0185879
+
+// Refer fstrim_range structure in https://github.com/torvalds/linux/blob/master/include/uapi/linux/fs.h
+type fstrimRange struct {
+	start  uint64
+	length uint64
+	minLen uint64
+}
+
+func trim(mountPoint string) error {
+	dir, err := os.Open(mountPoint)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	trimRange := fstrimRange{length: math.MaxUint64}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		dir.Fd(),
+		fiTrim,
+		uintptr(unsafe.Pointer(&trimRange)),
+	)
+	if errno != 0 {
+		return os.NewSyscallError("FITRIM", errno)
+	}
+	return nil
+}
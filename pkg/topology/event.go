@@ -0,0 +1,109 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package topology
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/k8s"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	workerThreads = 1
+	resyncPeriod  = 5 * time.Minute
+)
+
+type topologyEventHandler struct {
+	resolver *Resolver
+}
+
+func (handler *topologyEventHandler) ListerWatcher() cache.ListerWatcher {
+	return cache.NewListWatchFromClient(
+		k8s.KubeClient().CoreV1().RESTClient(),
+		"nodes",
+		"",
+		fields.OneTermEqualSelector("metadata.name", string(handler.resolver.config.NodeID)),
+	)
+}
+
+func (handler *topologyEventHandler) ObjectType() runtime.Object {
+	return &corev1.Node{}
+}
+
+func (handler *topologyEventHandler) Handle(ctx context.Context, eventType controller.EventType, object runtime.Object) error {
+	switch eventType {
+	case controller.AddEvent, controller.UpdateEvent:
+		node := object.(*corev1.Node)
+		if handler.resolver.update(node.Labels) {
+			klog.InfoS("Node topology changed", "node", node.Name, "topology", handler.resolver.Get())
+		}
+		return handler.syncDrives(ctx)
+	default:
+	}
+	return nil
+}
+
+// syncDrives updates the topology of the drives of the node to the latest segments.
+func (handler *topologyEventHandler) syncDrives(ctx context.Context) error {
+	segments := handler.resolver.Get()
+	drives, err := client.NewDriveLister().
+		NodeSelector([]directpvtypes.LabelValue{directpvtypes.ToLabelValue(string(handler.resolver.config.NodeID))}).
+		Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range drives {
+		if maps.Equal(drives[i].Status.Topology, segments) {
+			continue
+		}
+
+		updateFunc := func() error {
+			drive, err := client.DriveClient().Get(ctx, drives[i].Name, metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			drive.Status.Topology = segments
+			_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+			return err
+		}
+		if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StartController starts topology controller updating the resolver and drive topology on node label changes.
+func StartController(ctx context.Context, resolver *Resolver) {
+	ctrl := controller.New("topology", &topologyEventHandler{resolver: resolver}, workerThreads, resyncPeriod)
+	ctrl.Run(ctx)
+}
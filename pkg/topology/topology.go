@@ -0,0 +1,116 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package topology resolves topology segments of a node from its Kubernetes node labels.
+package topology
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Config denotes how topology segments of a node are derived.
+type Config struct {
+	Identity string
+	NodeID   directpvtypes.NodeID
+
+	// Rack, Zone and Region are used when the node has no respective labels.
+	Rack   string
+	Zone   string
+	Region string
+
+	// RackLabel, ZoneLabel and RegionLabel are the node label keys of rack, zone and region;
+	// empty value uses Rack, Zone and Region respectively always.
+	RackLabel   string
+	ZoneLabel   string
+	RegionLabel string
+	// ExtraKeys are node label keys added to the segments as is.
+	ExtraKeys []string
+}
+
+// Segments returns the topology segments for given node labels.
+func (config Config) Segments(labels map[string]string) map[string]string {
+	valueOf := func(key, defaultValue string) string {
+		if value, found := labels[key]; key != "" && found && value != "" {
+			return value
+		}
+		return defaultValue
+	}
+
+	segments := map[string]string{
+		string(directpvtypes.TopologyDriverIdentity): config.Identity,
+		string(directpvtypes.TopologyDriverRack):     valueOf(config.RackLabel, config.Rack),
+		string(directpvtypes.TopologyDriverZone):     valueOf(config.ZoneLabel, config.Zone),
+		string(directpvtypes.TopologyDriverRegion):   valueOf(config.RegionLabel, config.Region),
+		string(directpvtypes.TopologyDriverNode):     string(config.NodeID),
+	}
+	for _, key := range config.ExtraKeys {
+		if value, found := labels[key]; found {
+			segments[key] = value
+		}
+	}
+	return segments
+}
+
+// Resolver holds the latest topology segments of a node.
+type Resolver struct {
+	config   Config
+	mutex    sync.RWMutex
+	segments map[string]string
+}
+
+// NewResolver creates a resolver with the segments of a node without labels.
+func NewResolver(config Config) *Resolver {
+	return &Resolver{
+		config:   config,
+		segments: config.Segments(nil),
+	}
+}
+
+// Get returns a copy of the latest topology segments.
+func (resolver *Resolver) Get() map[string]string {
+	resolver.mutex.RLock()
+	defer resolver.mutex.RUnlock()
+	return maps.Clone(resolver.segments)
+}
+
+// update updates the segments from given node labels and returns whether they are changed.
+func (resolver *Resolver) update(labels map[string]string) bool {
+	segments := resolver.config.Segments(labels)
+
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	if maps.Equal(resolver.segments, segments) {
+		return false
+	}
+	resolver.segments = segments
+	return true
+}
+
+// Refresh reads the labels of the Kubernetes node and updates the segments.
+func (resolver *Resolver) Refresh(ctx context.Context) error {
+	node, err := k8s.KubeClient().CoreV1().Nodes().Get(ctx, string(resolver.config.NodeID), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	resolver.update(node.Labels)
+	return nil
+}
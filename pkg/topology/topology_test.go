@@ -0,0 +1,128 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package topology
+
+import (
+	"reflect"
+	"testing"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+func TestSegments(t *testing.T) {
+	config := Config{
+		Identity:    "directpv-min-io",
+		NodeID:      "node-1",
+		Rack:        "default",
+		Zone:        "default",
+		Region:      "default",
+		RackLabel:   "example.com/rack",
+		ZoneLabel:   corev1.LabelTopologyZone,
+		RegionLabel: corev1.LabelTopologyRegion,
+		ExtraKeys:   []string{"example.com/row"},
+	}
+
+	testCases := []struct {
+		labels   map[string]string
+		expected map[string]string
+	}{
+		{
+			labels: nil,
+			expected: map[string]string{
+				"directpv.min.io/identity": "directpv-min-io",
+				"directpv.min.io/node":     "node-1",
+				"directpv.min.io/rack":     "default",
+				"directpv.min.io/zone":     "default",
+				"directpv.min.io/region":   "default",
+			},
+		},
+		{
+			labels: map[string]string{
+				corev1.LabelTopologyZone:   "zone-a",
+				corev1.LabelTopologyRegion: "region-1",
+				"example.com/rack":         "rack-7",
+				"example.com/row":          "row-2",
+				"example.com/other":        "other",
+			},
+			expected: map[string]string{
+				"directpv.min.io/identity": "directpv-min-io",
+				"directpv.min.io/node":     "node-1",
+				"directpv.min.io/rack":     "rack-7",
+				"directpv.min.io/zone":     "zone-a",
+				"directpv.min.io/region":   "region-1",
+				"example.com/row":          "row-2",
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if result := config.Segments(testCase.labels); !reflect.DeepEqual(result, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+
+	// Zone and region labels are not read unless their label keys are set.
+	config.ZoneLabel, config.RegionLabel = "", ""
+	result := config.Segments(map[string]string{corev1.LabelTopologyZone: "zone-a", corev1.LabelTopologyRegion: "region-1"})
+	if result["directpv.min.io/zone"] != "default" || result["directpv.min.io/region"] != "default" {
+		t.Fatalf("expected: default zone and region, got: %v", result)
+	}
+}
+
+func TestTopologyEventHandler(t *testing.T) {
+	drive1 := types.NewDrive("drive-1", types.DriveStatus{Topology: map[string]string{"directpv.min.io/zone": "default"}}, "node-1", "sda", directpvtypes.AccessTierDefault)
+	drive2 := types.NewDrive("drive-2", types.DriveStatus{}, "node-2", "sda", directpvtypes.AccessTierDefault)
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive1, drive2))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+
+	resolver := NewResolver(Config{NodeID: "node-1", Zone: "default", ZoneLabel: corev1.LabelTopologyZone})
+	handler := &topologyEventHandler{resolver: resolver}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"}}}
+
+	ctx := t.Context()
+	if err := handler.Handle(ctx, controller.UpdateEvent, node); err != nil {
+		t.Fatal(err)
+	}
+	if zone := resolver.Get()["directpv.min.io/zone"]; zone != "zone-a" {
+		t.Fatalf("expected: zone-a, got: %v", zone)
+	}
+
+	drive, err := client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(drive.Status.Topology, resolver.Get()) {
+		t.Fatalf("expected: %v, got: %v", resolver.Get(), drive.Status.Topology)
+	}
+
+	if drive, err = client.DriveClient().Get(ctx, "drive-2", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.Topology != nil {
+		t.Fatalf("topology of drive of other node must not be changed; got %v", drive.Status.Topology)
+	}
+}
@@ -49,6 +49,7 @@ type (
 	NodeStatus          = directpv.NodeStatus
 	Node                = directpv.DirectPVNode
 	Device              = directpv.Device
+	VolumeDir           = directpv.VolumeDir
 	NodeStatusList      = []directpv.DirectPVNode
 	NodeList            = directpv.DirectPVNodeList
 	LatestNodeInterface = typeddirectpv.DirectPVNodeInterface
@@ -56,10 +57,26 @@ type (
 	InitRequestStatus          = directpv.InitRequestStatus
 	InitRequest                = directpv.DirectPVInitRequest
 	InitDevice                 = directpv.InitDevice
+	FormatProfile              = directpv.FormatProfile
+	Encryption                 = directpv.Encryption
+	DriveEncryption            = directpv.DriveEncryption
+	DriveLVM                   = directpv.DriveLVM
+	DriveVirtual               = directpv.DriveVirtual
 	InitDeviceResult           = directpv.InitDeviceResult
 	InitRequestStatusList      = []directpv.DirectPVInitRequest
 	InitRequestList            = directpv.DirectPVInitRequestList
 	LatestInitRequestInterface = typeddirectpv.DirectPVInitRequestInterface
+
+	QuotaSpec            = directpv.QuotaSpec
+	QuotaStatus          = directpv.QuotaStatus
+	Quota                = directpv.DirectPVQuota
+	QuotaList            = directpv.DirectPVQuotaList
+	LatestQuotaInterface = typeddirectpv.DirectPVQuotaInterface
+
+	SnapshotStatus          = directpv.SnapshotStatus
+	Snapshot                = directpv.DirectPVSnapshot
+	SnapshotList            = directpv.DirectPVSnapshotList
+	LatestSnapshotInterface = typeddirectpv.DirectPVSnapshotInterface
 )
 
 var (
@@ -67,6 +84,8 @@ var (
 	NewVolume      = directpv.NewDirectPVVolume
 	NewNode        = directpv.NewDirectPVNode
 	NewInitRequest = directpv.NewDirectPVInitRequest
+	NewQuota       = directpv.NewDirectPVQuota
+	NewSnapshot    = directpv.NewDirectPVSnapshot
 )
 
 type ExtClientsetInterface interface {
@@ -56,6 +56,22 @@ func NewInitRequestTypeMeta() metav1.TypeMeta {
 	}
 }
 
+// NewSnapshotTypeMeta gets new snapshot CRD type meta.
+func NewSnapshotTypeMeta() metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: string(directpvtypes.LatestVersionLabelKey),
+		Kind:       consts.SnapshotKind,
+	}
+}
+
+// NewQuotaTypeMeta gets new quota CRD type meta.
+func NewQuotaTypeMeta() metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: string(directpvtypes.LatestVersionLabelKey),
+		Kind:       consts.QuotaKind,
+	}
+}
+
 // GetDriveMountDir returns drive mount directory.
 func GetDriveMountDir(fsuuid string) string {
 	return path.Join(consts.MountRootDir, fsuuid)
@@ -71,6 +87,16 @@ func GetDriveMetaFile(fsuuid string) string {
 	return path.Join(GetDriveMetaDir(fsuuid), "meta.info")
 }
 
+// GetDriveJournalFile returns drive metadata journal file.
+func GetDriveJournalFile(fsuuid string) string {
+	return path.Join(GetDriveMetaDir(fsuuid), "journal.json")
+}
+
+// GetDriveWipeDir returns drive directory of volume wipe records.
+func GetDriveWipeDir(fsuuid string) string {
+	return path.Join(GetDriveMetaDir(fsuuid), "wipe")
+}
+
 // GetVolumeRootDir returns volume root directory.
 func GetVolumeRootDir(fsuuid string) string {
 	return path.Join(GetDriveMountDir(fsuuid), ".FSUUID."+fsuuid)
@@ -0,0 +1,91 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package uevent
+
+import (
+	"context"
+	"errors"
+	"syscall"
+
+	"k8s.io/klog/v2"
+)
+
+// kernelGroup is the netlink multicast group of kernel uevents. Events of udev group are
+// not received in containers as udevd sends them in host network namespace only.
+const kernelGroup = 1
+
+func listen(ctx context.Context) (<-chan *Event, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: kernelGroup}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		// Shutdown unblocks pending Recvfrom.
+		syscall.Shutdown(fd, syscall.SHUT_RDWR)
+	}()
+
+	eventCh := make(chan *Event)
+	go func() {
+		defer close(eventCh)
+		defer syscall.Close(fd)
+
+		buf := make([]byte, 64*1024)
+		for {
+			n, _, err := syscall.Recvfrom(fd, buf, 0)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if errors.Is(err, syscall.EINTR) || errors.Is(err, syscall.ENOBUFS) {
+					// ENOBUFS denotes dropped events; subsequent events are still received.
+					continue
+				}
+				klog.ErrorS(err, "unable to receive uevent")
+				return
+			}
+			if n == 0 {
+				continue
+			}
+
+			event, err := parse(buf[:n])
+			if err != nil {
+				klog.V(5).ErrorS(err, "unable to parse uevent")
+				continue
+			}
+			if event == nil {
+				continue
+			}
+
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
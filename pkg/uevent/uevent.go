@@ -0,0 +1,123 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+// Package uevent listens kernel uevents of block devices.
+package uevent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Action denotes the uevent action.
+type Action string
+
+// Enum values of Action type.
+const (
+	ActionAdd    Action = "add"
+	ActionRemove Action = "remove"
+	ActionChange Action = "change"
+)
+
+var errInvalidEvent = errors.New("invalid uevent")
+
+// Event denotes a block device uevent.
+type Event struct {
+	Action     Action
+	DevPath    string
+	DevName    string
+	DevType    string
+	MajorMinor string
+}
+
+// parse parses the kernel uevent message in "ACTION@DEVPATH\0KEY=VALUE\0..." format;
+// nil is returned for events other than block device add, remove and change.
+func parse(data []byte) (*Event, error) {
+	fields := bytes.Split(data, []byte{0})
+	if len(fields) == 0 || !bytes.Contains(fields[0], []byte("@")) {
+		return nil, errInvalidEvent
+	}
+
+	values := map[string]string{}
+	for _, field := range fields[1:] {
+		if key, value, found := strings.Cut(string(field), "="); found {
+			values[key] = value
+		}
+	}
+
+	if values["SUBSYSTEM"] != "block" {
+		return nil, nil
+	}
+
+	event := &Event{
+		Action:  Action(values["ACTION"]),
+		DevPath: values["DEVPATH"],
+		DevName: values["DEVNAME"],
+		DevType: values["DEVTYPE"],
+	}
+	switch event.Action {
+	case ActionAdd, ActionRemove, ActionChange:
+	default:
+		return nil, nil
+	}
+
+	if values["MAJOR"] == "" || values["MINOR"] == "" {
+		return nil, errInvalidEvent
+	}
+	event.MajorMinor = values["MAJOR"] + ":" + values["MINOR"]
+
+	return event, nil
+}
+
+// Debounce calls the handler with major:minor numbers of the devices from the events received
+// until no event is received for the period.
+func Debounce(ctx context.Context, eventCh <-chan *Event, period time.Duration, handler func(majorMinors []string)) {
+	timer := time.NewTimer(period)
+	timer.Stop()
+	defer timer.Stop()
+
+	var majorMinors []string
+	seen := map[string]struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				if len(majorMinors) != 0 {
+					handler(majorMinors)
+				}
+				return
+			}
+			if _, found := seen[event.MajorMinor]; !found {
+				seen[event.MajorMinor] = struct{}{}
+				majorMinors = append(majorMinors, event.MajorMinor)
+			}
+			timer.Reset(period)
+		case <-timer.C:
+			handler(majorMinors)
+			majorMinors = nil
+			seen = map[string]struct{}{}
+		}
+	}
+}
+
+// Listen returns the channel receiving block device uevents; the channel is closed when the context is done.
+func Listen(ctx context.Context) (<-chan *Event, error) {
+	return listen(ctx)
+}
@@ -0,0 +1,96 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+// Package uevent listens kernel uevents of block devices.
+package uevent
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func toMessage(fields ...string) []byte {
+	return []byte(strings.Join(fields, "\x00") + "\x00")
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		data          []byte
+		expectedEvent *Event
+		expectErr     bool
+	}{
+		{
+			data: toMessage("add@/devices/virtual/block/loop0", "ACTION=add", "DEVPATH=/devices/virtual/block/loop0", "SUBSYSTEM=block", "MAJOR=7", "MINOR=0", "DEVNAME=loop0", "DEVTYPE=disk", "SEQNUM=1"),
+			expectedEvent: &Event{
+				Action:     ActionAdd,
+				DevPath:    "/devices/virtual/block/loop0",
+				DevName:    "loop0",
+				DevType:    "disk",
+				MajorMinor: "7:0",
+			},
+		},
+		{
+			data: toMessage("remove@/devices/pci0000:00/block/sdb/sdb1", "ACTION=remove", "SUBSYSTEM=block", "MAJOR=8", "MINOR=17", "DEVNAME=sdb1", "DEVTYPE=partition"),
+			expectedEvent: &Event{
+				Action:     ActionRemove,
+				DevName:    "sdb1",
+				DevType:    "partition",
+				MajorMinor: "8:17",
+			},
+		},
+		{data: toMessage("add@/devices/pci0000:00/net/eth0", "ACTION=add", "SUBSYSTEM=net")},
+		{data: toMessage("bind@/devices/virtual/block/loop0", "ACTION=bind", "SUBSYSTEM=block", "MAJOR=7", "MINOR=0")},
+		{data: toMessage("change@/devices/virtual/block/loop0", "ACTION=change", "SUBSYSTEM=block"), expectErr: true},
+		{data: []byte("libudev"), expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		event, err := parse(testCase.data)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("case %v: expectErr: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if !reflect.DeepEqual(event, testCase.expectedEvent) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedEvent, event)
+		}
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	eventCh := make(chan *Event)
+	var batches [][]string
+	done := make(chan struct{})
+	go func() {
+		Debounce(context.Background(), eventCh, 50*time.Millisecond, func(majorMinors []string) {
+			batches = append(batches, majorMinors)
+		})
+		close(done)
+	}()
+
+	eventCh <- &Event{Action: ActionRemove, MajorMinor: "8:16"}
+	eventCh <- &Event{Action: ActionRemove, MajorMinor: "8:17"}
+	eventCh <- &Event{Action: ActionChange, MajorMinor: "8:16"}
+	time.Sleep(200 * time.Millisecond)
+	eventCh <- &Event{Action: ActionAdd, MajorMinor: "8:32"}
+	close(eventCh)
+	<-done
+
+	expected := [][]string{{"8:16", "8:17"}, {"8:32"}}
+	if !reflect.DeepEqual(batches, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, batches)
+	}
+}
@@ -22,12 +22,19 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/consts"
 	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/drive"
+	pkgfs "github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/journal"
+	"github.com/minio/directpv/pkg/lvm"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
 	"github.com/minio/directpv/pkg/xfs"
@@ -47,7 +54,23 @@ type volumeEventHandler struct {
 	nodeID            directpvtypes.NodeID
 	unmount           func(target string) error
 	getDeviceByFSUUID func(fsuuid string) (string, error)
-	removeQuota       func(ctx context.Context, device, path, volumeName string) error
+	getFSType         func(ctx context.Context, driveID directpvtypes.DriveID) (string, error)
+	removeQuota       func(ctx context.Context, fsType, device, path, volumeName string) error
+	getQuota          func(ctx context.Context, fsType, device, volumeName string) (*pkgfs.Quota, error)
+	setQuota          func(ctx context.Context, fsType, device, path, volumeName string, quota pkgfs.Quota) error
+	getWipeDir        func(fsuuid string) string
+	trim              func(fsuuid string) error
+	getMounts         func() (*sys.MountInfo, error)
+	remount           func(target string, readOnly bool, flags []string) error
+	updateJournal     func(ctx context.Context, driveID directpvtypes.DriveID) error
+	removeThinVolume  func(ctx context.Context, vgName, name string) error
+	startWipe         func(wipeFunc func())
+
+	// appliedFlags holds mount flags applied to staged volumes by this handler.
+	appliedFlags sync.Map
+
+	// wiping holds names of volumes being wiped in background.
+	wiping sync.Map
 }
 
 func newVolumeEventHandler(nodeID directpvtypes.NodeID) *volumeEventHandler {
@@ -57,9 +80,23 @@ func newVolumeEventHandler(nodeID directpvtypes.NodeID) *volumeEventHandler {
 			return sys.Unmount(mountPoint, true, true, false)
 		},
 		getDeviceByFSUUID: sys.GetDeviceByFSUUID,
-		removeQuota: func(ctx context.Context, device, path, volumeName string) error {
-			return xfs.SetQuota(ctx, device, path, volumeName, xfs.Quota{}, true)
+		getFSType:         drive.GetFSType,
+		removeQuota: func(ctx context.Context, fsType, device, path, volumeName string) error {
+			return pkgfs.SetQuota(ctx, fsType, device, path, volumeName, pkgfs.Quota{}, true)
+		},
+		getQuota: pkgfs.GetQuota,
+		setQuota: func(ctx context.Context, fsType, device, path, volumeName string, quota pkgfs.Quota) error {
+			return pkgfs.SetQuota(ctx, fsType, device, path, volumeName, quota, true)
+		},
+		getWipeDir: types.GetDriveWipeDir,
+		trim: func(fsuuid string) error {
+			return sys.Trim(types.GetDriveMountDir(fsuuid))
 		},
+		getMounts:        sys.NewMountInfo,
+		remount:          xfs.Remount,
+		updateJournal:    journal.Update,
+		removeThinVolume: lvm.RemoveVolume,
+		startWipe:        func(wipeFunc func()) { go wipeFunc() },
 	}
 }
 
@@ -86,13 +123,69 @@ func (handler *volumeEventHandler) Handle(ctx context.Context, eventType control
 	}
 
 	if eventType == controller.AddEvent {
-		return sync(ctx, volume)
+		if err := syncVolume(ctx, volume); err != nil {
+			return err
+		}
 	}
 
+	if err := handler.shrinkVolume(ctx, volume); err != nil {
+		return err
+	}
+
+	return handler.remountVolume(volume)
+}
+
+// remountVolume applies changed mount flags of the volume to its staging target and target paths.
+func (handler *volumeEventHandler) remountVolume(volume *types.Volume) error {
+	if !volume.IsStaged() || volume.IsSuspended() {
+		return nil
+	}
+
+	flags, _, err := xfs.ParseMountOptions(volume.Status.MountOptions)
+	if err != nil {
+		klog.ErrorS(err, "invalid mount options", "volume", volume.Name, "mountOptions", volume.Status.MountOptions)
+		return nil
+	}
+
+	value := strings.Join(flags, ",")
+	applied, found := handler.appliedFlags.Load(volume.Name)
+	switch {
+	case found && applied == value:
+		return nil
+	case !found && len(flags) == 0:
+		// Volume is mounted with default flags.
+		handler.appliedFlags.Store(volume.Name, value)
+		return nil
+	}
+
+	mountInfo, err := handler.getMounts()
+	if err != nil {
+		return err
+	}
+
+	for _, target := range []string{volume.Status.StagingTargetPath, volume.Status.TargetPath} {
+		if target == "" {
+			continue
+		}
+		entries := mountInfo.FilterByMountPoint(target).List()
+		if len(entries) == 0 {
+			continue
+		}
+		readOnly := entries[0].MountOptions.Exist("ro")
+		if err := handler.remount(target, readOnly, flags); err != nil {
+			klog.ErrorS(err, "unable to remount volume", "volume", volume.Name, "target", target, "flags", flags)
+			return err
+		}
+	}
+
+	handler.appliedFlags.Store(volume.Name, value)
+	if found {
+		client.Eventf(volume, client.EventTypeNormal, client.EventReasonVolumeRemounted, "volume is remounted with mount flags %v", flags)
+	}
 	return nil
 }
 
-func sync(ctx context.Context, volume *types.Volume) error {
+func syncVolume(ctx context.Context, volume *types.Volume) error {
 	drive, err := client.DriveClient().Get(ctx, string(volume.GetDriveID()), metav1.GetOptions{})
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -148,35 +241,38 @@ func (handler *volumeEventHandler) delete(ctx context.Context, volume *types.Vol
 		}
 	}
 
-	deletedDir := volume.Status.DataPath + ".deleted"
-	if err := os.Rename(volume.Status.DataPath, deletedDir); err != nil && !errors.Is(err, os.ErrNotExist) {
-		// FIXME: Also handle input/output error
-		klog.ErrorS(
-			err,
-			"unable to rename data path to deleted data path",
-			"volume", volume.Name,
-			"DataPath", volume.Status.DataPath,
-			"DeletedDir", deletedDir,
-		)
-		return err
-	}
-
-	go func(volumeName, deletedDir string) {
-		if err := os.RemoveAll(deletedDir); err != nil {
-			klog.ErrorS(
-				err,
-				"unable to remove deleted data path",
-				"volume", volumeName,
-				"DeletedDir", deletedDir,
-			)
+	switch {
+	case volume.GetBackend() == directpvtypes.BackendLVMThin:
+		// Thin pool discards blocks of the removed thin volume.
+		if err := handler.removeVolumeLV(ctx, volume); err != nil {
+			return err
+		}
+	case volume.Status.DataPath != "":
+		// Capacity is released to the drive only after the data is wiped.
+		completed, err := handler.wipeVolume(ctx, volume)
+		if err != nil || !completed {
+			return err
 		}
-	}(volume.Name, deletedDir)
+	}
 
 	// Release volume from associated drive.
 	if err := handler.releaseVolume(ctx, volume); err != nil {
 		return err
 	}
 
+	if err := handler.updateJournal(ctx, volume.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to update drive journal", "drive", volume.GetDriveID(), "volume", volume.Name)
+	}
+
+	// Wipe record is not required after the capacity is released; wiping again is harmless
+	// if the purge protection removal below fails.
+	if volume.Status.DataPath != "" {
+		recordFile := handler.getWipeRecordFile(volume)
+		if err := os.Remove(recordFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			klog.ErrorS(err, "unable to remove wipe record", "volume", volume.Name, "RecordFile", recordFile)
+		}
+	}
+
 	volume.RemovePurgeProtection()
 	_, err = client.VolumeClient().Update(
 		ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()},
@@ -191,6 +287,123 @@ func (handler *volumeEventHandler) delete(ctx context.Context, volume *types.Vol
 	return err
 }
 
+func (handler *volumeEventHandler) removeVolumeLV(ctx context.Context, volume *types.Volume) error {
+	drive, err := client.DriveClient().Get(
+		ctx, string(volume.GetDriveID()), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+	if drive.Status.LVM == nil {
+		return nil
+	}
+
+	if err := handler.removeThinVolume(ctx, drive.Status.LVM.VGName, volume.Name); err != nil {
+		klog.ErrorS(err, "unable to remove thin volume", "volume", volume.Name, "vg", drive.Status.LVM.VGName)
+		return err
+	}
+	return nil
+}
+
+func (handler *volumeEventHandler) getWipeRecordFile(volume *types.Volume) string {
+	return filepath.Join(handler.getWipeDir(volume.Status.FSUUID), volume.Name+".json")
+}
+
+// wipeVolume returns true if the volume data is wiped. Otherwise the wipe is started in
+// background, which completes the volume deletion after wiping the data. The wipe record
+// on the drive is the resume point of the wipe across restarts.
+func (handler *volumeEventHandler) wipeVolume(ctx context.Context, volume *types.Volume) (bool, error) {
+	recordFile := handler.getWipeRecordFile(volume)
+	record, err := readWipeRecord(recordFile)
+	if err != nil {
+		klog.ErrorS(err, "unable to read wipe record", "volume", volume.Name, "RecordFile", recordFile)
+		return false, err
+	}
+
+	if record == nil {
+		record = &wipeRecord{
+			Volume:    volume.Name,
+			Mode:      volume.GetWipeMode(),
+			DataPath:  volume.Status.DataPath,
+			Status:    wipeStatusPending,
+			StartedAt: time.Now().UTC(),
+		}
+		if err := writeWipeRecord(recordFile, record); err != nil {
+			klog.ErrorS(err, "unable to write wipe record", "volume", volume.Name, "RecordFile", recordFile)
+			return false, err
+		}
+	}
+
+	if record.Status == wipeStatusCompleted {
+		return true, nil
+	}
+
+	if _, found := handler.wiping.LoadOrStore(volume.Name, struct{}{}); found {
+		return false, nil
+	}
+
+	handler.startWipe(func() {
+		err := handler.wipeData(ctx, volume, recordFile, record)
+		handler.wiping.Delete(volume.Name)
+		if err != nil {
+			// Wipe is retried on next event or resync of the volume.
+			return
+		}
+
+		latest, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "unable to get volume after wipe", "volume", volume.Name)
+			}
+			return
+		}
+		if err := handler.delete(ctx, latest); err != nil {
+			klog.ErrorS(err, "unable to delete volume after wipe", "volume", volume.Name)
+		}
+	})
+
+	return false, nil
+}
+
+func (handler *volumeEventHandler) wipeData(ctx context.Context, volume *types.Volume, recordFile string, record *wipeRecord) error {
+	deletedDir := record.DataPath + ".deleted"
+	if err := os.Rename(record.DataPath, deletedDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		// FIXME: Also handle input/output error
+		klog.ErrorS(
+			err,
+			"unable to rename data path to deleted data path",
+			"volume", volume.Name,
+			"DataPath", record.DataPath,
+			"DeletedDir", deletedDir,
+		)
+		return err
+	}
+
+	record.Attempts++
+	err := wipe(ctx, record.Mode, deletedDir, func() error { return handler.trim(volume.Status.FSUUID) })
+	if err != nil {
+		klog.ErrorS(err, "unable to wipe deleted data path", "volume", volume.Name, "DeletedDir", deletedDir, "mode", record.Mode)
+		client.Eventf(volume, client.EventTypeWarning, client.EventReasonVolumeWipeError, "unable to wipe volume data by %v; %v", record.Mode, err)
+		record.Error = err.Error()
+		if werr := writeWipeRecord(recordFile, record); werr != nil {
+			klog.ErrorS(werr, "unable to write wipe record", "volume", volume.Name, "RecordFile", recordFile)
+		}
+		return err
+	}
+
+	completedAt := time.Now().UTC()
+	record.Status = wipeStatusCompleted
+	record.Error = ""
+	record.CompletedAt = &completedAt
+	if err := writeWipeRecord(recordFile, record); err != nil {
+		klog.ErrorS(err, "unable to write wipe record", "volume", volume.Name, "RecordFile", recordFile)
+		return err
+	}
+
+	client.Eventf(volume, client.EventTypeNormal, client.EventReasonVolumeWiped, "volume data is wiped by %v", record.Mode)
+	return nil
+}
+
 func (handler *volumeEventHandler) releaseVolume(ctx context.Context, volume *types.Volume) error {
 	drive, err := client.DriveClient().Get(
 		ctx, string(volume.GetDriveID()), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()},
@@ -201,22 +414,9 @@ func (handler *volumeEventHandler) releaseVolume(ctx context.Context, volume *ty
 
 	found := drive.RemoveVolumeFinalizer(volume.Name)
 	if found {
-		if device, err := handler.getDeviceByFSUUID(volume.Status.FSUUID); err != nil {
-			klog.ErrorS(
-				err,
-				"unable to find device by FSUUID; "+
-					"either device is removed or run command "+
-					"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
-					"on the host to reload",
-				"FSUUID", volume.Status.FSUUID)
-			client.Eventf(
-				volume, client.EventTypeWarning, client.EventReasonStageVolume,
-				"unable to find device by FSUUID %v; "+
-					"either device is removed or run command "+
-					"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
-					" on the host to reload", volume.Status.FSUUID)
-		} else if err := handler.removeQuota(ctx, device, volume.Status.DataPath, volume.Name); err != nil {
-			klog.ErrorS(err, "unable to remove quota on volume data path", "DataPath", volume.Status.DataPath)
+		// Thin volume has no project quota.
+		if volume.GetBackend() != directpvtypes.BackendLVMThin {
+			handler.removeVolumeQuota(ctx, volume)
 		}
 
 		drive.Status.FreeCapacity += volume.Status.TotalCapacity
@@ -230,6 +430,28 @@ func (handler *volumeEventHandler) releaseVolume(ctx context.Context, volume *ty
 	return err
 }
 
+func (handler *volumeEventHandler) removeVolumeQuota(ctx context.Context, volume *types.Volume) {
+	if device, err := handler.getDeviceByFSUUID(volume.Status.FSUUID); err != nil {
+		klog.ErrorS(
+			err,
+			"unable to find device by FSUUID; "+
+				"either device is removed or run command "+
+				"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
+				"on the host to reload",
+			"FSUUID", volume.Status.FSUUID)
+		client.Eventf(
+			volume, client.EventTypeWarning, client.EventReasonStageVolume,
+			"unable to find device by FSUUID %v; "+
+				"either device is removed or run command "+
+				"`sudo udevadm control --reload-rules && sudo udevadm trigger`"+
+				" on the host to reload", volume.Status.FSUUID)
+	} else if fsType, err := handler.getFSType(ctx, volume.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to get drive of volume", "volume", volume.Name, "drive", volume.GetDriveID())
+	} else if err := handler.removeQuota(ctx, fsType, device, volume.Status.DataPath, volume.Name); err != nil {
+		klog.ErrorS(err, "unable to remove quota on volume data path", "DataPath", volume.Status.DataPath)
+	}
+}
+
 // StartController starts volume controller.
 func StartController(ctx context.Context, nodeID directpvtypes.NodeID) {
 	ctrl := controller.New("volume", newVolumeEventHandler(nodeID), workerThreads, resyncPeriod)
@@ -19,13 +19,20 @@ package volume
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
 	"github.com/minio/directpv/pkg/client"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
 	"github.com/minio/directpv/pkg/controller"
+	pkgfs "github.com/minio/directpv/pkg/fs"
+	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/types"
+	"github.com/minio/directpv/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -41,7 +48,16 @@ func createFakeVolumeEventListener(nodeID directpvtypes.NodeID) *volumeEventHand
 		nodeID:            nodeID,
 		unmount:           func(_ string) error { return nil },
 		getDeviceByFSUUID: func(_ string) (string, error) { return "", nil },
-		removeQuota:       func(_ context.Context, _, _, _ string) error { return nil },
+		getFSType:         func(_ context.Context, _ directpvtypes.DriveID) (string, error) { return pkgfs.XFS, nil },
+		removeQuota:       func(_ context.Context, _, _, _, _ string) error { return nil },
+		getQuota:          func(_ context.Context, _, _, _ string) (*pkgfs.Quota, error) { return &pkgfs.Quota{}, nil },
+		setQuota:          func(_ context.Context, _, _, _, _ string, _ pkgfs.Quota) error { return nil },
+		getWipeDir:        func(_ string) string { return "" },
+		trim:              func(_ string) error { return nil },
+		getMounts:         func() (*sys.MountInfo, error) { return sys.FakeMountInfo(), nil },
+		remount:           func(_ string, _ bool, _ []string) error { return nil },
+		updateJournal:     func(_ context.Context, _ directpvtypes.DriveID) error { return nil },
+		startWipe:         func(wipeFunc func()) { wipeFunc() },
 	}
 }
 
@@ -166,6 +182,390 @@ func TestVolumeEventHandlerHandle(t *testing.T) {
 	}
 }
 
+func TestVolumeEventHandlerWipe(t *testing.T) {
+	dataDir := t.TempDir()
+	wipeDir := t.TempDir()
+
+	drive := types.NewDrive(
+		"drive-1",
+		types.DriveStatus{
+			TotalCapacity:     100 * MiB,
+			FreeCapacity:      90 * MiB,
+			AllocatedCapacity: 10 * MiB,
+			Status:            directpvtypes.DriveStatusReady,
+		},
+		"test-node",
+		"sda",
+		directpvtypes.AccessTierDefault,
+	)
+	drive.AddVolumeFinalizer("volume-1")
+
+	volume := types.NewVolume("volume-1", "fsuuid1", "test-node", "drive-1", "sda", 10*MiB)
+	volume.SetWipeMode(directpvtypes.WipeModeDiscard)
+	volume.Status.DataPath = filepath.Join(dataDir, "volume-1")
+	if err := os.MkdirAll(volume.Status.DataPath, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(volume.Status.DataPath, "object"), []byte("secret"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	now := metav1.Now()
+	volume.DeletionTimestamp = &now
+	volume.RemovePVProtection()
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive, volume))
+	client.SetDriveInterface(clientset.DirectpvLatest().DirectPVDrives())
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	handler := createFakeVolumeEventListener("test-node")
+	handler.getWipeDir = func(_ string) string { return wipeDir }
+	handler.trim = func(_ string) error { return errors.New("I/O error") }
+
+	ctx := t.Context()
+	recordFile := filepath.Join(wipeDir, "volume-1.json")
+
+	// Wipe runs in background; capacity is not released while wiping.
+	var wipeFuncs []func()
+	handler.startWipe = func(wipeFunc func()) { wipeFuncs = append(wipeFuncs, wipeFunc) }
+	for range 2 {
+		if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(wipeFuncs) != 1 {
+		t.Fatalf("expected: 1 wipe, got: %v", len(wipeFuncs))
+	}
+	record, err := readWipeRecord(recordFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record == nil || record.Status != wipeStatusPending || record.Mode != directpvtypes.WipeModeDiscard {
+		t.Fatalf("unexpected wipe record %+v", record)
+	}
+
+	// Failed wipe must keep capacity reserved and record the error.
+	wipeFuncs[0]()
+	if record, err = readWipeRecord(recordFile); err != nil {
+		t.Fatal(err)
+	}
+	if record == nil || record.Status != wipeStatusPending || record.Attempts != 1 || record.Error == "" {
+		t.Fatalf("unexpected wipe record %+v", record)
+	}
+	result, err := client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status.FreeCapacity != 90*MiB {
+		t.Fatalf("capacity must not be released before wipe completes; free capacity: %v", result.Status.FreeCapacity)
+	}
+
+	// Retried wipe after failure completes, releases capacity and removes the wipe record.
+	handler.trim = func(_ string) error { return nil }
+	if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(wipeFuncs) != 2 {
+		t.Fatalf("expected: 2 wipes, got: %v", len(wipeFuncs))
+	}
+	wipeFuncs[1]()
+	if _, err := os.Stat(recordFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("wipe record must be removed; %v", err)
+	}
+	if _, err := os.Stat(volume.Status.DataPath + ".deleted"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("deleted data path must be removed; %v", err)
+	}
+	if result, err = client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if result.Status.FreeCapacity != 100*MiB {
+		t.Fatalf("expected free capacity: %v, got: %v", 100*MiB, result.Status.FreeCapacity)
+	}
+	latest, err := client.VolumeClient().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest.Finalizers) != 0 {
+		t.Fatalf("purge protection must be removed; finalizers: %v", latest.Finalizers)
+	}
+}
+
+func TestWipeOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "object")
+	if err := os.WriteFile(name, []byte("secret"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := overwriteFiles(t.Context(), dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(make([]byte, len("secret"))) {
+		t.Fatalf("data is not overwritten; %q", data)
+	}
+	if err := wipe(t.Context(), directpvtypes.WipeModeOverwrite, dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("directory must be removed; %v", err)
+	}
+}
+
+func TestVolumeEventHandlerRemount(t *testing.T) {
+	volume := types.NewVolume("volume-1", "fsuuid1", "test-node", "drive-1", "sda", 10*MiB)
+	volume.Status.StagingTargetPath = "/path/staging"
+	volume.Status.TargetPath = "/path/target"
+
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume))
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	type remountCall struct {
+		target   string
+		readOnly bool
+		flags    string
+	}
+	var calls []remountCall
+
+	handler := createFakeVolumeEventListener("test-node")
+	handler.getMounts = func() (*sys.MountInfo, error) {
+		return sys.FakeMountInfo(
+			sys.MountEntry{MountPoint: "/path/staging", MountOptions: utils.StringSet{"rw": {}}},
+			sys.MountEntry{MountPoint: "/path/target", MountOptions: utils.StringSet{"ro": {}}},
+		), nil
+	}
+	handler.remount = func(target string, readOnly bool, flags []string) error {
+		calls = append(calls, remountCall{target, readOnly, strings.Join(flags, ",")})
+		return nil
+	}
+
+	ctx := t.Context()
+
+	// Volume with default flags is not remounted.
+	if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("unexpected remount calls %v", calls)
+	}
+
+	// Changed flags are applied to staging target and target paths.
+	volume.Status.MountOptions = []string{"nosuid", "noexec", "logbsize=256k"}
+	if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+		t.Fatal(err)
+	}
+	expected := []remountCall{
+		{"/path/staging", false, "nosuid,noexec"},
+		{"/path/target", true, "nosuid,noexec"},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, calls)
+	}
+
+	// Unchanged flags are not applied again.
+	if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("unexpected remount calls %v", calls)
+	}
+}
+
+func TestVolumeEventHandlerShrink(t *testing.T) {
+	newObjects := func(annotations map[string]string) (*types.Drive, *types.Volume) {
+		drive := types.NewDrive("drive-1", types.DriveStatus{
+			TotalCapacity:     100 * MiB,
+			FreeCapacity:      60 * MiB,
+			AllocatedCapacity: 40 * MiB,
+			Status:            directpvtypes.DriveStatusReady,
+		}, "test-node", "sda", directpvtypes.AccessTierDefault)
+		volume := types.NewVolume("volume-1", "fsuuid1", "test-node", "drive-1", "sda", 40*MiB)
+		volume.Status.DataPath = "/path/data"
+		volume.SetAnnotations(annotations)
+		drive.AddVolumeFinalizer(volume.Name)
+		return drive, volume
+	}
+
+	shrinkTo := func(value string) map[string]string {
+		return map[string]string{string(directpvtypes.ShrinkToAnnotationKey): value}
+	}
+
+	testCases := []struct {
+		annotations       map[string]string
+		currentSpace      uint64
+		expectedHardLimit uint64
+		expectedTotal     int64
+		expectedFree      int64
+	}{
+		{shrinkTo("10Mi"), 5 * MiB, 10 * MiB, 10 * MiB, 90 * MiB},
+		{shrinkTo("10Mi"), 20 * MiB, 0, 40 * MiB, 60 * MiB},
+		{shrinkTo("50Mi"), 5 * MiB, 0, 40 * MiB, 60 * MiB},
+		{shrinkTo("invalid"), 5 * MiB, 0, 40 * MiB, 60 * MiB},
+		// used space grown after the request is approved.
+		{
+			map[string]string{
+				string(directpvtypes.ShrinkToAnnotationKey):       "10Mi",
+				string(directpvtypes.ShrinkUsedAnnotationKey):     "5242880",
+				string(directpvtypes.ShrinkApprovedAnnotationKey): "10Mi",
+			},
+			20 * MiB, 0, 40 * MiB, 60 * MiB,
+		},
+	}
+
+	for i, testCase := range testCases {
+		drive, volume := newObjects(testCase.annotations)
+		driveClientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(drive))
+		client.SetDriveInterface(driveClientset.DirectpvLatest().DirectPVDrives())
+		volumeClientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume))
+		client.SetVolumeInterface(volumeClientset.DirectpvLatest().DirectPVVolumes())
+
+		var hardLimit uint64
+		handler := createFakeVolumeEventListener("test-node")
+		handler.getQuota = func(_ context.Context, _, _, _ string) (*pkgfs.Quota, error) {
+			return &pkgfs.Quota{HardLimit: 40 * MiB, SoftLimit: 40 * MiB, CurrentSpace: testCase.currentSpace}, nil
+		}
+		handler.setQuota = func(_ context.Context, _, _, _, _ string, quota pkgfs.Quota) error {
+			hardLimit = quota.HardLimit
+			return nil
+		}
+		shrinkHandler := &shrinkEventHandler{}
+
+		ctx := t.Context()
+		var err error
+		// node reports used space, controller validates and node applies approved size.
+		for _, handle := range []func(context.Context, controller.EventType, runtime.Object) error{handler.Handle, shrinkHandler.Handle, handler.Handle} {
+			if volume, err = client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := handle(ctx, controller.UpdateEvent, volume); err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+		}
+
+		if hardLimit != testCase.expectedHardLimit {
+			t.Fatalf("case %v: hard limit: expected: %v, got: %v", i+1, testCase.expectedHardLimit, hardLimit)
+		}
+
+		volume, err = client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []directpvtypes.LabelKey{directpvtypes.ShrinkToAnnotationKey, directpvtypes.ShrinkUsedAnnotationKey, directpvtypes.ShrinkApprovedAnnotationKey} {
+			if _, found := volume.GetAnnotations()[string(key)]; found {
+				t.Fatalf("case %v: %v annotation must be removed", i+1, key)
+			}
+		}
+		if volume.Status.TotalCapacity != testCase.expectedTotal {
+			t.Fatalf("case %v: volume capacity: expected: %v, got: %v", i+1, testCase.expectedTotal, volume.Status.TotalCapacity)
+		}
+
+		drive, err = client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if drive.Status.FreeCapacity != testCase.expectedFree {
+			t.Fatalf("case %v: drive free capacity: expected: %v, got: %v", i+1, testCase.expectedFree, drive.Status.FreeCapacity)
+		}
+	}
+}
+
+func TestVolumeEventHandlerShrinkRetry(t *testing.T) {
+	drive := types.NewDrive("drive-1", types.DriveStatus{
+		TotalCapacity:     100 * MiB,
+		FreeCapacity:      60 * MiB,
+		AllocatedCapacity: 40 * MiB,
+		Status:            directpvtypes.DriveStatusReady,
+	}, "test-node", "sda", directpvtypes.AccessTierDefault)
+	volume := types.NewVolume("volume-1", "fsuuid1", "test-node", "drive-1", "sda", 40*MiB)
+	volume.Status.DataPath = "/path/data"
+	volume.SetAnnotations(map[string]string{
+		string(directpvtypes.ShrinkToAnnotationKey):       "10Mi",
+		string(directpvtypes.ShrinkUsedAnnotationKey):     "5242880",
+		string(directpvtypes.ShrinkApprovedAnnotationKey): "10Mi",
+	})
+	drive.AddVolumeFinalizer(volume.Name)
+
+	driveClientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset())
+	client.SetDriveInterface(driveClientset.DirectpvLatest().DirectPVDrives())
+	volumeClientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume))
+	client.SetVolumeInterface(volumeClientset.DirectpvLatest().DirectPVVolumes())
+
+	handler := createFakeVolumeEventListener("test-node")
+	handler.getQuota = func(_ context.Context, _, _, _ string) (*pkgfs.Quota, error) {
+		return &pkgfs.Quota{HardLimit: 40 * MiB, SoftLimit: 40 * MiB, CurrentSpace: 5 * MiB}, nil
+	}
+	handler.setQuota = func(_ context.Context, _, _, _, _ string, _ pkgfs.Quota) error {
+		return nil
+	}
+
+	ctx := t.Context()
+	// drive update fails as the drive is not found.
+	if err := handler.Handle(ctx, controller.UpdateEvent, volume); err == nil {
+		t.Fatalf("expected error; but succeeded")
+	}
+	volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := volume.GetAnnotations()[string(directpvtypes.ShrinkApprovedAnnotationKey)]; !found {
+		t.Fatalf("shrink annotations must be kept for retry")
+	}
+
+	if _, err := driveClientset.DirectpvLatest().DirectPVDrives().Create(ctx, drive, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// retry twice; released capacity must be added only once.
+	for range 2 {
+		if volume, err = client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := handler.Handle(ctx, controller.UpdateEvent, volume); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	volume, err = client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := volume.GetAnnotations()[string(directpvtypes.ShrinkToAnnotationKey)]; found {
+		t.Fatalf("shrink annotations must be removed")
+	}
+	if volume.Status.TotalCapacity != 10*MiB {
+		t.Fatalf("volume capacity: expected: %v, got: %v", 10*MiB, volume.Status.TotalCapacity)
+	}
+	drive, err = client.DriveClient().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drive.Status.FreeCapacity != 90*MiB || drive.Status.AllocatedCapacity != 10*MiB {
+		t.Fatalf("drive capacity: expected: free %v, allocated %v, got: free %v, allocated %v", 90*MiB, 10*MiB, drive.Status.FreeCapacity, drive.Status.AllocatedCapacity)
+	}
+}
+
+func TestShrinkEventHandlerWaitsForUsage(t *testing.T) {
+	volume := types.NewVolume("volume-1", "fsuuid1", "test-node", "drive-1", "sda", 40*MiB)
+	volume.SetAnnotations(map[string]string{string(directpvtypes.ShrinkToAnnotationKey): "10Mi"})
+	clientset := types.NewExtFakeClientset(clientsetfake.NewSimpleClientset(volume))
+	client.SetVolumeInterface(clientset.DirectpvLatest().DirectPVVolumes())
+
+	ctx := t.Context()
+	if err := (&shrinkEventHandler{}).Handle(ctx, controller.UpdateEvent, volume); err != nil {
+		t.Fatal(err)
+	}
+	volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := volume.GetAnnotations()[string(directpvtypes.ShrinkApprovedAnnotationKey)]; found {
+		t.Fatalf("shrink must not be approved before the node reports used space")
+	}
+	if _, found := volume.GetAnnotations()[string(directpvtypes.ShrinkToAnnotationKey)]; !found {
+		t.Fatalf("shrink-to annotation must be kept")
+	}
+}
+
 func TestAbnormalDeleteEventHandle(t *testing.T) {
 	testVolumeObject := types.NewVolume("test-volume", "fsuuid1", "test-node", "test-drive", "test-drive", 100)
 	testVolumeObject.Status.DataPath = "data/path"
@@ -239,7 +639,7 @@ func TestSync(t *testing.T) {
 		t.Fatalf("Volume (%s) not found; %v", volume.Name, err)
 	}
 
-	err = sync(t.Context(), volume)
+	err = syncVolume(t.Context(), volume)
 	if err != nil {
 		t.Fatalf("unable to sync; %v", err)
 	}
@@ -0,0 +1,307 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/consts"
+	"github.com/minio/directpv/pkg/controller"
+	"github.com/minio/directpv/pkg/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// shrinkVolume handles directpv.min.io/shrink-to annotation. Used space of
+// the volume is reported to the controller by directpv.min.io/shrink-used
+// annotation. Once the controller approves the size by
+// directpv.min.io/shrink-approved annotation, the quota of the volume is
+// lowered and the freed capacity is released to the drive. The annotations
+// are removed once the request is applied or rejected.
+func (handler *volumeEventHandler) shrinkVolume(ctx context.Context, volume *types.Volume) error {
+	annotations := volume.GetAnnotations()
+	value, found := annotations[string(directpvtypes.ShrinkToAnnotationKey)]
+	if !found || volume.Status.DataPath == "" || volume.GetBackend() == directpvtypes.BackendLVMThin {
+		return nil
+	}
+
+	device, err := handler.getDeviceByFSUUID(volume.Status.FSUUID)
+	if err != nil {
+		klog.ErrorS(err, "unable to find device by FSUUID", "volume", volume.Name, "FSUUID", volume.Status.FSUUID)
+		return err
+	}
+
+	fsType, err := handler.getFSType(ctx, volume.GetDriveID())
+	if err != nil {
+		klog.ErrorS(err, "unable to get drive of volume", "volume", volume.Name, "drive", volume.GetDriveID())
+		return err
+	}
+
+	quota, err := handler.getQuota(ctx, fsType, device, volume.Name)
+	if err != nil {
+		klog.ErrorS(err, "unable to get quota of volume", "volume", volume.Name, "device", device)
+		return err
+	}
+
+	if annotations[string(directpvtypes.ShrinkApprovedAnnotationKey)] != value {
+		return reportShrinkUsage(ctx, volume, quota.CurrentSpace)
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return rejectShrink(ctx, volume, fmt.Sprintf("invalid size %v; %v", value, err))
+	}
+	size := quantity.Value()
+	// Size equal to the total capacity means the volume is already shrunk by
+	// previous attempt which failed to release the capacity to the drive.
+	if size <= 0 || size > volume.Status.TotalCapacity {
+		return rejectShrink(ctx, volume, fmt.Sprintf("invalid size %v", value))
+	}
+
+	// Used space may grow after the controller approved the request.
+	if quota.CurrentSpace > uint64(size) {
+		return rejectShrink(
+			ctx, volume,
+			fmt.Sprintf("used space %v is more than requested size %v", humanize.IBytes(quota.CurrentSpace), humanize.IBytes(uint64(size))),
+		)
+	}
+
+	quota.HardLimit = uint64(size)
+	quota.SoftLimit = uint64(size)
+	if err := handler.setQuota(ctx, fsType, device, volume.Status.DataPath, volume.Name, *quota); err != nil {
+		klog.ErrorS(err, "unable to set quota on volume data path", "volume", volume.Name, "DataPath", volume.Status.DataPath)
+		client.Eventf(volume, client.EventTypeWarning, client.EventReasonVolumeShrinkError, "unable to set quota; %v", err)
+		return err
+	}
+
+	// Shrink annotations are removed only after the drive is updated so that
+	// a failed drive update is retried on requeue.
+	updateVolume := func() error {
+		volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		volume.Status.TotalCapacity = size
+		volume.Status.UsedCapacity = int64(quota.CurrentSpace)
+		volume.Status.AvailableCapacity = size - volume.Status.UsedCapacity
+		_, err = client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateVolume); err != nil {
+		return err
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error { return syncDriveCapacity(ctx, volume.GetDriveID()) }); err != nil {
+		klog.ErrorS(err, "unable to release shrunk capacity to drive", "volume", volume.Name, "drive", volume.GetDriveID())
+		return err
+	}
+
+	removeAnnotations := func() error {
+		volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		removeShrinkAnnotations(volume)
+		_, err = client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, removeAnnotations); err != nil {
+		return err
+	}
+
+	if err := handler.updateJournal(ctx, volume.GetDriveID()); err != nil {
+		klog.ErrorS(err, "unable to update drive journal", "drive", volume.GetDriveID(), "volume", volume.Name)
+	}
+
+	client.Eventf(volume, client.EventTypeNormal, client.EventReasonVolumeShrunk, "volume is shrunk to %v", humanize.IBytes(uint64(size)))
+	return nil
+}
+
+// syncDriveCapacity recomputes allocated and free capacity of the drive from
+// the volumes in its finalizers. Unlike adding the released capacity, this is
+// safe to be retried any number of times.
+func syncDriveCapacity(ctx context.Context, driveID directpvtypes.DriveID) error {
+	drive, err := client.DriveClient().Get(ctx, string(driveID), metav1.GetOptions{TypeMeta: types.NewDriveTypeMeta()})
+	if err != nil {
+		return err
+	}
+
+	var allocatedCapacity int64
+	for _, volumeName := range drive.GetVolumes() {
+		volume, err := client.VolumeClient().Get(ctx, volumeName, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		switch {
+		case err == nil:
+			allocatedCapacity += volume.Status.TotalCapacity
+		case apierrors.IsNotFound(err):
+		default:
+			return err
+		}
+	}
+	if allocatedCapacity == drive.Status.AllocatedCapacity {
+		return nil
+	}
+
+	drive.Status.AllocatedCapacity = allocatedCapacity
+	drive.Status.FreeCapacity = drive.Status.TotalCapacity - drive.Status.AllocatedCapacity
+	_, err = client.DriveClient().Update(ctx, drive, metav1.UpdateOptions{TypeMeta: types.NewDriveTypeMeta()})
+	return err
+}
+
+// reportShrinkUsage sets the used space of the volume for the controller to
+// validate the shrink request.
+func reportShrinkUsage(ctx context.Context, volume *types.Volume, used uint64) error {
+	value := strconv.FormatUint(used, 10)
+	if volume.GetAnnotations()[string(directpvtypes.ShrinkUsedAnnotationKey)] == value {
+		return nil
+	}
+	updateFunc := func() error {
+		volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		volume.Annotations[string(directpvtypes.ShrinkUsedAnnotationKey)] = value
+		volume.Status.UsedCapacity = int64(used)
+		volume.Status.AvailableCapacity = volume.Status.TotalCapacity - volume.Status.UsedCapacity
+		_, err = client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
+}
+
+// rejectShrink removes the shrink annotations with a warning event.
+func rejectShrink(ctx context.Context, volume *types.Volume, reason string) error {
+	klog.ErrorS(nil, "volume shrink rejected", "volume", volume.Name, "reason", reason)
+	client.Eventf(volume, client.EventTypeWarning, client.EventReasonVolumeShrinkError, "volume shrink rejected; %v", reason)
+	updateFunc := func() error {
+		volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		removeShrinkAnnotations(volume)
+		_, err = client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, updateFunc)
+}
+
+func removeShrinkAnnotations(volume *types.Volume) {
+	delete(volume.Annotations, string(directpvtypes.ShrinkToAnnotationKey))
+	delete(volume.Annotations, string(directpvtypes.ShrinkUsedAnnotationKey))
+	delete(volume.Annotations, string(directpvtypes.ShrinkApprovedAnnotationKey))
+}
+
+type shrinkEventHandler struct{}
+
+func (handler *shrinkEventHandler) ListerWatcher() cache.ListerWatcher {
+	return cache.NewListWatchFromClient(
+		client.RESTClient(),
+		consts.VolumeResource,
+		"",
+		fields.Everything(),
+	)
+}
+
+func (handler *shrinkEventHandler) ObjectType() runtime.Object {
+	return &types.Volume{}
+}
+
+// Handle validates directpv.min.io/shrink-to annotation against the used
+// space reported by the node and approves the request for the node to lower
+// the quota of the volume.
+func (handler *shrinkEventHandler) Handle(ctx context.Context, eventType controller.EventType, object runtime.Object) error {
+	if eventType != controller.AddEvent && eventType != controller.UpdateEvent {
+		return nil
+	}
+
+	volume := object.(*types.Volume)
+	annotations := volume.GetAnnotations()
+	value, found := annotations[string(directpvtypes.ShrinkToAnnotationKey)]
+	if !found || !volume.GetDeletionTimestamp().IsZero() || annotations[string(directpvtypes.ShrinkApprovedAnnotationKey)] == value {
+		return nil
+	}
+
+	if volume.GetBackend() == directpvtypes.BackendLVMThin {
+		return rejectShrink(ctx, volume, "XFS filesystem of thin volume cannot be shrunk")
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return rejectShrink(ctx, volume, fmt.Sprintf("invalid size %v; %v", value, err))
+	}
+	size := quantity.Value()
+	switch {
+	case size <= 0:
+		return rejectShrink(ctx, volume, fmt.Sprintf("invalid size %v", value))
+	case size >= volume.Status.TotalCapacity:
+		return rejectShrink(
+			ctx, volume,
+			fmt.Sprintf("size %v must be less than current size %v", humanize.IBytes(uint64(size)), humanize.IBytes(uint64(volume.Status.TotalCapacity))),
+		)
+	}
+
+	usedValue, found := annotations[string(directpvtypes.ShrinkUsedAnnotationKey)]
+	if !found {
+		// Wait for the node to report the used space.
+		return nil
+	}
+	used, err := strconv.ParseUint(usedValue, 10, 64)
+	if err != nil {
+		return rejectShrink(ctx, volume, fmt.Sprintf("invalid used space %v; %v", usedValue, err))
+	}
+	if used > uint64(size) {
+		return rejectShrink(
+			ctx, volume,
+			fmt.Sprintf("used space %v is more than requested size %v", humanize.IBytes(used), humanize.IBytes(uint64(size))),
+		)
+	}
+
+	updateFunc := func() error {
+		volume, err := client.VolumeClient().Get(ctx, volume.Name, metav1.GetOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if volume.Annotations[string(directpvtypes.ShrinkToAnnotationKey)] != value {
+			return nil
+		}
+		volume.Annotations[string(directpvtypes.ShrinkApprovedAnnotationKey)] = value
+		_, err = client.VolumeClient().Update(ctx, volume, metav1.UpdateOptions{TypeMeta: types.NewVolumeTypeMeta()})
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFunc); err != nil {
+		return err
+	}
+
+	klog.V(3).InfoS("volume shrink approved", "volume", volume.Name, "size", size)
+	return nil
+}
+
+// StartShrinkController starts the controller validating shrink requests of volumes.
+func StartShrinkController(ctx context.Context) {
+	ctrl := controller.New("volume-shrink", &shrinkEventHandler{}, 5, resyncPeriod)
+	ctrl.Run(ctx)
+}
@@ -0,0 +1,152 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	directpvtypes "github.com/minio/directpv/pkg/apis/directpv.min.io/types"
+	"github.com/minio/directpv/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+const overwriteBufferSize = 1024 * 1024
+
+// wipeStatus denotes status of a volume wipe.
+type wipeStatus string
+
+const (
+	wipeStatusPending   wipeStatus = "Pending"
+	wipeStatusCompleted wipeStatus = "Completed"
+)
+
+// wipeRecord is persisted on the drive to track wipe of a deleted volume across restarts.
+type wipeRecord struct {
+	Volume      string                 `json:"volume"`
+	Mode        directpvtypes.WipeMode `json:"mode"`
+	DataPath    string                 `json:"dataPath"`
+	Status      wipeStatus             `json:"status"`
+	Attempts    int                    `json:"attempts"`
+	Error       string                 `json:"error,omitempty"`
+	StartedAt   time.Time              `json:"startedAt"`
+	CompletedAt *time.Time             `json:"completedAt,omitempty"`
+}
+
+func readWipeRecord(filename string) (*wipeRecord, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record wipeRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func writeWipeRecord(filename string, record *wipeRecord) error {
+	data, err := utils.ToJSON(record)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o750); err != nil {
+		return err
+	}
+
+	file, err := utils.NewSafeFile(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	return file.Close()
+}
+
+func overwriteFile(ctx context.Context, name string, size int64) error {
+	file, err := os.OpenFile(name, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buf := make([]byte, overwriteBufferSize)
+	for written := int64(0); written < size; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := min(int64(len(buf)), size-written)
+		if _, err := file.Write(buf[:n]); err != nil {
+			return err
+		}
+		written += n
+	}
+
+	return file.Sync()
+}
+
+func overwriteFiles(ctx context.Context, dir string) error {
+	return filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return overwriteFile(ctx, name, info.Size())
+	})
+}
+
+// wipe removes the data in dir by the mode. For discard mode, freed blocks
+// are discarded by trim. Drives not supporting discard are not treated as error.
+func wipe(ctx context.Context, mode directpvtypes.WipeMode, dir string, trim func() error) (err error) {
+	if mode == directpvtypes.WipeModeOverwrite {
+		if err = overwriteFiles(ctx, dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	if mode == directpvtypes.WipeModeDiscard {
+		if err = trim(); err != nil {
+			if !errors.Is(err, errors.ErrUnsupported) {
+				return err
+			}
+			klog.InfoS("discard is not supported by the drive; skipping", "dir", dir, "err", err)
+		}
+	}
+
+	return nil
+}
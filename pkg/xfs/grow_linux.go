@@ -0,0 +1,32 @@
+//go:build linux
+
+// This file is part of MinIO DirectPV
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package xfs
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+func grow(ctx context.Context, mountPoint string) error {
+	if output, err := exec.CommandContext(ctx, "xfs_growfs", "-d", mountPoint).CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to run xfs_growfs on %v; output=%v; %w", mountPoint, string(output), err)
+	}
+	return nil
+}
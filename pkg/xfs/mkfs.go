@@ -18,6 +18,7 @@ package xfs
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/minio/directpv/pkg/consts"
 )
@@ -25,7 +26,11 @@ import (
 // FSLabel is filesystem label.
 const FSLabel = consts.AppCapsName
 
-// MakeFS is a utility function to format a device
-func MakeFS(ctx context.Context, device, uuid string, force, reflink bool) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
-	return makeFS(ctx, device, uuid, force, reflink)
+// MakeFS is a utility function to format a device with given profile.
+func MakeFS(ctx context.Context, device, uuid string, force, reflink bool, profile Profile) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	if err = profile.Validate(); err != nil {
+		err = fmt.Errorf("invalid format profile; %w", err)
+		return
+	}
+	return makeFS(ctx, device, uuid, force, reflink, profile)
 }
@@ -24,11 +24,8 @@ import (
 	"os/exec"
 )
 
-func makeFS(ctx context.Context, device, uuid string, force, reflink bool) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
-	args := []string{"-i", "maxpct=50", "-m", fmt.Sprintf("uuid=%v", uuid)}
-	if !reflink {
-		args = append(args, "-m", "reflink=0")
-	}
+func makeFS(ctx context.Context, device, uuid string, force, reflink bool, profile Profile) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+	args := profile.args(uuid, reflink)
 	if force {
 		args = append(args, "-f")
 	}
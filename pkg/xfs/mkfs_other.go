@@ -24,7 +24,7 @@ import (
 	"runtime"
 )
 
-func makeFS(ctx context.Context, device, uuid string, force, reflink bool) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
+func makeFS(ctx context.Context, device, uuid string, force, reflink bool, profile Profile) (fsuuid, label string, totalCapacity, freeCapacity uint64, err error) {
 	err = fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 	return
 }
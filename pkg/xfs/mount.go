@@ -21,7 +21,12 @@ func Mount(device, target string) error {
 	return mount(device, target)
 }
 
-// BindMount bind-mounts source to target.
-func BindMount(source, target string, readOnly bool) error {
-	return bindMount(source, target, readOnly)
+// BindMount bind-mounts source to target and applies mount flags if any.
+func BindMount(source, target string, readOnly bool, flags []string) error {
+	return bindMount(source, target, readOnly, flags)
+}
+
+// Remount remounts bind-mounted target with mount flags.
+func Remount(target string, readOnly bool, flags []string) error {
+	return remount(target, readOnly, flags)
 }
@@ -57,6 +57,20 @@ func mount(device, target string) error {
 	return nil
 }
 
-func bindMount(source, target string, readOnly bool) error {
-	return sys.BindMount(source, target, "xfs", false, readOnly, "prjquota")
+func bindMount(source, target string, readOnly bool, flags []string) error {
+	if err := sys.BindMount(source, target, "xfs", false, readOnly, "prjquota"); err != nil {
+		return err
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+	// Mount flags of a bind mount are applied by remount only.
+	return remount(target, readOnly, flags)
+}
+
+func remount(target string, readOnly bool, flags []string) error {
+	if readOnly {
+		flags = append([]string{"ro"}, flags...)
+	}
+	return sys.RemountBind(target, flags)
 }
@@ -27,6 +27,10 @@ func mount(device, target string) error {
 	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
 
-func bindMount(source, target string, readOnly bool) error {
+func bindMount(source, target string, readOnly bool, flags []string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+func remount(target string, readOnly bool, flags []string) error {
 	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
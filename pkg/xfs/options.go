@@ -0,0 +1,133 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package xfs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// volumeMountFlags are allowed mount flags applied to bind mounts of a volume.
+var volumeMountFlags = map[string]struct{}{
+	"noatime":     {},
+	"nodiratime":  {},
+	"relatime":    {},
+	"strictatime": {},
+	"nosuid":      {},
+	"nodev":       {},
+	"noexec":      {},
+}
+
+// fsMountOptions are allowed XFS mount options with their value validators.
+// These options are filesystem wide i.e. they apply to the drive, not to a volume.
+var fsMountOptions = map[string]func(value string) error{
+	"logbsize":  validateLogBufSize,
+	"logbufs":   validateLogBufs,
+	"allocsize": validateAllocSize,
+	"largeio":   validateNoValue,
+	"nolargeio": validateNoValue,
+}
+
+func parseSize(value string) (uint64, error) {
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(value, "k"), strings.HasSuffix(value, "K"):
+		multiplier = 1024
+	case strings.HasSuffix(value, "m"), strings.HasSuffix(value, "M"):
+		multiplier = 1024 * 1024
+	case strings.HasSuffix(value, "g"), strings.HasSuffix(value, "G"):
+		multiplier = 1024 * 1024 * 1024
+	}
+	if multiplier != 1 {
+		value = value[:len(value)-1]
+	}
+	size, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return size * multiplier, nil
+}
+
+func validateNoValue(value string) error {
+	if value != "" {
+		return fmt.Errorf("value %v is not allowed", value)
+	}
+	return nil
+}
+
+func validateLogBufSize(value string) error {
+	size, err := parseSize(value)
+	if err != nil {
+		return err
+	}
+	switch size {
+	case 16 * 1024, 32 * 1024, 64 * 1024, 128 * 1024, 256 * 1024:
+		return nil
+	}
+	return fmt.Errorf("value %v must be one of 16k, 32k, 64k, 128k or 256k", value)
+}
+
+func validateLogBufs(value string) error {
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	if count < 2 || count > 8 {
+		return fmt.Errorf("value %v must be in range 2 to 8", value)
+	}
+	return nil
+}
+
+func validateAllocSize(value string) error {
+	size, err := parseSize(value)
+	if err != nil {
+		return err
+	}
+	if size < 4*1024 || size > 1024*1024*1024 || size&(size-1) != 0 {
+		return fmt.Errorf("value %v must be power of 2 in range 4k to 1g", value)
+	}
+	return nil
+}
+
+// ParseMountOptions validates mount options against allowed list and returns
+// volume mount flags and filesystem mount options.
+func ParseMountOptions(options []string) (flags, fsOptions []string, err error) {
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		if _, found := volumeMountFlags[option]; found {
+			flags = append(flags, option)
+			continue
+		}
+
+		name, value, _ := strings.Cut(option, "=")
+		validate, found := fsMountOptions[name]
+		if !found {
+			return nil, nil, fmt.Errorf("mount option %v is not allowed", option)
+		}
+		if err := validate(value); err != nil {
+			return nil, nil, fmt.Errorf("invalid mount option %v; %w", option, err)
+		}
+		fsOptions = append(fsOptions, option)
+	}
+
+	return flags, fsOptions, nil
+}
@@ -0,0 +1,62 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package xfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMountOptions(t *testing.T) {
+	testCases := []struct {
+		options           []string
+		expectedFlags     []string
+		expectedFSOptions []string
+		expectErr         bool
+	}{
+		{nil, nil, nil, false},
+		{[]string{"noatime", " nodev", "nosuid", ""}, []string{"noatime", "nodev", "nosuid"}, nil, false},
+		{[]string{"noexec", "logbsize=256k", "allocsize=64m", "largeio"}, []string{"noexec"}, []string{"logbsize=256k", "allocsize=64m", "largeio"}, false},
+		{[]string{"logbufs=8"}, nil, []string{"logbufs=8"}, false},
+		{[]string{"rw"}, nil, nil, true},
+		{[]string{"nouuid"}, nil, nil, true},
+		{[]string{"logbsize=100k"}, nil, nil, true},
+		{[]string{"allocsize=3m"}, nil, nil, true},
+		{[]string{"allocsize=2g"}, nil, nil, true},
+		{[]string{"logbufs=9"}, nil, nil, true},
+		{[]string{"largeio=1"}, nil, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		flags, fsOptions, err := ParseMountOptions(testCase.options)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error; but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(flags, testCase.expectedFlags) {
+			t.Fatalf("case %v: flags: expected: %v, got: %v", i+1, testCase.expectedFlags, flags)
+		}
+		if !reflect.DeepEqual(fsOptions, testCase.expectedFSOptions) {
+			t.Fatalf("case %v: fsOptions: expected: %v, got: %v", i+1, testCase.expectedFSOptions, fsOptions)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package xfs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultProfileName is the name of the profile used when no profile is specified.
+const DefaultProfileName = "default"
+
+// Profile denotes mkfs.xfs options to format a drive.
+type Profile struct {
+	BlockSize   string `yaml:"blockSize,omitempty" json:"blockSize,omitempty"`
+	InodeSize   string `yaml:"inodeSize,omitempty" json:"inodeSize,omitempty"`
+	LogSize     string `yaml:"logSize,omitempty" json:"logSize,omitempty"`
+	StripeUnit  string `yaml:"stripeUnit,omitempty" json:"stripeUnit,omitempty"`
+	StripeWidth uint32 `yaml:"stripeWidth,omitempty" json:"stripeWidth,omitempty"`
+	CRC         *bool  `yaml:"crc,omitempty" json:"crc,omitempty"`
+	BigTime     *bool  `yaml:"bigtime,omitempty" json:"bigtime,omitempty"`
+	InobtCount  *bool  `yaml:"inobtcount,omitempty" json:"inobtcount,omitempty"`
+	RmapBT      *bool  `yaml:"rmapbt,omitempty" json:"rmapbt,omitempty"`
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+// Profiles are built-in format profiles.
+var Profiles = map[string]Profile{
+	DefaultProfileName: {},
+	"large-log":        {LogSize: "1g"},
+	"large-inode":      {InodeSize: "1024"},
+	"bigtime":          {BigTime: boolPtr(true), InobtCount: boolPtr(true)},
+	"rmapbt":           {RmapBT: boolPtr(true)},
+}
+
+// ProfileNames returns sorted names of built-in format profiles.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isPowerOfTwo(value uint64) bool {
+	return value != 0 && value&(value-1) == 0
+}
+
+func validatePowerOfTwo(value string, minSize, maxSize uint64) error {
+	size, err := parseSize(value)
+	if err != nil {
+		return err
+	}
+	if !isPowerOfTwo(size) || size < minSize || size > maxSize {
+		return fmt.Errorf("value %v must be a power of 2 between %v and %v", value, minSize, maxSize)
+	}
+	return nil
+}
+
+// Validate validates the profile.
+func (profile Profile) Validate() error {
+	if profile.BlockSize != "" {
+		if err := validatePowerOfTwo(profile.BlockSize, 512, 64*1024); err != nil {
+			return fmt.Errorf("invalid block size; %w", err)
+		}
+	}
+
+	if profile.InodeSize != "" {
+		if err := validatePowerOfTwo(profile.InodeSize, 256, 2048); err != nil {
+			return fmt.Errorf("invalid inode size; %w", err)
+		}
+	}
+
+	if profile.LogSize != "" {
+		size, err := parseSize(profile.LogSize)
+		if err != nil {
+			return fmt.Errorf("invalid log size; %w", err)
+		}
+		if size < 4*1024*1024 || size > 2*1024*1024*1024 {
+			return fmt.Errorf("invalid log size; value %v must be between 4m and 2g", profile.LogSize)
+		}
+	}
+
+	switch {
+	case profile.StripeUnit == "" && profile.StripeWidth == 0:
+	case profile.StripeUnit == "" || profile.StripeWidth == 0:
+		return errors.New("stripe unit and stripe width must be specified together")
+	default:
+		size, err := parseSize(profile.StripeUnit)
+		if err != nil {
+			return fmt.Errorf("invalid stripe unit; %w", err)
+		}
+		if size == 0 || size%512 != 0 {
+			return fmt.Errorf("invalid stripe unit; value %v must be a multiple of 512", profile.StripeUnit)
+		}
+	}
+
+	if profile.CRC != nil && !*profile.CRC {
+		for name, value := range map[string]*bool{"bigtime": profile.BigTime, "inobtcount": profile.InobtCount, "rmapbt": profile.RmapBT} {
+			if value != nil && *value {
+				return fmt.Errorf("%v requires crc to be enabled", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func toFlag(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// args returns mkfs.xfs arguments of the profile.
+func (profile Profile) args(uuid string, reflink bool) (args []string) {
+	inodeOptions := []string{"maxpct=50"}
+	if profile.InodeSize != "" {
+		inodeOptions = append(inodeOptions, "size="+profile.InodeSize)
+	}
+	args = append(args, "-i", strings.Join(inodeOptions, ","))
+
+	metaOptions := []string{"uuid=" + uuid}
+	if profile.CRC != nil {
+		metaOptions = append(metaOptions, "crc="+toFlag(*profile.CRC))
+		// reflink is not supported without crc.
+		reflink = reflink && *profile.CRC
+	}
+	if profile.BigTime != nil {
+		metaOptions = append(metaOptions, "bigtime="+toFlag(*profile.BigTime))
+	}
+	if profile.InobtCount != nil {
+		metaOptions = append(metaOptions, "inobtcount="+toFlag(*profile.InobtCount))
+	}
+	if profile.RmapBT != nil {
+		metaOptions = append(metaOptions, "rmapbt="+toFlag(*profile.RmapBT))
+	}
+	if !reflink {
+		metaOptions = append(metaOptions, "reflink=0")
+	}
+	args = append(args, "-m", strings.Join(metaOptions, ","))
+
+	if profile.BlockSize != "" {
+		args = append(args, "-b", "size="+profile.BlockSize)
+	}
+	if profile.LogSize != "" {
+		args = append(args, "-l", "size="+profile.LogSize)
+	}
+	if profile.StripeUnit != "" {
+		args = append(args, "-d", fmt.Sprintf("su=%v,sw=%v", profile.StripeUnit, profile.StripeWidth))
+	}
+
+	return args
+}
@@ -0,0 +1,81 @@
+// This file is part of MinIO DirectPV
+// Copyright (c) 2021, 2022 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package xfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileValidate(t *testing.T) {
+	testCases := []struct {
+		profile     Profile
+		expectedErr bool
+	}{
+		{Profile{}, false},
+		{Profile{BlockSize: "4k", InodeSize: "512", LogSize: "128m"}, false},
+		{Profile{StripeUnit: "64k", StripeWidth: 4}, false},
+		{Profile{CRC: boolPtr(false)}, false},
+		{Profile{BlockSize: "3000"}, true},
+		{Profile{BlockSize: "128k"}, true},
+		{Profile{InodeSize: "128"}, true},
+		{Profile{LogSize: "1m"}, true},
+		{Profile{LogSize: "abc"}, true},
+		{Profile{StripeUnit: "64k"}, true},
+		{Profile{StripeWidth: 4}, true},
+		{Profile{StripeUnit: "1000", StripeWidth: 4}, true},
+		{Profile{CRC: boolPtr(false), BigTime: boolPtr(true)}, true},
+		{Profile{CRC: boolPtr(false), RmapBT: boolPtr(true)}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.profile.Validate()
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v; got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	for name, profile := range Profiles {
+		if err := profile.Validate(); err != nil {
+			t.Fatalf("built-in profile %v: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestProfileArgs(t *testing.T) {
+	testCases := []struct {
+		profile      Profile
+		reflink      bool
+		expectedArgs []string
+	}{
+		{Profile{}, true, []string{"-i", "maxpct=50", "-m", "uuid=u"}},
+		{Profile{}, false, []string{"-i", "maxpct=50", "-m", "uuid=u,reflink=0"}},
+		{
+			Profile{BlockSize: "4096", InodeSize: "512", LogSize: "1g", StripeUnit: "64k", StripeWidth: 4, BigTime: boolPtr(true), InobtCount: boolPtr(true), RmapBT: boolPtr(true)},
+			true,
+			[]string{"-i", "maxpct=50,size=512", "-m", "uuid=u,bigtime=1,inobtcount=1,rmapbt=1", "-b", "size=4096", "-l", "size=1g", "-d", "su=64k,sw=4"},
+		},
+		{Profile{CRC: boolPtr(false)}, true, []string{"-i", "maxpct=50", "-m", "uuid=u,crc=0,reflink=0"}},
+	}
+
+	for i, testCase := range testCases {
+		args := testCase.profile.args("u", testCase.reflink)
+		if !reflect.DeepEqual(args, testCase.expectedArgs) {
+			t.Fatalf("case %v: expected: %v; got: %v", i+1, testCase.expectedArgs, args)
+		}
+	}
+}
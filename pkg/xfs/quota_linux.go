@@ -19,13 +19,12 @@
 package xfs
 
 import (
-	"encoding/binary"
 	"math"
 	"os"
 	"syscall"
 	"unsafe"
 
-	sha256 "github.com/minio/sha256-simd"
+	"github.com/minio/directpv/pkg/sys"
 	"k8s.io/klog/v2"
 )
 
@@ -48,10 +47,6 @@ const (
 	fieldMaskBHard      = 8
 	fieldMaskBSoft      = 4
 	blockSize           = 512
-
-	fsGetAttr          = 0x801c581f // FS_IOC_FSGETXATTR
-	fsSetAttr          = 0x401c5820 // FS_IOC_FSSETXATTR
-	flagProjectInherit = 0x00000200
 )
 
 // Refer below link for more information about this structure.
@@ -81,26 +76,12 @@ type fsDiskQuota struct {
 	_               [8]byte // padding4: Yet more padding
 }
 
-type fsXAttr struct {
-	fsXXFlags uint32
-	_         uint32 // fsXExtSize
-	_         uint32 // fsXNextents
-	fsXProjID uint32
-	_         uint32  // fsXCowextSize
-	_         [8]byte // fsXPad
-}
-
-func getProjectIDHash(id string) uint32 {
-	hash := sha256.Sum256([]byte(id))
-	return binary.LittleEndian.Uint32(hash[:8])
-}
-
 func getQuota(device, volumeID string) (*Quota, error) {
 	deviceNamePtr, err := syscall.BytePtrFromString(device)
 	if err != nil {
 		return nil, err
 	}
-	projectID := int(getProjectIDHash(volumeID))
+	projectID := int(sys.GetProjectIDHash(volumeID))
 
 	result := &fsDiskQuota{}
 	_, _, errno := syscall.RawSyscall6(
@@ -123,39 +104,6 @@ func getQuota(device, volumeID string) (*Quota, error) {
 	}, nil
 }
 
-func setProjectID(path string, projectID uint32) error {
-	targetDir, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer targetDir.Close()
-
-	var fsx fsXAttr
-	_, _, errno := syscall.Syscall(
-		syscall.SYS_IOCTL,
-		targetDir.Fd(),
-		fsGetAttr,
-		uintptr(unsafe.Pointer(&fsx)),
-	)
-	if errno != 0 {
-		return os.NewSyscallError("FS_IOC_FSGETXATTR", errno)
-	}
-
-	fsx.fsXProjID = projectID
-	fsx.fsXXFlags |= uint32(flagProjectInherit)
-	_, _, errno = syscall.Syscall(
-		syscall.SYS_IOCTL,
-		targetDir.Fd(),
-		fsSetAttr,
-		uintptr(unsafe.Pointer(&fsx)),
-	)
-	if errno != 0 {
-		return os.NewSyscallError("FS_IOC_FSSETXATTR", errno)
-	}
-
-	return nil
-}
-
 func setProjectQuota(device string, projectID uint32, quota Quota) error {
 	hardLimitBlocks := uint64(math.Ceil(float64(quota.HardLimit) / blockSize))
 	softLimitBlocks := uint64(math.Ceil(float64(quota.SoftLimit) / blockSize))
@@ -191,7 +139,7 @@ func setProjectQuota(device string, projectID uint32, quota Quota) error {
 }
 
 func setQuota(device, path, volumeID string, quota Quota, update bool) error {
-	projectID := getProjectIDHash(volumeID)
+	projectID := sys.GetProjectIDHash(volumeID)
 
 	if !update {
 		if info, err := getQuota(device, volumeID); err == nil {
@@ -216,7 +164,7 @@ func setQuota(device, path, volumeID string, quota Quota, update bool) error {
 				"HardLimitSet", info.HardLimit,
 				"HardLimit", quota.HardLimit,
 			)
-		} else if err := setProjectID(path, projectID); err != nil {
+		} else if err := sys.SetProjectID(path, projectID); err != nil {
 			klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
 			return err
 		}